@@ -2,18 +2,25 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"flag"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
 	"github.com/joho/godotenv"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/job"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/repository"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/server"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
 )
 
 func main() {
@@ -33,11 +40,6 @@ func main() {
 		os.Exit(1)
 	}
 
-	if err := os.MkdirAll(config.Storage.Path+"/storage", os.ModePerm); err != nil {
-		slog.Error("could not create required storage folder", "error", err)
-		os.Exit(1)
-	}
-
 	conn, err := db.NewSqliteDatabaseConnection(config)
 
 	if err != nil {
@@ -51,9 +53,58 @@ func main() {
 
 	txFileRepo := repository.NewTxFilesRepository(ctx, conn.Db())
 
-	useCases := usecase.InitUseCases(config, fileRepo, txFileRepo)
+	linksRepo := repository.NewLinksRepository(ctx, conn.Db())
+
+	auditRepo := repository.NewAuditRepository(ctx, conn.Db())
+
+	accessTokensRepo := repository.NewAccessTokensRepository(ctx, conn.Db())
+
+	foldersRepo := repository.NewFoldersRepository(ctx, conn.Db())
+
+	versionsRepo := repository.NewVersionsRepository(ctx, conn.Db())
+
+	tagsRepo := repository.NewTagsRepository(ctx, conn.Db())
+
+	metadataRepo := repository.NewMetadataRepository(ctx, conn.Db())
+
+	starsRepo := repository.NewStarsRepository(ctx, conn.Db())
+
+	commentsRepo := repository.NewCommentsRepository(ctx, conn.Db())
 
-	fileFacade := facade.NewFileFacade(fileRepo)
+	locksRepo := repository.NewLocksRepository(ctx, conn.Db())
+
+	blobsRepo := repository.NewBlobsRepository(ctx, conn.Db())
+
+	blobDeletionsRepo := repository.NewBlobDeletionsRepository(ctx, conn.Db())
+
+	uploadReservationsRepo := repository.NewUploadReservationsRepository(ctx, conn.Db())
+
+	blobStore, mirror, err := storage.NewBlobStore(ctx, config, blobsRepo)
+
+	if err != nil {
+		slog.Error("could not initialize blob storage", "error", err, "backend", config.Storage.Backend)
+		os.Exit(1)
+	}
+
+	useCases := usecase.InitUseCases(config, blobStore, fileRepo, txFileRepo, foldersRepo, versionsRepo, locksRepo, blobDeletionsRepo, uploadReservationsRepo)
+
+	if len(os.Args) > 1 && os.Args[1] == "fsck" {
+		code := fsck(ctx, useCases, os.Args[2:])
+		conn.Close()
+		os.Exit(code)
+	}
+
+	fileFacade := facade.NewFileFacade(fileRepo, foldersRepo, versionsRepo, tagsRepo, metadataRepo, starsRepo, locksRepo)
+
+	linkFacade := facade.NewLinkFacade(fileRepo, linksRepo)
+
+	adminFacade := facade.NewAdminFacade(fileRepo, auditRepo, mirror)
+
+	accessTokenFacade := facade.NewAccessTokenFacade(accessTokensRepo)
+
+	folderFacade := facade.NewFolderFacade(foldersRepo)
+
+	commentFacade := facade.NewCommentFacade(fileRepo, commentsRepo)
 
 	if err != nil {
 		slog.Error("Error initializing database", "err", err)
@@ -75,6 +126,66 @@ func main() {
 		os.Exit(0)
 	}()
 
+	go job.Schedule(ctx, "purge-trash", time.Duration(config.Trash.PurgeIntervalMinutes)*time.Minute, func(ctx context.Context) error {
+		_, err := useCases.PurgeTrashUseCase.Execute(ctx)
+		return err
+	})
+
+	go job.Schedule(ctx, "scrub-files", time.Duration(config.Scrub.IntervalMinutes)*time.Minute, func(ctx context.Context) error {
+		_, err := useCases.ScrubFilesUseCase.Execute(ctx)
+		return err
+	})
+
+	go job.Schedule(ctx, "delete-blobs", time.Duration(config.Storage.DeletionRetryIntervalMinutes)*time.Minute, func(ctx context.Context) error {
+		_, err := useCases.DeleteBlobsUseCase.Execute(ctx)
+		return err
+	})
+
+	go job.Schedule(ctx, "fsck", time.Duration(config.Fsck.IntervalMinutes)*time.Minute, func(ctx context.Context) error {
+		_, err := useCases.ReconcileStorageUseCase.Execute(ctx, config.Fsck.Fix)
+		return err
+	})
+
+	if mirror != nil {
+		resyncMirrorUseCase := usecase.NewResyncMirrorUseCase(mirror)
+
+		go job.Schedule(ctx, "mirror-resync", time.Duration(config.Storage.Mirror.ResyncIntervalMinutes)*time.Minute, func(ctx context.Context) error {
+			_, err := resyncMirrorUseCase.Execute(ctx)
+			return err
+		})
+	}
+
 	slog.Info("Bootstraping servers")
-	server.StartApiServer(config, fileFacade, useCases)
+	server.StartApiServer(config, fileFacade, linkFacade, adminFacade, accessTokenFacade, folderFacade, commentFacade, auditRepo, blobStore, useCases)
+}
+
+// fsck reconciles the storage with the database once and prints the report, exiting with 1
+// when inconsistencies were found and left in place
+func fsck(ctx context.Context, useCases *usecase.UseCases, args []string) int {
+	flags := flag.NewFlagSet("fsck", flag.ExitOnError)
+	fix := flags.Bool("fix", false, "remove orphan blobs, flag files without content and correct sizes")
+	flags.Parse(args)
+
+	ctx = context.WithValue(ctx, chiMiddleware.RequestIDKey, uuid.NewString())
+
+	report, err := useCases.ReconcileStorageUseCase.Execute(ctx, *fix)
+
+	if err != nil {
+		slog.Error("could not reconcile storage", "error", err)
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		slog.Error("could not print storage report", "error", err)
+		return 1
+	}
+
+	if !report.Clean() && !report.Fixed {
+		return 1
+	}
+
+	return 0
 }
@@ -7,7 +7,7 @@ require (
 	github.com/joho/godotenv v1.5.1
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/testify v1.9.0
-	golang.org/x/crypto v0.22.0 // indirect
+	golang.org/x/crypto v0.22.0
 	gopkg.in/yaml.v3 v3.0.1
 )
 
@@ -15,6 +15,8 @@ require github.com/google/uuid v1.6.0
 
 require github.com/go-chi/chi/v5 v5.0.8
 
+require github.com/klauspost/compress v1.17.4
+
 require (
 	github.com/golang-migrate/migrate/v4 v4.17.1
 	github.com/lestrrat-go/jwx v1.2.29
@@ -184,7 +186,6 @@ require (
 	github.com/hashicorp/errwrap v1.1.0 // indirect
 	github.com/hashicorp/go-multierror v1.1.1 // indirect
 	github.com/hashicorp/golang-lru/v2 v2.0.7 // indirect
-	github.com/klauspost/compress v1.17.4 // indirect
 	github.com/lestrrat-go/backoff/v2 v2.0.8 // indirect
 	github.com/lestrrat-go/blackmagic v1.0.2 // indirect
 	github.com/lestrrat-go/httpcc v1.0.1 // indirect
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -148,8 +149,13 @@ func TestService(t *testing.T) {
 		assert.NoError(t, err)
 		defer file.Close()
 
+		stat, err := file.Stat()
+		assert.NoError(t, err)
+
 		body := &bytes.Buffer{}
 		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("size", strconv.FormatInt(stat.Size(), 10)))
+
 		part, err := writer.CreateFormFile("file", filepath.Base(tempFile))
 		assert.NoError(t, err)
 
@@ -0,0 +1,248 @@
+package compression
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+)
+
+type Method byte
+
+const (
+	None Method = 0
+	Zstd Method = 1
+)
+
+const (
+	magic       = "rstore-compress1"
+	headerSize  = len(magic) + 1
+	trailerSize = 8
+	// sniffSize is how much of the content http.DetectContentType looks at
+	sniffSize = 512
+)
+
+var (
+	ErrUnknownMethod = errors.New("blob was compressed with an unknown method")
+)
+
+func (m Method) String() string {
+	switch m {
+	case None:
+		return "none"
+	case Zstd:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
+
+// compressedExtensions are formats that are compressed already, so compressing them again only costs CPU
+var compressedExtensions = map[string]bool{
+	".7z": true, ".aac": true, ".apk": true, ".avi": true, ".br": true, ".bz2": true, ".docx": true,
+	".epub": true, ".flac": true, ".gif": true, ".gz": true, ".heic": true, ".jar": true, ".jpeg": true,
+	".jpg": true, ".lz4": true, ".m4a": true, ".mkv": true, ".mov": true, ".mp3": true, ".mp4": true,
+	".odt": true, ".ogg": true, ".opus": true, ".pdf": true, ".png": true, ".pptx": true, ".rar": true,
+	".tgz": true, ".webm": true, ".webp": true, ".xlsx": true, ".xz": true, ".zip": true, ".zst": true,
+}
+
+// compressedContentTypes are prefixes of sniffed content types that are compressed already
+var compressedContentTypes = []string{
+	"image/", "video/", "audio/", "font/woff", "application/zip", "application/x-gzip",
+	"application/x-rar-compressed", "application/pdf", "application/wasm",
+}
+
+// Choose picks the method for a file from its name and its first bytes. The returned reader
+// yields the whole content of src, including the bytes peeked to sniff its type
+func Choose(filename string, src io.Reader) (method Method, content io.Reader, err error) {
+	if compressedExtensions[strings.ToLower(filepath.Ext(filename))] {
+		return None, src, nil
+	}
+
+	buffered := bufio.NewReaderSize(src, sniffSize)
+
+	head, err := buffered.Peek(sniffSize)
+
+	if err != nil && err != io.EOF {
+		return None, nil, err
+	}
+
+	contentType := http.DetectContentType(head)
+
+	for _, prefix := range compressedContentTypes {
+		if strings.HasPrefix(contentType, prefix) {
+			return None, buffered, nil
+		}
+	}
+
+	return Zstd, buffered, nil
+}
+
+// Encode streams src as a blob made of a header naming the method, the content compressed with it,
+// and a trailer with the size of the content before compression, which is stored into size once src is consumed
+func Encode(src io.Reader, method Method, size *int64) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		pw.CloseWithError(encode(pw, src, method, size))
+	}()
+
+	return pr
+}
+
+func encode(dst io.Writer, src io.Reader, method Method, size *int64) error {
+	if _, err := dst.Write(append([]byte(magic), byte(method))); err != nil {
+		return err
+	}
+
+	var written int64
+	var err error
+
+	switch method {
+	case None:
+		written, err = io.Copy(dst, src)
+	case Zstd:
+		written, err = compress(dst, src)
+	default:
+		return ErrUnknownMethod
+	}
+
+	if err != nil {
+		return err
+	}
+
+	*size = written
+
+	return binary.Write(dst, binary.BigEndian, uint64(written))
+}
+
+func compress(dst io.Writer, src io.Reader) (written int64, err error) {
+	encoder, err := zstd.NewWriter(dst, zstd.WithEncoderConcurrency(1))
+
+	if err != nil {
+		return 0, err
+	}
+
+	if written, err = io.Copy(encoder, src); err != nil {
+		encoder.Close()
+		return 0, err
+	}
+
+	return written, encoder.Close()
+}
+
+// Open reads the blob stored under id as it was before compression. Blobs stored before compression
+// was enabled are read as they are. Seeking into a compressed blob decompresses it from the start
+// up to the new offset, so ranges of big blobs are served slower than the ones of plain blobs
+func Open(store storage.BlobStore, id string) (content io.ReadSeekCloser, err error) {
+	info, err := store.Stat(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := readAt(store, id, 0, int64(headerSize))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(header) < headerSize || string(header[:len(magic)]) != magic || info.Size < int64(headerSize+trailerSize) {
+		return storage.NewReader(store, id, info.Size), nil
+	}
+
+	trailer, err := readAt(store, id, info.Size-trailerSize, trailerSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(trailer) < trailerSize {
+		return nil, storage.ErrBlobCorrupted
+	}
+
+	size := int64(binary.BigEndian.Uint64(trailer))
+	payloadSize := info.Size - int64(headerSize+trailerSize)
+
+	switch Method(header[len(magic)]) {
+	case None:
+		return storage.NewOffsetReader(func(offset int64) (io.ReadCloser, error) {
+			return store.Get(id, int64(headerSize)+offset, payloadSize-offset)
+		}, size), nil
+	case Zstd:
+		return storage.NewOffsetReader(func(offset int64) (io.ReadCloser, error) {
+			return decompress(store, id, payloadSize, offset)
+		}, size), nil
+	default:
+		return nil, ErrUnknownMethod
+	}
+}
+
+// Size returns the size of the blob stored under id as it was before compression, without decompressing it
+func Size(store storage.BlobStore, id string) (size int64, err error) {
+	content, err := Open(store, id)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer content.Close()
+
+	return content.Seek(0, io.SeekEnd)
+}
+
+func decompress(store storage.BlobStore, id string, payloadSize int64, offset int64) (io.ReadCloser, error) {
+	payload, err := store.Get(id, int64(headerSize), payloadSize)
+
+	if err != nil {
+		return nil, err
+	}
+
+	decoder, err := zstd.NewReader(payload, zstd.WithDecoderConcurrency(1))
+
+	if err != nil {
+		payload.Close()
+		return nil, err
+	}
+
+	content := &decoderReadCloser{decoder: decoder, payload: payload}
+
+	if _, err := io.CopyN(io.Discard, decoder, offset); err != nil {
+		content.Close()
+		return nil, err
+	}
+
+	return content, nil
+}
+
+func readAt(store storage.BlobStore, id string, offset int64, length int64) ([]byte, error) {
+	content, err := store.Get(id, offset, length)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer content.Close()
+
+	return io.ReadAll(content)
+}
+
+type decoderReadCloser struct {
+	decoder *zstd.Decoder
+	payload io.ReadCloser
+}
+
+func (d *decoderReadCloser) Read(p []byte) (int, error) {
+	return d.decoder.Read(p)
+}
+
+func (d *decoderReadCloser) Close() error {
+	d.decoder.Close()
+	return d.payload.Close()
+}
@@ -0,0 +1,103 @@
+package compression_test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/compression"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChoose(t *testing.T) {
+	png := append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+
+	cases := []struct {
+		filename string
+		content  []byte
+		expected compression.Method
+	}{
+		{"report.csv", []byte("id,name\n1,foo\n"), compression.Zstd},
+		{"server.log", bytes.Repeat([]byte("GET / 200\n"), 1000), compression.Zstd},
+		{"photo.JPG", []byte("whatever"), compression.None},
+		{"archive.zip", []byte("PK"), compression.None},
+		{"no-extension", png, compression.None},
+	}
+
+	for _, c := range cases {
+		method, content, err := compression.Choose(c.filename, bytes.NewReader(c.content))
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, method, c.filename)
+
+		read, err := io.ReadAll(content)
+		assert.NoError(t, err)
+		assert.Equal(t, c.content, read, "the sniffed bytes must not be lost")
+	}
+}
+
+func TestEncodeAndOpen(t *testing.T) {
+	root := t.TempDir()
+
+	store, err := storage.NewLocalBlobStore(root)
+	assert.NoError(t, err)
+
+	content := strings.Repeat("2024-01-01 INFO request served in 3ms\n", 5000)
+
+	for _, method := range []compression.Method{compression.None, compression.Zstd} {
+		t.Run(method.String(), func(t *testing.T) {
+			var size int64
+
+			encoded := compression.Encode(strings.NewReader(content), method, &size)
+
+			_, err := store.Put(method.String(), encoded)
+			assert.NoError(t, err)
+			assert.NoError(t, encoded.Close())
+			assert.Equal(t, int64(len(content)), size)
+
+			reader, err := compression.Open(store, method.String())
+			assert.NoError(t, err)
+
+			defer reader.Close()
+
+			end, err := reader.Seek(0, io.SeekEnd)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(content)), end)
+
+			_, err = reader.Seek(100000, io.SeekStart)
+			assert.NoError(t, err)
+
+			read, err := io.ReadAll(reader)
+			assert.NoError(t, err)
+			assert.Equal(t, content[100000:], string(read))
+
+			_, err = reader.Seek(0, io.SeekStart)
+			assert.NoError(t, err)
+
+			read, err = io.ReadAll(reader)
+			assert.NoError(t, err)
+			assert.Equal(t, content, string(read))
+		})
+	}
+
+	t.Run("compresses text", func(t *testing.T) {
+		stat, err := os.Stat(root + "/" + compression.Zstd.String())
+		assert.NoError(t, err)
+		assert.Less(t, stat.Size(), int64(len(content)/10))
+	})
+
+	t.Run("reads blobs stored before compression", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(root+"/legacy", []byte("legacy content"), 0600))
+
+		reader, err := compression.Open(store, "legacy")
+		assert.NoError(t, err)
+
+		defer reader.Close()
+
+		read, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+		assert.Equal(t, "legacy content", string(read))
+	})
+}
@@ -0,0 +1,123 @@
+package facade
+
+import (
+	"log/slog"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+)
+
+type AdminFacade interface {
+	FindAllUsage(traceId string, page int, size int) (*entity.UsagePage, error)
+	FindTotals(traceId string) (*entity.Totals, error)
+	FindAllFiles(traceId string, userId string, page int, size int, filename string) (*entity.FilePage, error)
+	DeleteFile(traceId string, userId string, fileId string) error
+	FindAllAudit(traceId string, page int, size int) (*entity.AuditPage, error)
+	FindStorageStatus(traceId string) *entity.StorageStatus
+}
+
+type adminFacade struct {
+	filesRepository repository.FilesRepository
+	auditRepository repository.AuditRepository
+	mirror          storage.Mirror
+}
+
+// NewAdminFacade accepts a nil mirror when the storage is not mirrored
+func NewAdminFacade(filesRepository repository.FilesRepository, auditRepository repository.AuditRepository, mirror storage.Mirror) *adminFacade {
+	return &adminFacade{filesRepository: filesRepository, auditRepository: auditRepository, mirror: mirror}
+}
+
+func (af *adminFacade) FindAllUsage(traceId string, page int, size int) (*entity.UsagePage, error) {
+	if size == 0 || size > maxListSize {
+		size = maxListSize
+	}
+
+	usagePage, err := af.filesRepository.FindAllUsage(page, size)
+
+	if err != nil {
+		slog.Error("Could not list users usage", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	return usagePage, nil
+}
+
+func (af *adminFacade) FindTotals(traceId string) (*entity.Totals, error) {
+	totals, err := af.filesRepository.FindTotals()
+
+	if err != nil {
+		slog.Error("Could not find server totals", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	return totals, nil
+}
+
+func (af *adminFacade) FindAllFiles(traceId string, userId string, page int, size int, filename string) (*entity.FilePage, error) {
+	if size == 0 || size > maxListSize {
+		size = maxListSize
+	}
+
+	filesPage, err := af.filesRepository.FindAllByOwnerId(userId, page, size, filename)
+
+	if err != nil {
+		slog.Error("Could not list user files", "traceId", traceId, "userId", userId, "error", err)
+		return nil, err
+	}
+
+	return filesPage, nil
+}
+
+func (af *adminFacade) DeleteFile(traceId string, userId string, fileId string) error {
+	// only files owned by the user can be deleted on their behalf,
+	// files shared with them are reported as not found
+	_, err := findOwnedFile(af.filesRepository, traceId, userId, fileId)
+
+	if err == ErrNotFileOwner {
+		return repository.ErrFileDoesNotExists
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := af.filesRepository.Trash(userId, nil, fileId); err != nil {
+		slog.Error("Could not move file to trash", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	slog.Info("File removed on behalf of user successfully", "traceId", traceId, "userId", userId, "fileId", fileId)
+	return nil
+}
+
+func (af *adminFacade) FindAllAudit(traceId string, page int, size int) (*entity.AuditPage, error) {
+	if size == 0 || size > maxListSize {
+		size = maxListSize
+	}
+
+	auditPage, err := af.auditRepository.FindAll(page, size)
+
+	if err != nil {
+		slog.Error("Could not list admin audit", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	return auditPage, nil
+}
+
+func (af *adminFacade) FindStorageStatus(traceId string) *entity.StorageStatus {
+	if af.mirror == nil {
+		return &entity.StorageStatus{Mirrored: false, Disks: []*entity.DiskStatus{}}
+	}
+
+	status := af.mirror.Status()
+
+	for _, disk := range status.Disks {
+		if !disk.Healthy {
+			slog.Warn("Storage mirror disk is unhealthy", "traceId", traceId, "path", disk.Path, "error", disk.Error)
+		}
+	}
+
+	return status
+}
@@ -0,0 +1,171 @@
+package facade
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+)
+
+var (
+	ErrNotCommentAuthor = errors.New("only the author of the comment can perform this operation")
+)
+
+type CommentFacade interface {
+	Create(traceId string, requesterId string, groups []string, fileId string, parentId string, content string) (*entity.Comment, error)
+	FindAll(traceId string, requesterId string, groups []string, fileId string) ([]*entity.Comment, error)
+	Update(traceId string, requesterId string, groups []string, fileId string, commentId string, content string) (*entity.Comment, error)
+	Delete(traceId string, requesterId string, groups []string, fileId string, commentId string) error
+}
+
+type commentFacade struct {
+	filesRepository    repository.FilesRepository
+	commentsRepository repository.CommentsRepository
+}
+
+func NewCommentFacade(filesRepository repository.FilesRepository, commentsRepository repository.CommentsRepository) *commentFacade {
+	return &commentFacade{filesRepository: filesRepository, commentsRepository: commentsRepository}
+}
+
+// Create adds a comment to the file, or a reply to parentId when it is sent
+func (cf *commentFacade) Create(traceId string, requesterId string, groups []string, fileId string, parentId string, content string) (*entity.Comment, error) {
+	if _, err := cf.findCommentableFile(traceId, requesterId, groups, fileId); err != nil {
+		return nil, err
+	}
+
+	if parentId != "" {
+		if _, err := cf.findComment(traceId, fileId, parentId); err != nil {
+			return nil, err
+		}
+	}
+
+	comment := entity.NewComment(fileId, parentId, content, requesterId)
+
+	if err := cf.commentsRepository.Save(comment); err != nil {
+		slog.Error("Could not save comment", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Comment created successfully", "traceId", traceId, "fileId", fileId, "commentId", comment.CommentId)
+	return comment, nil
+}
+
+// FindAll returns the threads of the file, each root comment carrying its replies
+func (cf *commentFacade) FindAll(traceId string, requesterId string, groups []string, fileId string) ([]*entity.Comment, error) {
+	if _, err := cf.findCommentableFile(traceId, requesterId, groups, fileId); err != nil {
+		return nil, err
+	}
+
+	comments, err := cf.commentsRepository.FindAllByFileId(fileId)
+
+	if err != nil {
+		slog.Error("Could not list comments", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	return threads(comments), nil
+}
+
+func (cf *commentFacade) Update(traceId string, requesterId string, groups []string, fileId string, commentId string, content string) (*entity.Comment, error) {
+	if _, err := cf.findCommentableFile(traceId, requesterId, groups, fileId); err != nil {
+		return nil, err
+	}
+
+	comment, err := cf.findComment(traceId, fileId, commentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.CreatedBy != requesterId {
+		slog.Info("Requester is not the author of the comment", "traceId", traceId, "commentId", commentId)
+		return nil, ErrNotCommentAuthor
+	}
+
+	comment.Content = content
+
+	if err := cf.commentsRepository.Update(comment); err != nil {
+		slog.Error("Could not update comment", "traceId", traceId, "commentId", commentId, "error", err)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// Delete can be done by the author of the comment or by the owner of the file, and removes the replies along with it
+func (cf *commentFacade) Delete(traceId string, requesterId string, groups []string, fileId string, commentId string) error {
+	file, err := cf.findCommentableFile(traceId, requesterId, groups, fileId)
+
+	if err != nil {
+		return err
+	}
+
+	comment, err := cf.findComment(traceId, fileId, commentId)
+
+	if err != nil {
+		return err
+	}
+
+	if comment.CreatedBy != requesterId && file.Owner != requesterId {
+		slog.Info("Requester cannot delete the comment", "traceId", traceId, "commentId", commentId)
+		return ErrNotCommentAuthor
+	}
+
+	if err := cf.commentsRepository.Delete(commentId); err != nil {
+		slog.Error("Could not delete comment", "traceId", traceId, "commentId", commentId, "error", err)
+		return err
+	}
+
+	slog.Info("Comment deleted successfully", "traceId", traceId, "fileId", fileId, "commentId", commentId)
+	return nil
+}
+
+// findCommentableFile applies the same visibility as finding the file by its ID, except that
+// the comments of a secret file are only seen by its owner, even if it was shared before being made secret
+func (cf *commentFacade) findCommentableFile(traceId string, requesterId string, groups []string, fileId string) (*entity.File, error) {
+	file, err := cf.filesRepository.FindById(requesterId, groups, fileId)
+
+	if err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if file.Secret && file.Owner != requesterId {
+		slog.Info("Refusing comments of secret file to non owner", "traceId", traceId, "fileId", fileId)
+		return nil, repository.ErrFileDoesNotExists
+	}
+
+	return file, nil
+}
+
+func (cf *commentFacade) findComment(traceId string, fileId string, commentId string) (*entity.Comment, error) {
+	comment, err := cf.commentsRepository.FindById(fileId, commentId)
+
+	if err != nil {
+		slog.Error("Could not find comment", "traceId", traceId, "fileId", fileId, "commentId", commentId, "error", err)
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// threads nests every comment under the one it replies to. Comments must come ordered by creation,
+// so a parent is always seen before its replies
+func threads(comments []*entity.Comment) []*entity.Comment {
+	roots := []*entity.Comment{}
+	byId := make(map[string]*entity.Comment, len(comments))
+
+	for _, comment := range comments {
+		byId[comment.CommentId] = comment
+
+		if parent, ok := byId[comment.ParentId]; ok {
+			parent.Replies = append(parent.Replies, comment)
+			continue
+		}
+
+		roots = append(roots, comment)
+	}
+
+	return roots
+}
@@ -1,7 +1,9 @@
 package facade
 
 import (
+	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
@@ -9,40 +11,188 @@ import (
 
 const maxListSize = 50
 
+var (
+	ErrNotFileOwner             = errors.New("only the owner of the file can perform this operation")
+	ErrNotFileEditor            = errors.New("only the owner or editors of the file can perform this operation")
+	ErrSecretFileCannotBeShared = errors.New("secret files cannot be shared with other users")
+	ErrCannotGrantOwner         = errors.New("the owner of the file cannot receive permissions over it")
+	ErrNotLockHolder            = errors.New("only the lock holder or the owner of the file can unlock it")
+)
+
 type FileFacade interface {
-	FindById(requesterId string, fileId string) (*entity.File, error)
-	DeleteById(traceId string, requesterId string, fileId string) error
-	FindAll(traceId string, requesterId string, page int, size int, filename string, secret bool) (*entity.FilePage, error)
+	FindById(requesterId string, groups []string, fileId string) (*entity.File, error)
+	FindByName(requesterId string, folderId string, filename string) (*entity.File, error)
+	FindVersions(traceId string, requesterId string, groups []string, fileId string) ([]*entity.FileVersion, error)
+	FindVersion(traceId string, requesterId string, groups []string, fileId string, version int64) (*entity.FileVersion, error)
+	DeleteById(traceId string, requesterId string, groups []string, fileId string) error
+	FindAll(traceId string, requesterId string, groups []string, page int, size int, filename string, secret bool, folderId string, tag string,
+		metadata map[string]string, starred bool) (*entity.FilePage, error)
+	FindAllShared(traceId string, requesterId string, groups []string, page int, size int) (*entity.FilePage, error)
+	FindAllTrashed(traceId string, requesterId string, page int, size int) (*entity.FilePage, error)
+	Restore(traceId string, requesterId string, fileId string) (*entity.File, error)
+	Move(traceId string, requesterId string, groups []string, fileId string, folderId string) (*entity.File, error)
+	AddTag(traceId string, requesterId string, groups []string, fileId string, name string) (*entity.File, error)
+	RemoveTag(traceId string, requesterId string, groups []string, fileId string, name string) error
+	FindAllTags(traceId string, requesterId string, groups []string) ([]*entity.Tag, error)
+	UpdateMetadata(traceId string, requesterId string, groups []string, fileId string, metadata map[string]string) (*entity.File, error)
+	Star(traceId string, requesterId string, groups []string, fileId string) error
+	Unstar(traceId string, requesterId string, fileId string) error
+	Lock(traceId string, requesterId string, groups []string, fileId string, duration time.Duration) (*entity.Lock, error)
+	Unlock(traceId string, requesterId string, groups []string, fileId string) error
+	FindPermissions(traceId string, requesterId string, fileId string) (*entity.File, error)
+	GrantPermission(traceId string, requesterId string, fileId string, userId string, permission string) (*entity.File, error)
+	RevokePermission(traceId string, requesterId string, fileId string, userId string) error
+	GrantGroupPermission(traceId string, requesterId string, fileId string, group string, permission string) (*entity.File, error)
+	RevokeGroupPermission(traceId string, requesterId string, fileId string, group string) error
 }
 
 type fileFacade struct {
-	filesRepository repository.FilesRepository
+	filesRepository    repository.FilesRepository
+	foldersRepository  repository.FoldersRepository
+	versionsRepository repository.VersionsRepository
+	tagsRepository     repository.TagsRepository
+	metadataRepository repository.MetadataRepository
+	starsRepository    repository.StarsRepository
+	locksRepository    repository.LocksRepository
+}
+
+func NewFileFacade(filesRepository repository.FilesRepository, foldersRepository repository.FoldersRepository,
+	versionsRepository repository.VersionsRepository, tagsRepository repository.TagsRepository,
+	metadataRepository repository.MetadataRepository, starsRepository repository.StarsRepository,
+	locksRepository repository.LocksRepository) *fileFacade {
+	return &fileFacade{
+		filesRepository:    filesRepository,
+		foldersRepository:  foldersRepository,
+		versionsRepository: versionsRepository,
+		tagsRepository:     tagsRepository,
+		metadataRepository: metadataRepository,
+		starsRepository:    starsRepository,
+		locksRepository:    locksRepository,
+	}
+}
+
+func (ff *fileFacade) FindById(requesterId string, groups []string, fileId string) (*entity.File, error) {
+	file, err := ff.filesRepository.FindById(requesterId, groups, fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := ff.tagsRepository.FindAllByFileId(fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	metadata, err := ff.metadataRepository.FindByFileId(fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	starred, err := ff.starsRepository.Exists(fileId, requesterId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := ff.locksRepository.FindByFileId(fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	file.Tags = tags
+	file.Metadata = metadata
+	file.Starred = starred
+	file.Lock = lock
+	return file, nil
 }
 
-func NewFileFacade(filesRepository repository.FilesRepository) *fileFacade {
-	return &fileFacade{filesRepository: filesRepository}
+// FindByName looks for a file of the requester with the same name in the same folder,
+// which is how uploads tell a new version apart from a new file
+func (ff *fileFacade) FindByName(requesterId string, folderId string, filename string) (*entity.File, error) {
+	return ff.filesRepository.FindByName(requesterId, folderId, filename)
 }
 
-func (ff *fileFacade) FindById(requesterId string, fileId string) (*entity.File, error) {
-	return ff.filesRepository.FindById(requesterId, fileId)
+func (ff *fileFacade) FindVersions(traceId string, requesterId string, groups []string, fileId string) ([]*entity.FileVersion, error) {
+	if _, err := ff.filesRepository.FindById(requesterId, groups, fileId); err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	versions, err := ff.versionsRepository.FindAllByFileId(fileId)
+
+	if err != nil {
+		slog.Error("Could not list file versions", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	return versions, nil
 }
 
-func (ff *fileFacade) DeleteById(traceId string, requesterId string, fileId string) error {
-	if err := ff.filesRepository.Delete(requesterId, fileId); err != nil {
-		slog.Error("Could not delete file in database:", "traceId", traceId, "fileId", fileId, "error", err)
+func (ff *fileFacade) FindVersion(traceId string, requesterId string, groups []string, fileId string, version int64) (*entity.FileVersion, error) {
+	if _, err := ff.filesRepository.FindById(requesterId, groups, fileId); err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	return ff.versionsRepository.FindByVersion(fileId, version)
+}
+
+// DeleteById looks at the lock only once the requester is known to be able to edit the file,
+// so that users without access cannot tell whether it is locked
+func (ff *fileFacade) DeleteById(traceId string, requesterId string, groups []string, fileId string) error {
+	if _, err := findEditableFile(ff.filesRepository, traceId, requesterId, groups, fileId); err != nil {
+		return err
+	}
+
+	if err := checkLock(ff.locksRepository, traceId, requesterId, fileId); err != nil {
 		return err
 	}
 
-	slog.Info("File removed successfully:", "traceId", traceId, "fileId", fileId)
+	if err := ff.filesRepository.Trash(requesterId, groups, fileId); err != nil {
+		slog.Error("Could not move file to trash:", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	slog.Info("File moved to trash successfully:", "traceId", traceId, "fileId", fileId)
 	return nil
 }
 
-func (ff *fileFacade) FindAll(traceId string, requesterId string, page int, size int, filename string, secret bool) (*entity.FilePage, error) {
+func (ff *fileFacade) FindAllTrashed(traceId string, requesterId string, page int, size int) (*entity.FilePage, error) {
 	if size == 0 || size > maxListSize {
 		size = maxListSize
 	}
 
-	filesPage, err := ff.filesRepository.FindAll(requesterId, page, size, filename, secret)
+	filesPage, err := ff.filesRepository.FindAllTrashed(requesterId, page, size)
+
+	if err != nil {
+		slog.Error("Could not list trashed files", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	return filesPage, nil
+}
+
+// Restore is restricted to the owner, since the trash only lists the files of the requester
+func (ff *fileFacade) Restore(traceId string, requesterId string, fileId string) (*entity.File, error) {
+	if err := ff.filesRepository.Restore(requesterId, fileId); err != nil {
+		slog.Info("Could not restore file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("File restored successfully", "traceId", traceId, "fileId", fileId)
+	return ff.filesRepository.FindById(requesterId, nil, fileId)
+}
+
+func (ff *fileFacade) FindAll(traceId string, requesterId string, groups []string, page int, size int, filename string, secret bool, folderId string, tag string,
+	metadata map[string]string, starred bool) (*entity.FilePage, error) {
+	if size == 0 || size > maxListSize {
+		size = maxListSize
+	}
+
+	filesPage, err := ff.filesRepository.FindAll(requesterId, groups, page, size, filename, secret, folderId, entity.NormalizeTag(tag), metadata, starred)
 
 	if err != nil {
 		slog.Error("Could not list files", "traceId", traceId, "error", err)
@@ -51,3 +201,320 @@ func (ff *fileFacade) FindAll(traceId string, requesterId string, page int, size
 
 	return filesPage, nil
 }
+
+func (ff *fileFacade) FindAllShared(traceId string, requesterId string, groups []string, page int, size int) (*entity.FilePage, error) {
+	if size == 0 || size > maxListSize {
+		size = maxListSize
+	}
+
+	filesPage, err := ff.filesRepository.FindAllShared(requesterId, groups, page, size)
+
+	if err != nil {
+		slog.Error("Could not list shared files", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	return filesPage, nil
+}
+
+// Move places the file inside folderId, or at the top level when folderId is empty.
+// Folders belong to a single user, so the target must be one of the file owner's folders
+func (ff *fileFacade) Move(traceId string, requesterId string, groups []string, fileId string, folderId string) (*entity.File, error) {
+	file, err := ff.filesRepository.FindById(requesterId, groups, fileId)
+
+	if err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if err := checkLock(ff.locksRepository, traceId, requesterId, fileId); err != nil {
+		return nil, err
+	}
+
+	if folderId != "" {
+		if _, err := ff.foldersRepository.FindById(file.Owner, folderId); err != nil {
+			slog.Info("Target folder not found for file owner", "traceId", traceId, "folderId", folderId, "error", err)
+			return nil, err
+		}
+	}
+
+	file.ParentId = folderId
+
+	updated, err := ff.filesRepository.UpdateParent(requesterId, groups, file)
+
+	if err != nil {
+		slog.Error("Could not move file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if !updated {
+		slog.Info("Requester cannot edit file", "traceId", traceId, "fileId", fileId)
+		return nil, ErrNotFileEditor
+	}
+
+	slog.Info("File moved successfully", "traceId", traceId, "fileId", fileId, "folderId", folderId)
+	return file, nil
+}
+
+// AddTag can be done by anyone that can edit the file, and the tag is seen by everyone that can see it
+func (ff *fileFacade) AddTag(traceId string, requesterId string, groups []string, fileId string, name string) (*entity.File, error) {
+	if _, err := findEditableFile(ff.filesRepository, traceId, requesterId, groups, fileId); err != nil {
+		return nil, err
+	}
+
+	if err := ff.tagsRepository.Save(fileId, entity.NormalizeTag(name), requesterId); err != nil {
+		slog.Error("Could not save file tag", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("File tagged successfully", "traceId", traceId, "fileId", fileId)
+	return ff.FindById(requesterId, groups, fileId)
+}
+
+func (ff *fileFacade) RemoveTag(traceId string, requesterId string, groups []string, fileId string, name string) error {
+	if _, err := findEditableFile(ff.filesRepository, traceId, requesterId, groups, fileId); err != nil {
+		return err
+	}
+
+	if err := ff.tagsRepository.Delete(fileId, entity.NormalizeTag(name)); err != nil {
+		slog.Info("Could not remove file tag", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	slog.Info("File tag removed successfully", "traceId", traceId, "fileId", fileId)
+	return nil
+}
+
+func (ff *fileFacade) FindAllTags(traceId string, requesterId string, groups []string) ([]*entity.Tag, error) {
+	tags, err := ff.tagsRepository.FindAll(requesterId, groups)
+
+	if err != nil {
+		slog.Error("Could not list tags", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	return tags, nil
+}
+
+// UpdateMetadata replaces the whole metadata of the file, so an empty map clears it
+func (ff *fileFacade) UpdateMetadata(traceId string, requesterId string, groups []string, fileId string, metadata map[string]string) (*entity.File, error) {
+	if _, err := findEditableFile(ff.filesRepository, traceId, requesterId, groups, fileId); err != nil {
+		return nil, err
+	}
+
+	if err := checkLock(ff.locksRepository, traceId, requesterId, fileId); err != nil {
+		return nil, err
+	}
+
+	if err := ff.metadataRepository.Replace(fileId, metadata); err != nil {
+		slog.Error("Could not save file metadata", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("File metadata updated successfully", "traceId", traceId, "fileId", fileId, "entries", len(metadata))
+	return ff.FindById(requesterId, groups, fileId)
+}
+
+// Star marks the file as a favourite of the requester only, so every viewer of a shared file has their own
+func (ff *fileFacade) Star(traceId string, requesterId string, groups []string, fileId string) error {
+	if _, err := ff.filesRepository.FindById(requesterId, groups, fileId); err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	if err := ff.starsRepository.Save(fileId, requesterId); err != nil {
+		slog.Error("Could not star file", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// Unstar does not check access to the file, so a star can still be removed after the file stops being shared
+func (ff *fileFacade) Unstar(traceId string, requesterId string, fileId string) error {
+	if err := ff.starsRepository.Delete(fileId, requesterId); err != nil {
+		slog.Error("Could not unstar file", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// Lock can be taken by anyone that can edit the file. Locking it again before it expires extends the lock
+func (ff *fileFacade) Lock(traceId string, requesterId string, groups []string, fileId string, duration time.Duration) (*entity.Lock, error) {
+	if _, err := findEditableFile(ff.filesRepository, traceId, requesterId, groups, fileId); err != nil {
+		return nil, err
+	}
+
+	lock := entity.NewLock(fileId, requesterId, duration)
+
+	if err := ff.locksRepository.Save(lock); err != nil {
+		slog.Info("Could not lock file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("File locked successfully", "traceId", traceId, "fileId", fileId, "expiresAt", lock.ExpiresAt)
+	return lock, nil
+}
+
+// Unlock releases the lock of the holder, and lets the owner of the file break a lock held by someone else
+func (ff *fileFacade) Unlock(traceId string, requesterId string, groups []string, fileId string) error {
+	file, err := ff.filesRepository.FindById(requesterId, groups, fileId)
+
+	if err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	lock, err := ff.locksRepository.FindByFileId(fileId)
+
+	if err != nil {
+		slog.Error("Could not find file lock", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	if lock == nil {
+		return repository.ErrLockDoesNotExists
+	}
+
+	if lock.LockedBy != requesterId && file.Owner != requesterId {
+		slog.Info("Requester cannot unlock file", "traceId", traceId, "fileId", fileId)
+		return ErrNotLockHolder
+	}
+
+	if err := ff.locksRepository.Delete(fileId); err != nil {
+		slog.Error("Could not unlock file", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	slog.Info("File unlocked successfully", "traceId", traceId, "fileId", fileId, "lockedBy", lock.LockedBy)
+	return nil
+}
+
+// FindPermissions returns the file with the users and groups it is shared with, which only its owner can see
+func (ff *fileFacade) FindPermissions(traceId string, requesterId string, fileId string) (*entity.File, error) {
+	return findOwnedFile(ff.filesRepository, traceId, requesterId, fileId)
+}
+
+func (ff *fileFacade) GrantPermission(traceId string, requesterId string, fileId string, userId string, permission string) (*entity.File, error) {
+	file, err := findOwnedFile(ff.filesRepository, traceId, requesterId, fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Secret {
+		slog.Info("Refusing to share secret file", "traceId", traceId, "fileId", fileId)
+		return nil, ErrSecretFileCannotBeShared
+	}
+
+	if userId == file.Owner {
+		return nil, ErrCannotGrantOwner
+	}
+
+	if err := ff.filesRepository.SavePermission(fileId, userId, permission); err != nil {
+		slog.Error("Could not save file permission", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("File permission granted successfully", "traceId", traceId, "fileId", fileId, "userId", userId, "permission", permission)
+	return ff.filesRepository.FindById(requesterId, nil, fileId)
+}
+
+func (ff *fileFacade) RevokePermission(traceId string, requesterId string, fileId string, userId string) error {
+	if _, err := findOwnedFile(ff.filesRepository, traceId, requesterId, fileId); err != nil {
+		return err
+	}
+
+	if err := ff.filesRepository.DeletePermission(fileId, userId); err != nil {
+		slog.Error("Could not delete file permission", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	slog.Info("File permission revoked successfully", "traceId", traceId, "fileId", fileId, "userId", userId)
+	return nil
+}
+
+func (ff *fileFacade) GrantGroupPermission(traceId string, requesterId string, fileId string, group string, permission string) (*entity.File, error) {
+	file, err := findOwnedFile(ff.filesRepository, traceId, requesterId, fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Secret {
+		slog.Info("Refusing to share secret file", "traceId", traceId, "fileId", fileId)
+		return nil, ErrSecretFileCannotBeShared
+	}
+
+	if err := ff.filesRepository.SaveGroupPermission(fileId, group, permission); err != nil {
+		slog.Error("Could not save file group permission", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("File group permission granted successfully", "traceId", traceId, "fileId", fileId, "group", group, "permission", permission)
+	return ff.filesRepository.FindById(requesterId, nil, fileId)
+}
+
+func (ff *fileFacade) RevokeGroupPermission(traceId string, requesterId string, fileId string, group string) error {
+	if _, err := findOwnedFile(ff.filesRepository, traceId, requesterId, fileId); err != nil {
+		return err
+	}
+
+	if err := ff.filesRepository.DeleteGroupPermission(fileId, group); err != nil {
+		slog.Error("Could not delete file group permission", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	slog.Info("File group permission revoked successfully", "traceId", traceId, "fileId", fileId, "group", group)
+	return nil
+}
+
+func findOwnedFile(filesRepository repository.FilesRepository, traceId string, requesterId string, fileId string) (*entity.File, error) {
+	file, err := filesRepository.FindById(requesterId, nil, fileId)
+
+	if err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if file.Owner != requesterId {
+		slog.Info("Requester is not the owner of the file", "traceId", traceId, "fileId", fileId)
+		return nil, ErrNotFileOwner
+	}
+
+	return file, nil
+}
+
+func findEditableFile(filesRepository repository.FilesRepository, traceId string, requesterId string, groups []string, fileId string) (*entity.File, error) {
+	file, err := filesRepository.FindById(requesterId, groups, fileId)
+
+	if err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if !file.CanEdit(requesterId, groups) {
+		slog.Info("Requester cannot edit file", "traceId", traceId, "fileId", fileId)
+		return nil, ErrNotFileEditor
+	}
+
+	return file, nil
+}
+
+// checkLock returns repository.ErrFileLocked when the file is locked by someone other than the requester
+func checkLock(locksRepository repository.LocksRepository, traceId string, requesterId string, fileId string) error {
+	lock, err := locksRepository.FindByFileId(fileId)
+
+	if err != nil {
+		slog.Error("Could not find file lock", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	if lock.Blocks(requesterId) {
+		slog.Info("Refusing to change file locked by another user", "traceId", traceId, "fileId", fileId)
+		return repository.ErrFileLocked
+	}
+
+	return nil
+}
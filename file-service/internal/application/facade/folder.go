@@ -0,0 +1,144 @@
+package facade
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+)
+
+var (
+	ErrFolderNotEmpty = errors.New("folder must be empty to be removed")
+)
+
+type FolderFacade interface {
+	Create(traceId string, requesterId string, name string, parentId string) (*entity.Folder, error)
+	FindById(traceId string, requesterId string, folderId string) (*entity.Folder, error)
+	FindChildren(traceId string, requesterId string, folderId string) ([]*entity.Folder, error)
+	Rename(traceId string, requesterId string, folderId string, name string) (*entity.Folder, error)
+	Delete(traceId string, requesterId string, folderId string) error
+}
+
+type folderFacade struct {
+	foldersRepository repository.FoldersRepository
+}
+
+func NewFolderFacade(foldersRepository repository.FoldersRepository) *folderFacade {
+	return &folderFacade{foldersRepository: foldersRepository}
+}
+
+func (ff *folderFacade) Create(traceId string, requesterId string, name string, parentId string) (*entity.Folder, error) {
+	if parentId != "" {
+		if _, err := ff.findFolder(traceId, requesterId, parentId); err != nil {
+			return nil, err
+		}
+	}
+
+	folder := entity.NewFolder(name, parentId, requesterId)
+
+	if err := ff.foldersRepository.Save(folder); err != nil {
+		slog.Error("Could not save folder", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Folder created successfully", "traceId", traceId, "folderId", folder.FolderId)
+	return folder, nil
+}
+
+func (ff *folderFacade) FindById(traceId string, requesterId string, folderId string) (*entity.Folder, error) {
+	folder, err := ff.findFolder(traceId, requesterId, folderId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	path, err := ff.foldersRepository.FindPath(folderId)
+
+	if err != nil {
+		slog.Error("Could not find folder path", "traceId", traceId, "folderId", folderId, "error", err)
+		return nil, err
+	}
+
+	folder.Path = path
+	return folder, nil
+}
+
+// FindChildren lists the folders directly inside folderId, or the top level folders
+// when folderId is empty
+func (ff *folderFacade) FindChildren(traceId string, requesterId string, folderId string) ([]*entity.Folder, error) {
+	if folderId != "" {
+		if _, err := ff.findFolder(traceId, requesterId, folderId); err != nil {
+			return nil, err
+		}
+	}
+
+	folders, err := ff.foldersRepository.FindAllByParentId(requesterId, folderId)
+
+	if err != nil {
+		slog.Error("Could not list folders", "traceId", traceId, "folderId", folderId, "error", err)
+		return nil, err
+	}
+
+	return folders, nil
+}
+
+func (ff *folderFacade) Rename(traceId string, requesterId string, folderId string, name string) (*entity.Folder, error) {
+	folder, err := ff.findFolder(traceId, requesterId, folderId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	folder.Name = name
+
+	if err := ff.foldersRepository.Update(requesterId, folder); err != nil {
+		slog.Error("Could not rename folder", "traceId", traceId, "folderId", folderId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Folder renamed successfully", "traceId", traceId, "folderId", folderId)
+	return folder, nil
+}
+
+func (ff *folderFacade) Delete(traceId string, requesterId string, folderId string) error {
+	if _, err := ff.findFolder(traceId, requesterId, folderId); err != nil {
+		return err
+	}
+
+	children, err := ff.foldersRepository.CountChildren(folderId)
+
+	if err != nil {
+		slog.Error("Could not count folder children", "traceId", traceId, "folderId", folderId, "error", err)
+		return err
+	}
+
+	if children > 0 {
+		slog.Info("Refusing to remove folder that is not empty", "traceId", traceId, "folderId", folderId)
+		return ErrFolderNotEmpty
+	}
+
+	if err := ff.foldersRepository.Delete(requesterId, folderId); err != nil {
+		slog.Error("Could not remove folder", "traceId", traceId, "folderId", folderId, "error", err)
+		return err
+	}
+
+	slog.Info("Folder removed successfully", "traceId", traceId, "folderId", folderId)
+	return nil
+}
+
+func (ff *folderFacade) findFolder(traceId string, requesterId string, folderId string) (*entity.Folder, error) {
+	folder, err := ff.foldersRepository.FindById(requesterId, folderId)
+
+	if err == repository.ErrFolderDoesNotExists {
+		slog.Info("Folder not found", "traceId", traceId, "folderId", folderId)
+		return nil, err
+	}
+
+	if err != nil {
+		slog.Error("Could not find folder", "traceId", traceId, "folderId", folderId, "error", err)
+		return nil, err
+	}
+
+	return folder, nil
+}
@@ -0,0 +1,133 @@
+package facade
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrLinkUnavailable     = errors.New("link expired or reached its download limit")
+	ErrInvalidLinkPassword = errors.New("password does not match the one defined for this link")
+)
+
+type LinkFacade interface {
+	Create(traceId string, requesterId string, fileId string, password string, expiresAt *time.Time, maxDownloads *int64) (*entity.Link, error)
+	FindAll(traceId string, requesterId string, fileId string) ([]*entity.Link, error)
+	Delete(traceId string, requesterId string, fileId string, token string) error
+	Redeem(traceId string, token string, password string) (*entity.File, error)
+}
+
+type linkFacade struct {
+	filesRepository repository.FilesRepository
+	linksRepository repository.LinksRepository
+}
+
+func NewLinkFacade(filesRepository repository.FilesRepository, linksRepository repository.LinksRepository) *linkFacade {
+	return &linkFacade{filesRepository: filesRepository, linksRepository: linksRepository}
+}
+
+func (lf *linkFacade) Create(traceId string, requesterId string, fileId string, password string, expiresAt *time.Time, maxDownloads *int64) (*entity.Link, error) {
+	file, err := findOwnedFile(lf.filesRepository, traceId, requesterId, fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if file.Secret {
+		slog.Info("Refusing to create link for secret file", "traceId", traceId, "fileId", fileId)
+		return nil, ErrSecretFileCannotBeShared
+	}
+
+	link, err := entity.NewLink(fileId, requesterId, expiresAt, maxDownloads)
+
+	if err != nil {
+		slog.Error("Could not generate link token", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	if password != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+
+		if err != nil {
+			slog.Error("Could not hash link password", "traceId", traceId, "error", err)
+			return nil, err
+		}
+
+		link.PasswordHash = string(hash)
+	}
+
+	if err := lf.linksRepository.Save(link); err != nil {
+		slog.Error("Could not save link", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Link created successfully", "traceId", traceId, "fileId", fileId)
+	return link, nil
+}
+
+func (lf *linkFacade) FindAll(traceId string, requesterId string, fileId string) ([]*entity.Link, error) {
+	if _, err := findOwnedFile(lf.filesRepository, traceId, requesterId, fileId); err != nil {
+		return nil, err
+	}
+
+	links, err := lf.linksRepository.FindAllByFileId(fileId)
+
+	if err != nil {
+		slog.Error("Could not list links", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	return links, nil
+}
+
+func (lf *linkFacade) Delete(traceId string, requesterId string, fileId string, token string) error {
+	if _, err := findOwnedFile(lf.filesRepository, traceId, requesterId, fileId); err != nil {
+		return err
+	}
+
+	if err := lf.linksRepository.Delete(fileId, token); err != nil {
+		slog.Error("Could not delete link", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	slog.Info("Link removed successfully", "traceId", traceId, "fileId", fileId)
+	return nil
+}
+
+func (lf *linkFacade) Redeem(traceId string, token string, password string) (*entity.File, error) {
+	link, file, err := lf.linksRepository.FindByToken(token)
+
+	if err != nil {
+		slog.Error("Could not find link", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	if link.Expired() || link.Exhausted() {
+		return nil, ErrLinkUnavailable
+	}
+
+	if link.Protected() {
+		if err := bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password)); err != nil {
+			slog.Info("Link password does not match", "traceId", traceId, "fileId", link.FileId)
+			return nil, ErrInvalidLinkPassword
+		}
+	}
+
+	incremented, err := lf.linksRepository.IncrementDownloads(token)
+
+	if err != nil {
+		slog.Error("Could not register link download", "traceId", traceId, "fileId", link.FileId, "error", err)
+		return nil, err
+	}
+
+	if !incremented {
+		return nil, ErrLinkUnavailable
+	}
+
+	return file, nil
+}
@@ -10,6 +10,7 @@ package mocks
 
 import (
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
 	gomock "go.uber.org/mock/gomock"
@@ -38,46 +39,340 @@ func (m *MockFileFacade) EXPECT() *MockFileFacadeMockRecorder {
 	return m.recorder
 }
 
+// AddTag mocks base method.
+func (m *MockFileFacade) AddTag(traceId, requesterId string, groups []string, fileId, name string) (*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTag", traceId, requesterId, groups, fileId, name)
+	ret0, _ := ret[0].(*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddTag indicates an expected call of AddTag.
+func (mr *MockFileFacadeMockRecorder) AddTag(traceId, requesterId, groups, fileId, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTag", reflect.TypeOf((*MockFileFacade)(nil).AddTag), traceId, requesterId, groups, fileId, name)
+}
+
 // DeleteById mocks base method.
-func (m *MockFileFacade) DeleteById(traceId, requesterId, fileId string) error {
+func (m *MockFileFacade) DeleteById(traceId, requesterId string, groups []string, fileId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteById", traceId, requesterId, fileId)
+	ret := m.ctrl.Call(m, "DeleteById", traceId, requesterId, groups, fileId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteById indicates an expected call of DeleteById.
-func (mr *MockFileFacadeMockRecorder) DeleteById(traceId, requesterId, fileId any) *gomock.Call {
+func (mr *MockFileFacadeMockRecorder) DeleteById(traceId, requesterId, groups, fileId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockFileFacade)(nil).DeleteById), traceId, requesterId, fileId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteById", reflect.TypeOf((*MockFileFacade)(nil).DeleteById), traceId, requesterId, groups, fileId)
 }
 
 // FindAll mocks base method.
-func (m *MockFileFacade) FindAll(traceId, requesterId string, page, size int, filename string, secret bool) (*entity.FilePage, error) {
+func (m *MockFileFacade) FindAll(traceId, requesterId string, groups []string, page, size int, filename string, secret bool, folderId, tag string, metadata map[string]string, starred bool) (*entity.FilePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", traceId, requesterId, page, size, filename, secret)
+	ret := m.ctrl.Call(m, "FindAll", traceId, requesterId, groups, page, size, filename, secret, folderId, tag, metadata, starred)
 	ret0, _ := ret[0].(*entity.FilePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockFileFacadeMockRecorder) FindAll(traceId, requesterId, page, size, filename, secret any) *gomock.Call {
+func (mr *MockFileFacadeMockRecorder) FindAll(traceId, requesterId, groups, page, size, filename, secret, folderId, tag, metadata, starred any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFileFacade)(nil).FindAll), traceId, requesterId, groups, page, size, filename, secret, folderId, tag, metadata, starred)
+}
+
+// FindAllShared mocks base method.
+func (m *MockFileFacade) FindAllShared(traceId, requesterId string, groups []string, page, size int) (*entity.FilePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllShared", traceId, requesterId, groups, page, size)
+	ret0, _ := ret[0].(*entity.FilePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllShared indicates an expected call of FindAllShared.
+func (mr *MockFileFacadeMockRecorder) FindAllShared(traceId, requesterId, groups, page, size any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFileFacade)(nil).FindAll), traceId, requesterId, page, size, filename, secret)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllShared", reflect.TypeOf((*MockFileFacade)(nil).FindAllShared), traceId, requesterId, groups, page, size)
+}
+
+// FindAllTags mocks base method.
+func (m *MockFileFacade) FindAllTags(traceId, requesterId string, groups []string) ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllTags", traceId, requesterId, groups)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllTags indicates an expected call of FindAllTags.
+func (mr *MockFileFacadeMockRecorder) FindAllTags(traceId, requesterId, groups any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllTags", reflect.TypeOf((*MockFileFacade)(nil).FindAllTags), traceId, requesterId, groups)
+}
+
+// FindAllTrashed mocks base method.
+func (m *MockFileFacade) FindAllTrashed(traceId, requesterId string, page, size int) (*entity.FilePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllTrashed", traceId, requesterId, page, size)
+	ret0, _ := ret[0].(*entity.FilePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllTrashed indicates an expected call of FindAllTrashed.
+func (mr *MockFileFacadeMockRecorder) FindAllTrashed(traceId, requesterId, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllTrashed", reflect.TypeOf((*MockFileFacade)(nil).FindAllTrashed), traceId, requesterId, page, size)
 }
 
 // FindById mocks base method.
-func (m *MockFileFacade) FindById(requesterId, fileId string) (*entity.File, error) {
+func (m *MockFileFacade) FindById(requesterId string, groups []string, fileId string) (*entity.File, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindById", requesterId, fileId)
+	ret := m.ctrl.Call(m, "FindById", requesterId, groups, fileId)
 	ret0, _ := ret[0].(*entity.File)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindById indicates an expected call of FindById.
-func (mr *MockFileFacadeMockRecorder) FindById(requesterId, fileId any) *gomock.Call {
+func (mr *MockFileFacadeMockRecorder) FindById(requesterId, groups, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockFileFacade)(nil).FindById), requesterId, groups, fileId)
+}
+
+// FindByName mocks base method.
+func (m *MockFileFacade) FindByName(requesterId, folderId, filename string) (*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", requesterId, folderId, filename)
+	ret0, _ := ret[0].(*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockFileFacadeMockRecorder) FindByName(requesterId, folderId, filename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockFileFacade)(nil).FindByName), requesterId, folderId, filename)
+}
+
+// FindPermissions mocks base method.
+func (m *MockFileFacade) FindPermissions(traceId, requesterId, fileId string) (*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPermissions", traceId, requesterId, fileId)
+	ret0, _ := ret[0].(*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPermissions indicates an expected call of FindPermissions.
+func (mr *MockFileFacadeMockRecorder) FindPermissions(traceId, requesterId, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPermissions", reflect.TypeOf((*MockFileFacade)(nil).FindPermissions), traceId, requesterId, fileId)
+}
+
+// FindVersion mocks base method.
+func (m *MockFileFacade) FindVersion(traceId, requesterId string, groups []string, fileId string, version int64) (*entity.FileVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersion", traceId, requesterId, groups, fileId, version)
+	ret0, _ := ret[0].(*entity.FileVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersion indicates an expected call of FindVersion.
+func (mr *MockFileFacadeMockRecorder) FindVersion(traceId, requesterId, groups, fileId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersion", reflect.TypeOf((*MockFileFacade)(nil).FindVersion), traceId, requesterId, groups, fileId, version)
+}
+
+// FindVersions mocks base method.
+func (m *MockFileFacade) FindVersions(traceId, requesterId string, groups []string, fileId string) ([]*entity.FileVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindVersions", traceId, requesterId, groups, fileId)
+	ret0, _ := ret[0].([]*entity.FileVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindVersions indicates an expected call of FindVersions.
+func (mr *MockFileFacadeMockRecorder) FindVersions(traceId, requesterId, groups, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindVersions", reflect.TypeOf((*MockFileFacade)(nil).FindVersions), traceId, requesterId, groups, fileId)
+}
+
+// GrantGroupPermission mocks base method.
+func (m *MockFileFacade) GrantGroupPermission(traceId, requesterId, fileId, group, permission string) (*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantGroupPermission", traceId, requesterId, fileId, group, permission)
+	ret0, _ := ret[0].(*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantGroupPermission indicates an expected call of GrantGroupPermission.
+func (mr *MockFileFacadeMockRecorder) GrantGroupPermission(traceId, requesterId, fileId, group, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantGroupPermission", reflect.TypeOf((*MockFileFacade)(nil).GrantGroupPermission), traceId, requesterId, fileId, group, permission)
+}
+
+// GrantPermission mocks base method.
+func (m *MockFileFacade) GrantPermission(traceId, requesterId, fileId, userId, permission string) (*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GrantPermission", traceId, requesterId, fileId, userId, permission)
+	ret0, _ := ret[0].(*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GrantPermission indicates an expected call of GrantPermission.
+func (mr *MockFileFacadeMockRecorder) GrantPermission(traceId, requesterId, fileId, userId, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GrantPermission", reflect.TypeOf((*MockFileFacade)(nil).GrantPermission), traceId, requesterId, fileId, userId, permission)
+}
+
+// Lock mocks base method.
+func (m *MockFileFacade) Lock(traceId, requesterId string, groups []string, fileId string, duration time.Duration) (*entity.Lock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", traceId, requesterId, groups, fileId, duration)
+	ret0, _ := ret[0].(*entity.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockFileFacadeMockRecorder) Lock(traceId, requesterId, groups, fileId, duration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockFileFacade)(nil).Lock), traceId, requesterId, groups, fileId, duration)
+}
+
+// Move mocks base method.
+func (m *MockFileFacade) Move(traceId, requesterId string, groups []string, fileId, folderId string) (*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", traceId, requesterId, groups, fileId, folderId)
+	ret0, _ := ret[0].(*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockFileFacadeMockRecorder) Move(traceId, requesterId, groups, fileId, folderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockFileFacade)(nil).Move), traceId, requesterId, groups, fileId, folderId)
+}
+
+// RemoveTag mocks base method.
+func (m *MockFileFacade) RemoveTag(traceId, requesterId string, groups []string, fileId, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTag", traceId, requesterId, groups, fileId, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTag indicates an expected call of RemoveTag.
+func (mr *MockFileFacadeMockRecorder) RemoveTag(traceId, requesterId, groups, fileId, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTag", reflect.TypeOf((*MockFileFacade)(nil).RemoveTag), traceId, requesterId, groups, fileId, name)
+}
+
+// Restore mocks base method.
+func (m *MockFileFacade) Restore(traceId, requesterId, fileId string) (*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", traceId, requesterId, fileId)
+	ret0, _ := ret[0].(*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockFileFacadeMockRecorder) Restore(traceId, requesterId, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockFileFacade)(nil).Restore), traceId, requesterId, fileId)
+}
+
+// RevokeGroupPermission mocks base method.
+func (m *MockFileFacade) RevokeGroupPermission(traceId, requesterId, fileId, group string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeGroupPermission", traceId, requesterId, fileId, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeGroupPermission indicates an expected call of RevokeGroupPermission.
+func (mr *MockFileFacadeMockRecorder) RevokeGroupPermission(traceId, requesterId, fileId, group any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeGroupPermission", reflect.TypeOf((*MockFileFacade)(nil).RevokeGroupPermission), traceId, requesterId, fileId, group)
+}
+
+// RevokePermission mocks base method.
+func (m *MockFileFacade) RevokePermission(traceId, requesterId, fileId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokePermission", traceId, requesterId, fileId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokePermission indicates an expected call of RevokePermission.
+func (mr *MockFileFacadeMockRecorder) RevokePermission(traceId, requesterId, fileId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokePermission", reflect.TypeOf((*MockFileFacade)(nil).RevokePermission), traceId, requesterId, fileId, userId)
+}
+
+// Star mocks base method.
+func (m *MockFileFacade) Star(traceId, requesterId string, groups []string, fileId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Star", traceId, requesterId, groups, fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Star indicates an expected call of Star.
+func (mr *MockFileFacadeMockRecorder) Star(traceId, requesterId, groups, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Star", reflect.TypeOf((*MockFileFacade)(nil).Star), traceId, requesterId, groups, fileId)
+}
+
+// Unlock mocks base method.
+func (m *MockFileFacade) Unlock(traceId, requesterId string, groups []string, fileId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", traceId, requesterId, groups, fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockFileFacadeMockRecorder) Unlock(traceId, requesterId, groups, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockFileFacade)(nil).Unlock), traceId, requesterId, groups, fileId)
+}
+
+// Unstar mocks base method.
+func (m *MockFileFacade) Unstar(traceId, requesterId, fileId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unstar", traceId, requesterId, fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unstar indicates an expected call of Unstar.
+func (mr *MockFileFacadeMockRecorder) Unstar(traceId, requesterId, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unstar", reflect.TypeOf((*MockFileFacade)(nil).Unstar), traceId, requesterId, fileId)
+}
+
+// UpdateMetadata mocks base method.
+func (m *MockFileFacade) UpdateMetadata(traceId, requesterId string, groups []string, fileId string, metadata map[string]string) (*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMetadata", traceId, requesterId, groups, fileId, metadata)
+	ret0, _ := ret[0].(*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMetadata indicates an expected call of UpdateMetadata.
+func (mr *MockFileFacadeMockRecorder) UpdateMetadata(traceId, requesterId, groups, fileId, metadata any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockFileFacade)(nil).FindById), requesterId, fileId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMetadata", reflect.TypeOf((*MockFileFacade)(nil).UpdateMetadata), traceId, requesterId, groups, fileId, metadata)
 }
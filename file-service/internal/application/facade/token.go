@@ -0,0 +1,103 @@
+package facade
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+)
+
+var (
+	ErrInvalidAccessToken = errors.New("access token does not exists or is expired")
+)
+
+type AccessTokenFacade interface {
+	Create(traceId string, userId string, groups []string, name string, scope string, expiresAt *time.Time) (*entity.AccessToken, error)
+	FindAll(traceId string, userId string) ([]*entity.AccessToken, error)
+	Delete(traceId string, userId string, tokenId string) error
+	Authenticate(traceId string, token string) (*entity.AccessToken, error)
+}
+
+type accessTokenFacade struct {
+	accessTokensRepository repository.AccessTokensRepository
+}
+
+func NewAccessTokenFacade(accessTokensRepository repository.AccessTokensRepository) *accessTokenFacade {
+	return &accessTokenFacade{accessTokensRepository: accessTokensRepository}
+}
+
+func (af *accessTokenFacade) Create(traceId string, userId string, groups []string, name string, scope string, expiresAt *time.Time) (*entity.AccessToken, error) {
+	if scope == "" {
+		scope = entity.ScopeFull
+	}
+
+	token, err := entity.NewAccessToken(userId, name, scope, groups, expiresAt)
+
+	if err != nil {
+		slog.Error("Could not generate access token", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	if err := af.accessTokensRepository.Save(token); err != nil {
+		slog.Error("Could not save access token", "traceId", traceId, "userId", userId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("Access token created successfully", "traceId", traceId, "tokenId", token.TokenId)
+	return token, nil
+}
+
+func (af *accessTokenFacade) FindAll(traceId string, userId string) ([]*entity.AccessToken, error) {
+	tokens, err := af.accessTokensRepository.FindAllByUserId(userId)
+
+	if err != nil {
+		slog.Error("Could not list access tokens", "traceId", traceId, "userId", userId, "error", err)
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+func (af *accessTokenFacade) Delete(traceId string, userId string, tokenId string) error {
+	err := af.accessTokensRepository.Delete(userId, tokenId)
+
+	if err == repository.ErrAccessTokenDoesNotExists {
+		slog.Info("Access token not found", "traceId", traceId, "tokenId", tokenId)
+		return err
+	}
+
+	if err != nil {
+		slog.Error("Could not revoke access token", "traceId", traceId, "tokenId", tokenId, "error", err)
+		return err
+	}
+
+	slog.Info("Access token revoked successfully", "traceId", traceId, "tokenId", tokenId)
+	return nil
+}
+
+func (af *accessTokenFacade) Authenticate(traceId string, raw string) (*entity.AccessToken, error) {
+	token, err := af.accessTokensRepository.FindByHash(entity.HashAccessToken(raw))
+
+	if err == repository.ErrAccessTokenDoesNotExists {
+		slog.Info("Unknown access token presented", "traceId", traceId)
+		return nil, ErrInvalidAccessToken
+	}
+
+	if err != nil {
+		slog.Error("Could not find access token", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	if token.Expired() {
+		slog.Info("Expired access token presented", "traceId", traceId, "tokenId", token.TokenId)
+		return nil, ErrInvalidAccessToken
+	}
+
+	if err := af.accessTokensRepository.UpdateLastUsed(token.TokenId); err != nil {
+		slog.Warn("Could not update access token last usage", "traceId", traceId, "tokenId", token.TokenId, "error", err)
+	}
+
+	return token, nil
+}
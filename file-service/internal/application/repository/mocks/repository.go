@@ -11,6 +11,7 @@ package mocks
 import (
 	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
 	gomock "go.uber.org/mock/gomock"
@@ -39,62 +40,211 @@ func (m *MockFilesRepository) EXPECT() *MockFilesRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Delete mocks base method.
-func (m *MockFilesRepository) Delete(userId, fileId string) error {
+// DeleteFilePermissionByFileId mocks base method.
+func (m *MockFilesRepository) DeleteFilePermissionByFileId(fileId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, fileId)
+	ret := m.ctrl.Call(m, "DeleteFilePermissionByFileId", fileId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Delete indicates an expected call of Delete.
-func (mr *MockFilesRepositoryMockRecorder) Delete(userId, fileId any) *gomock.Call {
+// DeleteFilePermissionByFileId indicates an expected call of DeleteFilePermissionByFileId.
+func (mr *MockFilesRepositoryMockRecorder) DeleteFilePermissionByFileId(fileId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFilesRepository)(nil).Delete), userId, fileId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilePermissionByFileId", reflect.TypeOf((*MockFilesRepository)(nil).DeleteFilePermissionByFileId), fileId)
 }
 
-// DeleteFilePermissionByFileId mocks base method.
-func (m *MockFilesRepository) DeleteFilePermissionByFileId(fileId string) error {
+// DeleteGroupPermission mocks base method.
+func (m *MockFilesRepository) DeleteGroupPermission(fileId, group string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFilePermissionByFileId", fileId)
+	ret := m.ctrl.Call(m, "DeleteGroupPermission", fileId, group)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteFilePermissionByFileId indicates an expected call of DeleteFilePermissionByFileId.
-func (mr *MockFilesRepositoryMockRecorder) DeleteFilePermissionByFileId(fileId any) *gomock.Call {
+// DeleteGroupPermission indicates an expected call of DeleteGroupPermission.
+func (mr *MockFilesRepositoryMockRecorder) DeleteGroupPermission(fileId, group any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilePermissionByFileId", reflect.TypeOf((*MockFilesRepository)(nil).DeleteFilePermissionByFileId), fileId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGroupPermission", reflect.TypeOf((*MockFilesRepository)(nil).DeleteGroupPermission), fileId, group)
+}
+
+// DeletePermission mocks base method.
+func (m *MockFilesRepository) DeletePermission(fileId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePermission", fileId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePermission indicates an expected call of DeletePermission.
+func (mr *MockFilesRepositoryMockRecorder) DeletePermission(fileId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermission", reflect.TypeOf((*MockFilesRepository)(nil).DeletePermission), fileId, userId)
 }
 
 // FindAll mocks base method.
-func (m *MockFilesRepository) FindAll(userId string, page, size int, filename string, secret bool) (*entity.FilePage, error) {
+func (m *MockFilesRepository) FindAll(userId string, groups []string, page, size int, filename string, secret bool, folderId, tag string, metadata map[string]string, starred bool) (*entity.FilePage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", userId, page, size, filename, secret)
+	ret := m.ctrl.Call(m, "FindAll", userId, groups, page, size, filename, secret, folderId, tag, metadata, starred)
 	ret0, _ := ret[0].(*entity.FilePage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockFilesRepositoryMockRecorder) FindAll(userId, page, size, filename, secret any) *gomock.Call {
+func (mr *MockFilesRepositoryMockRecorder) FindAll(userId, groups, page, size, filename, secret, folderId, tag, metadata, starred any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFilesRepository)(nil).FindAll), userId, groups, page, size, filename, secret, folderId, tag, metadata, starred)
+}
+
+// FindAllByOwnerId mocks base method.
+func (m *MockFilesRepository) FindAllByOwnerId(ownerId string, page, size int, filename string) (*entity.FilePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByOwnerId", ownerId, page, size, filename)
+	ret0, _ := ret[0].(*entity.FilePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByOwnerId indicates an expected call of FindAllByOwnerId.
+func (mr *MockFilesRepositoryMockRecorder) FindAllByOwnerId(ownerId, page, size, filename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByOwnerId", reflect.TypeOf((*MockFilesRepository)(nil).FindAllByOwnerId), ownerId, page, size, filename)
+}
+
+// FindAllChecksummed mocks base method.
+func (m *MockFilesRepository) FindAllChecksummed(afterFileId string, limit int) ([]*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllChecksummed", afterFileId, limit)
+	ret0, _ := ret[0].([]*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllChecksummed indicates an expected call of FindAllChecksummed.
+func (mr *MockFilesRepositoryMockRecorder) FindAllChecksummed(afterFileId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllChecksummed", reflect.TypeOf((*MockFilesRepository)(nil).FindAllChecksummed), afterFileId, limit)
+}
+
+// FindAllExpiredTrash mocks base method.
+func (m *MockFilesRepository) FindAllExpiredTrash(deletedBefore time.Time, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllExpiredTrash", deletedBefore, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllExpiredTrash indicates an expected call of FindAllExpiredTrash.
+func (mr *MockFilesRepositoryMockRecorder) FindAllExpiredTrash(deletedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllExpiredTrash", reflect.TypeOf((*MockFilesRepository)(nil).FindAllExpiredTrash), deletedBefore, limit)
+}
+
+// FindAllShared mocks base method.
+func (m *MockFilesRepository) FindAllShared(userId string, groups []string, page, size int) (*entity.FilePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllShared", userId, groups, page, size)
+	ret0, _ := ret[0].(*entity.FilePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllShared indicates an expected call of FindAllShared.
+func (mr *MockFilesRepositoryMockRecorder) FindAllShared(userId, groups, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllShared", reflect.TypeOf((*MockFilesRepository)(nil).FindAllShared), userId, groups, page, size)
+}
+
+// FindAllSizes mocks base method.
+func (m *MockFilesRepository) FindAllSizes() ([]*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllSizes")
+	ret0, _ := ret[0].([]*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllSizes indicates an expected call of FindAllSizes.
+func (mr *MockFilesRepositoryMockRecorder) FindAllSizes() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFilesRepository)(nil).FindAll), userId, page, size, filename, secret)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllSizes", reflect.TypeOf((*MockFilesRepository)(nil).FindAllSizes))
+}
+
+// FindAllTrashed mocks base method.
+func (m *MockFilesRepository) FindAllTrashed(ownerId string, page, size int) (*entity.FilePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllTrashed", ownerId, page, size)
+	ret0, _ := ret[0].(*entity.FilePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllTrashed indicates an expected call of FindAllTrashed.
+func (mr *MockFilesRepositoryMockRecorder) FindAllTrashed(ownerId, page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllTrashed", reflect.TypeOf((*MockFilesRepository)(nil).FindAllTrashed), ownerId, page, size)
+}
+
+// FindAllUsage mocks base method.
+func (m *MockFilesRepository) FindAllUsage(page, size int) (*entity.UsagePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllUsage", page, size)
+	ret0, _ := ret[0].(*entity.UsagePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllUsage indicates an expected call of FindAllUsage.
+func (mr *MockFilesRepositoryMockRecorder) FindAllUsage(page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllUsage", reflect.TypeOf((*MockFilesRepository)(nil).FindAllUsage), page, size)
 }
 
 // FindById mocks base method.
-func (m *MockFilesRepository) FindById(userId, fileId string) (*entity.File, error) {
+func (m *MockFilesRepository) FindById(userId string, groups []string, fileId string) (*entity.File, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindById", userId, fileId)
+	ret := m.ctrl.Call(m, "FindById", userId, groups, fileId)
 	ret0, _ := ret[0].(*entity.File)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindById indicates an expected call of FindById.
-func (mr *MockFilesRepositoryMockRecorder) FindById(userId, fileId any) *gomock.Call {
+func (mr *MockFilesRepositoryMockRecorder) FindById(userId, groups, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockFilesRepository)(nil).FindById), userId, groups, fileId)
+}
+
+// FindByName mocks base method.
+func (m *MockFilesRepository) FindByName(ownerId, folderId, filename string) (*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ownerId, folderId, filename)
+	ret0, _ := ret[0].(*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockFilesRepositoryMockRecorder) FindByName(ownerId, folderId, filename any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockFilesRepository)(nil).FindByName), ownerId, folderId, filename)
+}
+
+// FindTotals mocks base method.
+func (m *MockFilesRepository) FindTotals() (*entity.Totals, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTotals")
+	ret0, _ := ret[0].(*entity.Totals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTotals indicates an expected call of FindTotals.
+func (mr *MockFilesRepositoryMockRecorder) FindTotals() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockFilesRepository)(nil).FindById), userId, fileId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTotals", reflect.TypeOf((*MockFilesRepository)(nil).FindTotals))
 }
 
 // FindUsageByUserId mocks base method.
@@ -112,6 +262,34 @@ func (mr *MockFilesRepositoryMockRecorder) FindUsageByUserId(userId any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUsageByUserId", reflect.TypeOf((*MockFilesRepository)(nil).FindUsageByUserId), userId)
 }
 
+// Purge mocks base method.
+func (m *MockFilesRepository) Purge(fileId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockFilesRepositoryMockRecorder) Purge(fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockFilesRepository)(nil).Purge), fileId)
+}
+
+// Restore mocks base method.
+func (m *MockFilesRepository) Restore(ownerId, fileId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ownerId, fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockFilesRepositoryMockRecorder) Restore(ownerId, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockFilesRepository)(nil).Restore), ownerId, fileId)
+}
+
 // Save mocks base method.
 func (m *MockFilesRepository) Save(file *entity.File) error {
 	m.ctrl.T.Helper()
@@ -126,111 +304,1497 @@ func (mr *MockFilesRepositoryMockRecorder) Save(file any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFilesRepository)(nil).Save), file)
 }
 
+// SaveGroupPermission mocks base method.
+func (m *MockFilesRepository) SaveGroupPermission(fileId, group, permission string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveGroupPermission", fileId, group, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveGroupPermission indicates an expected call of SaveGroupPermission.
+func (mr *MockFilesRepositoryMockRecorder) SaveGroupPermission(fileId, group, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveGroupPermission", reflect.TypeOf((*MockFilesRepository)(nil).SaveGroupPermission), fileId, group, permission)
+}
+
+// SavePermission mocks base method.
+func (m *MockFilesRepository) SavePermission(fileId, userId, permission string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePermission", fileId, userId, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePermission indicates an expected call of SavePermission.
+func (mr *MockFilesRepositoryMockRecorder) SavePermission(fileId, userId, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePermission", reflect.TypeOf((*MockFilesRepository)(nil).SavePermission), fileId, userId, permission)
+}
+
+// Trash mocks base method.
+func (m *MockFilesRepository) Trash(userId string, groups []string, fileId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trash", userId, groups, fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Trash indicates an expected call of Trash.
+func (mr *MockFilesRepositoryMockRecorder) Trash(userId, groups, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trash", reflect.TypeOf((*MockFilesRepository)(nil).Trash), userId, groups, fileId)
+}
+
 // Update mocks base method.
-func (m *MockFilesRepository) Update(userId string, file *entity.File) error {
+func (m *MockFilesRepository) Update(userId string, groups []string, file *entity.File) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, file)
+	ret := m.ctrl.Call(m, "Update", userId, groups, file)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockFilesRepositoryMockRecorder) Update(userId, file any) *gomock.Call {
+func (mr *MockFilesRepositoryMockRecorder) Update(userId, groups, file any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFilesRepository)(nil).Update), userId, file)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFilesRepository)(nil).Update), userId, groups, file)
 }
 
-// MockTxFilesRepository is a mock of TxFilesRepository interface.
-type MockTxFilesRepository struct {
+// UpdateCorruptedAt mocks base method.
+func (m *MockFilesRepository) UpdateCorruptedAt(fileId, checksum string, corruptedAt *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCorruptedAt", fileId, checksum, corruptedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCorruptedAt indicates an expected call of UpdateCorruptedAt.
+func (mr *MockFilesRepositoryMockRecorder) UpdateCorruptedAt(fileId, checksum, corruptedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCorruptedAt", reflect.TypeOf((*MockFilesRepository)(nil).UpdateCorruptedAt), fileId, checksum, corruptedAt)
+}
+
+// UpdateParent mocks base method.
+func (m *MockFilesRepository) UpdateParent(userId string, groups []string, file *entity.File) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateParent", userId, groups, file)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateParent indicates an expected call of UpdateParent.
+func (mr *MockFilesRepositoryMockRecorder) UpdateParent(userId, groups, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateParent", reflect.TypeOf((*MockFilesRepository)(nil).UpdateParent), userId, groups, file)
+}
+
+// UpdateSize mocks base method.
+func (m *MockFilesRepository) UpdateSize(fileId string, size int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSize", fileId, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSize indicates an expected call of UpdateSize.
+func (mr *MockFilesRepositoryMockRecorder) UpdateSize(fileId, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSize", reflect.TypeOf((*MockFilesRepository)(nil).UpdateSize), fileId, size)
+}
+
+// MockFoldersRepository is a mock of FoldersRepository interface.
+type MockFoldersRepository struct {
 	ctrl     *gomock.Controller
-	recorder *MockTxFilesRepositoryMockRecorder
+	recorder *MockFoldersRepositoryMockRecorder
 }
 
-// MockTxFilesRepositoryMockRecorder is the mock recorder for MockTxFilesRepository.
-type MockTxFilesRepositoryMockRecorder struct {
-	mock *MockTxFilesRepository
+// MockFoldersRepositoryMockRecorder is the mock recorder for MockFoldersRepository.
+type MockFoldersRepositoryMockRecorder struct {
+	mock *MockFoldersRepository
 }
 
-// NewMockTxFilesRepository creates a new mock instance.
-func NewMockTxFilesRepository(ctrl *gomock.Controller) *MockTxFilesRepository {
-	mock := &MockTxFilesRepository{ctrl: ctrl}
-	mock.recorder = &MockTxFilesRepositoryMockRecorder{mock}
+// NewMockFoldersRepository creates a new mock instance.
+func NewMockFoldersRepository(ctrl *gomock.Controller) *MockFoldersRepository {
+	mock := &MockFoldersRepository{ctrl: ctrl}
+	mock.recorder = &MockFoldersRepositoryMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTxFilesRepository) EXPECT() *MockTxFilesRepositoryMockRecorder {
+func (m *MockFoldersRepository) EXPECT() *MockFoldersRepositoryMockRecorder {
 	return m.recorder
 }
 
-// Begin mocks base method.
-func (m *MockTxFilesRepository) Begin() (*sql.Tx, error) {
+// CountChildren mocks base method.
+func (m *MockFoldersRepository) CountChildren(folderId string) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Begin")
-	ret0, _ := ret[0].(*sql.Tx)
+	ret := m.ctrl.Call(m, "CountChildren", folderId)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// Begin indicates an expected call of Begin.
-func (mr *MockTxFilesRepositoryMockRecorder) Begin() *gomock.Call {
+// CountChildren indicates an expected call of CountChildren.
+func (mr *MockFoldersRepositoryMockRecorder) CountChildren(folderId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockTxFilesRepository)(nil).Begin))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountChildren", reflect.TypeOf((*MockFoldersRepository)(nil).CountChildren), folderId)
 }
 
-// Commit mocks base method.
-func (m *MockTxFilesRepository) Commit(tx *sql.Tx) error {
+// Delete mocks base method.
+func (m *MockFoldersRepository) Delete(ownerId, folderId string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Commit", tx)
+	ret := m.ctrl.Call(m, "Delete", ownerId, folderId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Commit indicates an expected call of Commit.
-func (mr *MockTxFilesRepositoryMockRecorder) Commit(tx any) *gomock.Call {
+// Delete indicates an expected call of Delete.
+func (mr *MockFoldersRepositoryMockRecorder) Delete(ownerId, folderId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockTxFilesRepository)(nil).Commit), tx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFoldersRepository)(nil).Delete), ownerId, folderId)
 }
 
-// DeleteFilePermissionByFileId mocks base method.
-func (m *MockTxFilesRepository) DeleteFilePermissionByFileId(tx *sql.Tx, fileId string) error {
+// FindAllByParentId mocks base method.
+func (m *MockFoldersRepository) FindAllByParentId(ownerId, parentId string) ([]*entity.Folder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFilePermissionByFileId", tx, fileId)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "FindAllByParentId", ownerId, parentId)
+	ret0, _ := ret[0].([]*entity.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteFilePermissionByFileId indicates an expected call of DeleteFilePermissionByFileId.
-func (mr *MockTxFilesRepositoryMockRecorder) DeleteFilePermissionByFileId(tx, fileId any) *gomock.Call {
+// FindAllByParentId indicates an expected call of FindAllByParentId.
+func (mr *MockFoldersRepositoryMockRecorder) FindAllByParentId(ownerId, parentId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilePermissionByFileId", reflect.TypeOf((*MockTxFilesRepository)(nil).DeleteFilePermissionByFileId), tx, fileId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByParentId", reflect.TypeOf((*MockFoldersRepository)(nil).FindAllByParentId), ownerId, parentId)
 }
 
 // FindById mocks base method.
-func (m *MockTxFilesRepository) FindById(tx *sql.Tx, userId, fileId string) (*entity.File, error) {
+func (m *MockFoldersRepository) FindById(ownerId, folderId string) (*entity.Folder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindById", tx, userId, fileId)
-	ret0, _ := ret[0].(*entity.File)
+	ret := m.ctrl.Call(m, "FindById", ownerId, folderId)
+	ret0, _ := ret[0].(*entity.Folder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindById indicates an expected call of FindById.
-func (mr *MockTxFilesRepositoryMockRecorder) FindById(tx, userId, fileId any) *gomock.Call {
+func (mr *MockFoldersRepositoryMockRecorder) FindById(ownerId, folderId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockFoldersRepository)(nil).FindById), ownerId, folderId)
+}
+
+// FindPath mocks base method.
+func (m *MockFoldersRepository) FindPath(folderId string) ([]*entity.Breadcrumb, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindPath", folderId)
+	ret0, _ := ret[0].([]*entity.Breadcrumb)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindPath indicates an expected call of FindPath.
+func (mr *MockFoldersRepositoryMockRecorder) FindPath(folderId any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTxFilesRepository)(nil).FindById), tx, userId, fileId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPath", reflect.TypeOf((*MockFoldersRepository)(nil).FindPath), folderId)
+}
+
+// Save mocks base method.
+func (m *MockFoldersRepository) Save(folder *entity.Folder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", folder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockFoldersRepositoryMockRecorder) Save(folder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFoldersRepository)(nil).Save), folder)
 }
 
 // Update mocks base method.
-func (m *MockTxFilesRepository) Update(tx *sql.Tx, userId string, file *entity.File) error {
+func (m *MockFoldersRepository) Update(ownerId string, folder *entity.Folder) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", tx, userId, file)
+	ret := m.ctrl.Call(m, "Update", ownerId, folder)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTxFilesRepositoryMockRecorder) Update(tx, userId, file any) *gomock.Call {
+func (mr *MockFoldersRepositoryMockRecorder) Update(ownerId, folder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFoldersRepository)(nil).Update), ownerId, folder)
+}
+
+// MockVersionsRepository is a mock of VersionsRepository interface.
+type MockVersionsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersionsRepositoryMockRecorder
+}
+
+// MockVersionsRepositoryMockRecorder is the mock recorder for MockVersionsRepository.
+type MockVersionsRepositoryMockRecorder struct {
+	mock *MockVersionsRepository
+}
+
+// NewMockVersionsRepository creates a new mock instance.
+func NewMockVersionsRepository(ctrl *gomock.Controller) *MockVersionsRepository {
+	mock := &MockVersionsRepository{ctrl: ctrl}
+	mock.recorder = &MockVersionsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersionsRepository) EXPECT() *MockVersionsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockVersionsRepository) Delete(versionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", versionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockVersionsRepositoryMockRecorder) Delete(versionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockVersionsRepository)(nil).Delete), versionId)
+}
+
+// FindAllByFileId mocks base method.
+func (m *MockVersionsRepository) FindAllByFileId(fileId string) ([]*entity.FileVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByFileId", fileId)
+	ret0, _ := ret[0].([]*entity.FileVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByFileId indicates an expected call of FindAllByFileId.
+func (mr *MockVersionsRepositoryMockRecorder) FindAllByFileId(fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByFileId", reflect.TypeOf((*MockVersionsRepository)(nil).FindAllByFileId), fileId)
+}
+
+// FindAllSizes mocks base method.
+func (m *MockVersionsRepository) FindAllSizes() ([]*entity.FileVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllSizes")
+	ret0, _ := ret[0].([]*entity.FileVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllSizes indicates an expected call of FindAllSizes.
+func (mr *MockVersionsRepositoryMockRecorder) FindAllSizes() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllSizes", reflect.TypeOf((*MockVersionsRepository)(nil).FindAllSizes))
+}
+
+// FindByVersion mocks base method.
+func (m *MockVersionsRepository) FindByVersion(fileId string, version int64) (*entity.FileVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByVersion", fileId, version)
+	ret0, _ := ret[0].(*entity.FileVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByVersion indicates an expected call of FindByVersion.
+func (mr *MockVersionsRepositoryMockRecorder) FindByVersion(fileId, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByVersion", reflect.TypeOf((*MockVersionsRepository)(nil).FindByVersion), fileId, version)
+}
+
+// Purge mocks base method.
+func (m *MockVersionsRepository) Purge(versionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", versionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockVersionsRepositoryMockRecorder) Purge(versionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockVersionsRepository)(nil).Purge), versionId)
+}
+
+// Save mocks base method.
+func (m *MockVersionsRepository) Save(version *entity.FileVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockVersionsRepositoryMockRecorder) Save(version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockVersionsRepository)(nil).Save), version)
+}
+
+// UpdateSize mocks base method.
+func (m *MockVersionsRepository) UpdateSize(versionId string, size int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSize", versionId, size)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSize indicates an expected call of UpdateSize.
+func (mr *MockVersionsRepositoryMockRecorder) UpdateSize(versionId, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSize", reflect.TypeOf((*MockVersionsRepository)(nil).UpdateSize), versionId, size)
+}
+
+// MockTxFilesRepository is a mock of TxFilesRepository interface.
+type MockTxFilesRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTxFilesRepositoryMockRecorder
+}
+
+// MockTxFilesRepositoryMockRecorder is the mock recorder for MockTxFilesRepository.
+type MockTxFilesRepositoryMockRecorder struct {
+	mock *MockTxFilesRepository
+}
+
+// NewMockTxFilesRepository creates a new mock instance.
+func NewMockTxFilesRepository(ctrl *gomock.Controller) *MockTxFilesRepository {
+	mock := &MockTxFilesRepository{ctrl: ctrl}
+	mock.recorder = &MockTxFilesRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTxFilesRepository) EXPECT() *MockTxFilesRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Begin mocks base method.
+func (m *MockTxFilesRepository) Begin() (*sql.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Begin")
+	ret0, _ := ret[0].(*sql.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Begin indicates an expected call of Begin.
+func (mr *MockTxFilesRepositoryMockRecorder) Begin() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Begin", reflect.TypeOf((*MockTxFilesRepository)(nil).Begin))
+}
+
+// Commit mocks base method.
+func (m *MockTxFilesRepository) Commit(tx *sql.Tx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Commit", tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Commit indicates an expected call of Commit.
+func (mr *MockTxFilesRepositoryMockRecorder) Commit(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Commit", reflect.TypeOf((*MockTxFilesRepository)(nil).Commit), tx)
+}
+
+// DeleteFileLinksByFileId mocks base method.
+func (m *MockTxFilesRepository) DeleteFileLinksByFileId(tx *sql.Tx, fileId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFileLinksByFileId", tx, fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFileLinksByFileId indicates an expected call of DeleteFileLinksByFileId.
+func (mr *MockTxFilesRepositoryMockRecorder) DeleteFileLinksByFileId(tx, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFileLinksByFileId", reflect.TypeOf((*MockTxFilesRepository)(nil).DeleteFileLinksByFileId), tx, fileId)
+}
+
+// DeleteFilePermissionByFileId mocks base method.
+func (m *MockTxFilesRepository) DeleteFilePermissionByFileId(tx *sql.Tx, fileId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFilePermissionByFileId", tx, fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFilePermissionByFileId indicates an expected call of DeleteFilePermissionByFileId.
+func (mr *MockTxFilesRepositoryMockRecorder) DeleteFilePermissionByFileId(tx, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFilePermissionByFileId", reflect.TypeOf((*MockTxFilesRepository)(nil).DeleteFilePermissionByFileId), tx, fileId)
+}
+
+// DeletePermission mocks base method.
+func (m *MockTxFilesRepository) DeletePermission(tx *sql.Tx, fileId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePermission", tx, fileId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePermission indicates an expected call of DeletePermission.
+func (mr *MockTxFilesRepositoryMockRecorder) DeletePermission(tx, fileId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermission", reflect.TypeOf((*MockTxFilesRepository)(nil).DeletePermission), tx, fileId, userId)
+}
+
+// DeletePermissionsByOwnerId mocks base method.
+func (m *MockTxFilesRepository) DeletePermissionsByOwnerId(tx *sql.Tx, ownerId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePermissionsByOwnerId", tx, ownerId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePermissionsByOwnerId indicates an expected call of DeletePermissionsByOwnerId.
+func (mr *MockTxFilesRepositoryMockRecorder) DeletePermissionsByOwnerId(tx, ownerId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePermissionsByOwnerId", reflect.TypeOf((*MockTxFilesRepository)(nil).DeletePermissionsByOwnerId), tx, ownerId, userId)
+}
+
+// DeleteVersion mocks base method.
+func (m *MockTxFilesRepository) DeleteVersion(tx *sql.Tx, versionId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVersion", tx, versionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVersion indicates an expected call of DeleteVersion.
+func (mr *MockTxFilesRepositoryMockRecorder) DeleteVersion(tx, versionId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVersion", reflect.TypeOf((*MockTxFilesRepository)(nil).DeleteVersion), tx, versionId)
+}
+
+// FindById mocks base method.
+func (m *MockTxFilesRepository) FindById(tx *sql.Tx, userId string, groups []string, fileId string) (*entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", tx, userId, groups, fileId)
+	ret0, _ := ret[0].(*entity.File)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockTxFilesRepositoryMockRecorder) FindById(tx, userId, groups, fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockTxFilesRepository)(nil).FindById), tx, userId, groups, fileId)
+}
+
+// FindUsageByUserId mocks base method.
+func (m *MockTxFilesRepository) FindUsageByUserId(tx *sql.Tx, userId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindUsageByUserId", tx, userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindUsageByUserId indicates an expected call of FindUsageByUserId.
+func (mr *MockTxFilesRepositoryMockRecorder) FindUsageByUserId(tx, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindUsageByUserId", reflect.TypeOf((*MockTxFilesRepository)(nil).FindUsageByUserId), tx, userId)
+}
+
+// Rollback mocks base method.
+func (m *MockTxFilesRepository) Rollback(tx *sql.Tx) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rollback", tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rollback indicates an expected call of Rollback.
+func (mr *MockTxFilesRepositoryMockRecorder) Rollback(tx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockTxFilesRepository)(nil).Rollback), tx)
+}
+
+// Save mocks base method.
+func (m *MockTxFilesRepository) Save(tx *sql.Tx, file *entity.File) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", tx, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTxFilesRepositoryMockRecorder) Save(tx, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTxFilesRepository)(nil).Save), tx, file)
+}
+
+// SaveOwnerEditorPermissions mocks base method.
+func (m *MockTxFilesRepository) SaveOwnerEditorPermissions(tx *sql.Tx, ownerId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveOwnerEditorPermissions", tx, ownerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveOwnerEditorPermissions indicates an expected call of SaveOwnerEditorPermissions.
+func (mr *MockTxFilesRepositoryMockRecorder) SaveOwnerEditorPermissions(tx, ownerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOwnerEditorPermissions", reflect.TypeOf((*MockTxFilesRepository)(nil).SaveOwnerEditorPermissions), tx, ownerId)
+}
+
+// SavePermission mocks base method.
+func (m *MockTxFilesRepository) SavePermission(tx *sql.Tx, fileId, userId, permission string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePermission", tx, fileId, userId, permission)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePermission indicates an expected call of SavePermission.
+func (mr *MockTxFilesRepositoryMockRecorder) SavePermission(tx, fileId, userId, permission any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePermission", reflect.TypeOf((*MockTxFilesRepository)(nil).SavePermission), tx, fileId, userId, permission)
+}
+
+// SaveVersion mocks base method.
+func (m *MockTxFilesRepository) SaveVersion(tx *sql.Tx, version *entity.FileVersion) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveVersion", tx, version)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveVersion indicates an expected call of SaveVersion.
+func (mr *MockTxFilesRepositoryMockRecorder) SaveVersion(tx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveVersion", reflect.TypeOf((*MockTxFilesRepository)(nil).SaveVersion), tx, version)
+}
+
+// Update mocks base method.
+func (m *MockTxFilesRepository) Update(tx *sql.Tx, userId string, groups []string, file *entity.File) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", tx, userId, groups, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTxFilesRepositoryMockRecorder) Update(tx, userId, groups, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTxFilesRepository)(nil).Update), tx, userId, groups, file)
+}
+
+// UpdateContent mocks base method.
+func (m *MockTxFilesRepository) UpdateContent(tx *sql.Tx, userId string, file *entity.File) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContent", tx, userId, file)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContent indicates an expected call of UpdateContent.
+func (mr *MockTxFilesRepositoryMockRecorder) UpdateContent(tx, userId, file any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContent", reflect.TypeOf((*MockTxFilesRepository)(nil).UpdateContent), tx, userId, file)
+}
+
+// UpdateOwner mocks base method.
+func (m *MockTxFilesRepository) UpdateOwner(tx *sql.Tx, requesterId, fileId, ownerId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOwner", tx, requesterId, fileId, ownerId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOwner indicates an expected call of UpdateOwner.
+func (mr *MockTxFilesRepositoryMockRecorder) UpdateOwner(tx, requesterId, fileId, ownerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOwner", reflect.TypeOf((*MockTxFilesRepository)(nil).UpdateOwner), tx, requesterId, fileId, ownerId)
+}
+
+// UpdateOwnerByOwnerId mocks base method.
+func (m *MockTxFilesRepository) UpdateOwnerByOwnerId(tx *sql.Tx, requesterId, ownerId, newOwnerId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOwnerByOwnerId", tx, requesterId, ownerId, newOwnerId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOwnerByOwnerId indicates an expected call of UpdateOwnerByOwnerId.
+func (mr *MockTxFilesRepositoryMockRecorder) UpdateOwnerByOwnerId(tx, requesterId, ownerId, newOwnerId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOwnerByOwnerId", reflect.TypeOf((*MockTxFilesRepository)(nil).UpdateOwnerByOwnerId), tx, requesterId, ownerId, newOwnerId)
+}
+
+// MockAuditRepository is a mock of AuditRepository interface.
+type MockAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditRepositoryMockRecorder
+}
+
+// MockAuditRepositoryMockRecorder is the mock recorder for MockAuditRepository.
+type MockAuditRepositoryMockRecorder struct {
+	mock *MockAuditRepository
+}
+
+// NewMockAuditRepository creates a new mock instance.
+func NewMockAuditRepository(ctrl *gomock.Controller) *MockAuditRepository {
+	mock := &MockAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditRepository) EXPECT() *MockAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockAuditRepository) FindAll(page, size int) (*entity.AuditPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", page, size)
+	ret0, _ := ret[0].(*entity.AuditPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockAuditRepositoryMockRecorder) FindAll(page, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockAuditRepository)(nil).FindAll), page, size)
+}
+
+// Save mocks base method.
+func (m *MockAuditRepository) Save(entry *entity.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAuditRepositoryMockRecorder) Save(entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAuditRepository)(nil).Save), entry)
+}
+
+// MockAccessTokensRepository is a mock of AccessTokensRepository interface.
+type MockAccessTokensRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccessTokensRepositoryMockRecorder
+}
+
+// MockAccessTokensRepositoryMockRecorder is the mock recorder for MockAccessTokensRepository.
+type MockAccessTokensRepositoryMockRecorder struct {
+	mock *MockAccessTokensRepository
+}
+
+// NewMockAccessTokensRepository creates a new mock instance.
+func NewMockAccessTokensRepository(ctrl *gomock.Controller) *MockAccessTokensRepository {
+	mock := &MockAccessTokensRepository{ctrl: ctrl}
+	mock.recorder = &MockAccessTokensRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccessTokensRepository) EXPECT() *MockAccessTokensRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockAccessTokensRepository) Delete(userId, tokenId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, tokenId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAccessTokensRepositoryMockRecorder) Delete(userId, tokenId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAccessTokensRepository)(nil).Delete), userId, tokenId)
+}
+
+// FindAllByUserId mocks base method.
+func (m *MockAccessTokensRepository) FindAllByUserId(userId string) ([]*entity.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByUserId", userId)
+	ret0, _ := ret[0].([]*entity.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByUserId indicates an expected call of FindAllByUserId.
+func (mr *MockAccessTokensRepositoryMockRecorder) FindAllByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByUserId", reflect.TypeOf((*MockAccessTokensRepository)(nil).FindAllByUserId), userId)
+}
+
+// FindByHash mocks base method.
+func (m *MockAccessTokensRepository) FindByHash(tokenHash string) (*entity.AccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByHash", tokenHash)
+	ret0, _ := ret[0].(*entity.AccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByHash indicates an expected call of FindByHash.
+func (mr *MockAccessTokensRepositoryMockRecorder) FindByHash(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByHash", reflect.TypeOf((*MockAccessTokensRepository)(nil).FindByHash), tokenHash)
+}
+
+// Save mocks base method.
+func (m *MockAccessTokensRepository) Save(token *entity.AccessToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockAccessTokensRepositoryMockRecorder) Save(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAccessTokensRepository)(nil).Save), token)
+}
+
+// UpdateLastUsed mocks base method.
+func (m *MockAccessTokensRepository) UpdateLastUsed(tokenId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastUsed", tokenId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastUsed indicates an expected call of UpdateLastUsed.
+func (mr *MockAccessTokensRepositoryMockRecorder) UpdateLastUsed(tokenId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastUsed", reflect.TypeOf((*MockAccessTokensRepository)(nil).UpdateLastUsed), tokenId)
+}
+
+// MockLinksRepository is a mock of LinksRepository interface.
+type MockLinksRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLinksRepositoryMockRecorder
+}
+
+// MockLinksRepositoryMockRecorder is the mock recorder for MockLinksRepository.
+type MockLinksRepositoryMockRecorder struct {
+	mock *MockLinksRepository
+}
+
+// NewMockLinksRepository creates a new mock instance.
+func NewMockLinksRepository(ctrl *gomock.Controller) *MockLinksRepository {
+	mock := &MockLinksRepository{ctrl: ctrl}
+	mock.recorder = &MockLinksRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLinksRepository) EXPECT() *MockLinksRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockLinksRepository) Delete(fileId, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", fileId, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLinksRepositoryMockRecorder) Delete(fileId, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLinksRepository)(nil).Delete), fileId, token)
+}
+
+// FindAllByFileId mocks base method.
+func (m *MockLinksRepository) FindAllByFileId(fileId string) ([]*entity.Link, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByFileId", fileId)
+	ret0, _ := ret[0].([]*entity.Link)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByFileId indicates an expected call of FindAllByFileId.
+func (mr *MockLinksRepositoryMockRecorder) FindAllByFileId(fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByFileId", reflect.TypeOf((*MockLinksRepository)(nil).FindAllByFileId), fileId)
+}
+
+// FindByToken mocks base method.
+func (m *MockLinksRepository) FindByToken(token string) (*entity.Link, *entity.File, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByToken", token)
+	ret0, _ := ret[0].(*entity.Link)
+	ret1, _ := ret[1].(*entity.File)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// FindByToken indicates an expected call of FindByToken.
+func (mr *MockLinksRepositoryMockRecorder) FindByToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByToken", reflect.TypeOf((*MockLinksRepository)(nil).FindByToken), token)
+}
+
+// IncrementDownloads mocks base method.
+func (m *MockLinksRepository) IncrementDownloads(token string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementDownloads", token)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementDownloads indicates an expected call of IncrementDownloads.
+func (mr *MockLinksRepositoryMockRecorder) IncrementDownloads(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementDownloads", reflect.TypeOf((*MockLinksRepository)(nil).IncrementDownloads), token)
+}
+
+// Save mocks base method.
+func (m *MockLinksRepository) Save(link *entity.Link) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", link)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockLinksRepositoryMockRecorder) Save(link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockLinksRepository)(nil).Save), link)
+}
+
+// MockTagsRepository is a mock of TagsRepository interface.
+type MockTagsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagsRepositoryMockRecorder
+}
+
+// MockTagsRepositoryMockRecorder is the mock recorder for MockTagsRepository.
+type MockTagsRepositoryMockRecorder struct {
+	mock *MockTagsRepository
+}
+
+// NewMockTagsRepository creates a new mock instance.
+func NewMockTagsRepository(ctrl *gomock.Controller) *MockTagsRepository {
+	mock := &MockTagsRepository{ctrl: ctrl}
+	mock.recorder = &MockTagsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagsRepository) EXPECT() *MockTagsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockTagsRepository) Delete(fileId, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", fileId, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagsRepositoryMockRecorder) Delete(fileId, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTagsRepository)(nil).Delete), fileId, name)
+}
+
+// FindAll mocks base method.
+func (m *MockTagsRepository) FindAll(userId string, groups []string) ([]*entity.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", userId, groups)
+	ret0, _ := ret[0].([]*entity.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockTagsRepositoryMockRecorder) FindAll(userId, groups any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTagsRepository)(nil).FindAll), userId, groups)
+}
+
+// FindAllByFileId mocks base method.
+func (m *MockTagsRepository) FindAllByFileId(fileId string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByFileId", fileId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByFileId indicates an expected call of FindAllByFileId.
+func (mr *MockTagsRepositoryMockRecorder) FindAllByFileId(fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByFileId", reflect.TypeOf((*MockTagsRepository)(nil).FindAllByFileId), fileId)
+}
+
+// Save mocks base method.
+func (m *MockTagsRepository) Save(fileId, name, createdBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", fileId, name, createdBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockTagsRepositoryMockRecorder) Save(fileId, name, createdBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockTagsRepository)(nil).Save), fileId, name, createdBy)
+}
+
+// MockMetadataRepository is a mock of MetadataRepository interface.
+type MockMetadataRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMetadataRepositoryMockRecorder
+}
+
+// MockMetadataRepositoryMockRecorder is the mock recorder for MockMetadataRepository.
+type MockMetadataRepositoryMockRecorder struct {
+	mock *MockMetadataRepository
+}
+
+// NewMockMetadataRepository creates a new mock instance.
+func NewMockMetadataRepository(ctrl *gomock.Controller) *MockMetadataRepository {
+	mock := &MockMetadataRepository{ctrl: ctrl}
+	mock.recorder = &MockMetadataRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMetadataRepository) EXPECT() *MockMetadataRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindByFileId mocks base method.
+func (m *MockMetadataRepository) FindByFileId(fileId string) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByFileId", fileId)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByFileId indicates an expected call of FindByFileId.
+func (mr *MockMetadataRepositoryMockRecorder) FindByFileId(fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByFileId", reflect.TypeOf((*MockMetadataRepository)(nil).FindByFileId), fileId)
+}
+
+// Replace mocks base method.
+func (m *MockMetadataRepository) Replace(fileId string, metadata map[string]string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", fileId, metadata)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockMetadataRepositoryMockRecorder) Replace(fileId, metadata any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockMetadataRepository)(nil).Replace), fileId, metadata)
+}
+
+// MockStarsRepository is a mock of StarsRepository interface.
+type MockStarsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockStarsRepositoryMockRecorder
+}
+
+// MockStarsRepositoryMockRecorder is the mock recorder for MockStarsRepository.
+type MockStarsRepositoryMockRecorder struct {
+	mock *MockStarsRepository
+}
+
+// NewMockStarsRepository creates a new mock instance.
+func NewMockStarsRepository(ctrl *gomock.Controller) *MockStarsRepository {
+	mock := &MockStarsRepository{ctrl: ctrl}
+	mock.recorder = &MockStarsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStarsRepository) EXPECT() *MockStarsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockStarsRepository) Delete(fileId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", fileId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStarsRepositoryMockRecorder) Delete(fileId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStarsRepository)(nil).Delete), fileId, userId)
+}
+
+// Exists mocks base method.
+func (m *MockStarsRepository) Exists(fileId, userId string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", fileId, userId)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockStarsRepositoryMockRecorder) Exists(fileId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockStarsRepository)(nil).Exists), fileId, userId)
+}
+
+// Save mocks base method.
+func (m *MockStarsRepository) Save(fileId, userId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", fileId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockStarsRepositoryMockRecorder) Save(fileId, userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockStarsRepository)(nil).Save), fileId, userId)
+}
+
+// MockCommentsRepository is a mock of CommentsRepository interface.
+type MockCommentsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentsRepositoryMockRecorder
+}
+
+// MockCommentsRepositoryMockRecorder is the mock recorder for MockCommentsRepository.
+type MockCommentsRepositoryMockRecorder struct {
+	mock *MockCommentsRepository
+}
+
+// NewMockCommentsRepository creates a new mock instance.
+func NewMockCommentsRepository(ctrl *gomock.Controller) *MockCommentsRepository {
+	mock := &MockCommentsRepository{ctrl: ctrl}
+	mock.recorder = &MockCommentsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentsRepository) EXPECT() *MockCommentsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockCommentsRepository) Delete(commentId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", commentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentsRepositoryMockRecorder) Delete(commentId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCommentsRepository)(nil).Delete), commentId)
+}
+
+// FindAllByFileId mocks base method.
+func (m *MockCommentsRepository) FindAllByFileId(fileId string) ([]*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllByFileId", fileId)
+	ret0, _ := ret[0].([]*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllByFileId indicates an expected call of FindAllByFileId.
+func (mr *MockCommentsRepositoryMockRecorder) FindAllByFileId(fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllByFileId", reflect.TypeOf((*MockCommentsRepository)(nil).FindAllByFileId), fileId)
+}
+
+// FindById mocks base method.
+func (m *MockCommentsRepository) FindById(fileId, commentId string) (*entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindById", fileId, commentId)
+	ret0, _ := ret[0].(*entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindById indicates an expected call of FindById.
+func (mr *MockCommentsRepositoryMockRecorder) FindById(fileId, commentId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindById", reflect.TypeOf((*MockCommentsRepository)(nil).FindById), fileId, commentId)
+}
+
+// Save mocks base method.
+func (m *MockCommentsRepository) Save(comment *entity.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCommentsRepositoryMockRecorder) Save(comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCommentsRepository)(nil).Save), comment)
+}
+
+// Update mocks base method.
+func (m *MockCommentsRepository) Update(comment *entity.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCommentsRepositoryMockRecorder) Update(comment any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCommentsRepository)(nil).Update), comment)
+}
+
+// MockLocksRepository is a mock of LocksRepository interface.
+type MockLocksRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLocksRepositoryMockRecorder
+}
+
+// MockLocksRepositoryMockRecorder is the mock recorder for MockLocksRepository.
+type MockLocksRepositoryMockRecorder struct {
+	mock *MockLocksRepository
+}
+
+// NewMockLocksRepository creates a new mock instance.
+func NewMockLocksRepository(ctrl *gomock.Controller) *MockLocksRepository {
+	mock := &MockLocksRepository{ctrl: ctrl}
+	mock.recorder = &MockLocksRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLocksRepository) EXPECT() *MockLocksRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockLocksRepository) Delete(fileId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", fileId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLocksRepositoryMockRecorder) Delete(fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLocksRepository)(nil).Delete), fileId)
+}
+
+// FindByFileId mocks base method.
+func (m *MockLocksRepository) FindByFileId(fileId string) (*entity.Lock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByFileId", fileId)
+	ret0, _ := ret[0].(*entity.Lock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByFileId indicates an expected call of FindByFileId.
+func (mr *MockLocksRepositoryMockRecorder) FindByFileId(fileId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByFileId", reflect.TypeOf((*MockLocksRepository)(nil).FindByFileId), fileId)
+}
+
+// Save mocks base method.
+func (m *MockLocksRepository) Save(lock *entity.Lock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", lock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockLocksRepositoryMockRecorder) Save(lock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockLocksRepository)(nil).Save), lock)
+}
+
+// MockBlobsRepository is a mock of BlobsRepository interface.
+type MockBlobsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobsRepositoryMockRecorder
+}
+
+// MockBlobsRepositoryMockRecorder is the mock recorder for MockBlobsRepository.
+type MockBlobsRepositoryMockRecorder struct {
+	mock *MockBlobsRepository
+}
+
+// NewMockBlobsRepository creates a new mock instance.
+func NewMockBlobsRepository(ctrl *gomock.Controller) *MockBlobsRepository {
+	mock := &MockBlobsRepository{ctrl: ctrl}
+	mock.recorder = &MockBlobsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobsRepository) EXPECT() *MockBlobsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Acquire mocks base method.
+func (m *MockBlobsRepository) Acquire(blobId, hash string, size int64) (int64, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Acquire", blobId, hash, size)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Acquire indicates an expected call of Acquire.
+func (mr *MockBlobsRepositoryMockRecorder) Acquire(blobId, hash, size any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Acquire", reflect.TypeOf((*MockBlobsRepository)(nil).Acquire), blobId, hash, size)
+}
+
+// FindAll mocks base method.
+func (m *MockBlobsRepository) FindAll() ([]*entity.BlobRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].([]*entity.BlobRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockBlobsRepositoryMockRecorder) FindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBlobsRepository)(nil).FindAll))
+}
+
+// FindByBlobId mocks base method.
+func (m *MockBlobsRepository) FindByBlobId(blobId string) (*entity.BlobRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByBlobId", blobId)
+	ret0, _ := ret[0].(*entity.BlobRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByBlobId indicates an expected call of FindByBlobId.
+func (mr *MockBlobsRepositoryMockRecorder) FindByBlobId(blobId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByBlobId", reflect.TypeOf((*MockBlobsRepository)(nil).FindByBlobId), blobId)
+}
+
+// Release mocks base method.
+func (m *MockBlobsRepository) Release(blobId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", blobId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockBlobsRepositoryMockRecorder) Release(blobId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockBlobsRepository)(nil).Release), blobId)
+}
+
+// Rename mocks base method.
+func (m *MockBlobsRepository) Rename(fromId, toId string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", fromId, toId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockBlobsRepositoryMockRecorder) Rename(fromId, toId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockBlobsRepository)(nil).Rename), fromId, toId)
+}
+
+// MockBlobDeletionsRepository is a mock of BlobDeletionsRepository interface.
+type MockBlobDeletionsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlobDeletionsRepositoryMockRecorder
+}
+
+// MockBlobDeletionsRepositoryMockRecorder is the mock recorder for MockBlobDeletionsRepository.
+type MockBlobDeletionsRepositoryMockRecorder struct {
+	mock *MockBlobDeletionsRepository
+}
+
+// NewMockBlobDeletionsRepository creates a new mock instance.
+func NewMockBlobDeletionsRepository(ctrl *gomock.Controller) *MockBlobDeletionsRepository {
+	mock := &MockBlobDeletionsRepository{ctrl: ctrl}
+	mock.recorder = &MockBlobDeletionsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlobDeletionsRepository) EXPECT() *MockBlobDeletionsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockBlobDeletionsRepository) Delete(blobId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", blobId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockBlobDeletionsRepositoryMockRecorder) Delete(blobId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockBlobDeletionsRepository)(nil).Delete), blobId)
+}
+
+// Fail mocks base method.
+func (m *MockBlobDeletionsRepository) Fail(blobId, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Fail", blobId, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Fail indicates an expected call of Fail.
+func (mr *MockBlobDeletionsRepositoryMockRecorder) Fail(blobId, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Fail", reflect.TypeOf((*MockBlobDeletionsRepository)(nil).Fail), blobId, reason)
+}
+
+// FindAll mocks base method.
+func (m *MockBlobDeletionsRepository) FindAll() ([]*entity.BlobDeletion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll")
+	ret0, _ := ret[0].([]*entity.BlobDeletion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockBlobDeletionsRepositoryMockRecorder) FindAll() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockBlobDeletionsRepository)(nil).FindAll))
+}
+
+// Save mocks base method.
+func (m *MockBlobDeletionsRepository) Save(blobIds ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range blobIds {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Save", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockBlobDeletionsRepositoryMockRecorder) Save(blobIds ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockBlobDeletionsRepository)(nil).Save), blobIds...)
+}
+
+// MockUploadReservationsRepository is a mock of UploadReservationsRepository interface.
+type MockUploadReservationsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUploadReservationsRepositoryMockRecorder
+}
+
+// MockUploadReservationsRepositoryMockRecorder is the mock recorder for MockUploadReservationsRepository.
+type MockUploadReservationsRepositoryMockRecorder struct {
+	mock *MockUploadReservationsRepository
+}
+
+// NewMockUploadReservationsRepository creates a new mock instance.
+func NewMockUploadReservationsRepository(ctrl *gomock.Controller) *MockUploadReservationsRepository {
+	mock := &MockUploadReservationsRepository{ctrl: ctrl}
+	mock.recorder = &MockUploadReservationsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUploadReservationsRepository) EXPECT() *MockUploadReservationsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockUploadReservationsRepository) Delete(reservationId string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", reservationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockUploadReservationsRepositoryMockRecorder) Delete(reservationId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockUploadReservationsRepository)(nil).Delete), reservationId)
+}
+
+// FindAllActive mocks base method.
+func (m *MockUploadReservationsRepository) FindAllActive() ([]*entity.UploadReservation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAllActive")
+	ret0, _ := ret[0].([]*entity.UploadReservation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAllActive indicates an expected call of FindAllActive.
+func (mr *MockUploadReservationsRepositoryMockRecorder) FindAllActive() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAllActive", reflect.TypeOf((*MockUploadReservationsRepository)(nil).FindAllActive))
+}
+
+// FindReservedByUserId mocks base method.
+func (m *MockUploadReservationsRepository) FindReservedByUserId(userId string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindReservedByUserId", userId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindReservedByUserId indicates an expected call of FindReservedByUserId.
+func (mr *MockUploadReservationsRepositoryMockRecorder) FindReservedByUserId(userId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindReservedByUserId", reflect.TypeOf((*MockUploadReservationsRepository)(nil).FindReservedByUserId), userId)
+}
+
+// Save mocks base method.
+func (m *MockUploadReservationsRepository) Save(reservation *entity.UploadReservation, quota int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", reservation, quota)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockUploadReservationsRepositoryMockRecorder) Save(reservation, quota any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTxFilesRepository)(nil).Update), tx, userId, file)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUploadReservationsRepository)(nil).Save), reservation, quota)
 }
@@ -3,26 +3,190 @@ package repository
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
 )
 
-var ErrFileDoesNotExists = errors.New("file with provided ID does not exists")
+var (
+	ErrFileDoesNotExists        = errors.New("file with provided ID does not exists")
+	ErrLinkDoesNotExists        = errors.New("link with provided token does not exists")
+	ErrAccessTokenDoesNotExists = errors.New("access token with provided ID does not exists")
+	ErrFolderDoesNotExists      = errors.New("folder with provided ID does not exists")
+	ErrVersionDoesNotExists     = errors.New("version with provided number does not exists")
+	ErrTagDoesNotExists         = errors.New("tag with provided name is not attached to the file")
+	ErrCommentDoesNotExists     = errors.New("comment with provided ID does not exists")
+	ErrLockDoesNotExists        = errors.New("file is not locked")
+	ErrFileLocked               = errors.New("file is locked by another user")
+	ErrBlobRefDoesNotExists     = errors.New("blob with provided ID does not reference any content")
+	ErrQuotaExceeded            = errors.New("reservation does not fit in the space available for the user")
+)
 
 type FilesRepository interface {
 	Save(file *entity.File) error
-	FindById(userId string, fileId string) (*entity.File, error)
+	FindById(userId string, groups []string, fileId string) (*entity.File, error)
+	FindByName(ownerId string, folderId string, filename string) (*entity.File, error)
 	FindUsageByUserId(userId string) (usage int64, err error)
-	Delete(userId string, fileId string) error
-	Update(userId string, file *entity.File) error
-	FindAll(userId string, page int, size int, filename string, secret bool) (filesPage *entity.FilePage, err error)
+	Trash(userId string, groups []string, fileId string) error
+	Restore(ownerId string, fileId string) error
+	// Purge removes the file with everything attached to it and queues the blobs of the file and its
+	// versions for deletion in the same transaction
+	Purge(fileId string) error
+	Update(userId string, groups []string, file *entity.File) error
+	UpdateParent(userId string, groups []string, file *entity.File) (updated bool, err error)
+	FindAll(userId string, groups []string, page int, size int, filename string, secret bool, folderId string, tag string,
+		metadata map[string]string, starred bool) (filesPage *entity.FilePage, err error)
+	FindAllShared(userId string, groups []string, page int, size int) (filesPage *entity.FilePage, err error)
+	FindAllByOwnerId(ownerId string, page int, size int, filename string) (filesPage *entity.FilePage, err error)
+	FindAllTrashed(ownerId string, page int, size int) (filesPage *entity.FilePage, err error)
+	FindAllExpiredTrash(deletedBefore time.Time, limit int) (fileIds []string, err error)
+	// FindAllChecksummed lists, ordered by ID, the files after afterFileId that have a checksum,
+	// only FileId, Checksum and CorruptedAt are set
+	FindAllChecksummed(afterFileId string, limit int) (files []*entity.File, err error)
+	// UpdateCorruptedAt leaves the file untouched when its checksum is no longer the given one,
+	// an empty checksum matches the files without one
+	UpdateCorruptedAt(fileId string, checksum string, corruptedAt *time.Time) error
+	// FindAllSizes lists every file, trashed ones included, with only FileId, Size, Checksum, CreatedAt and UpdatedAt set
+	FindAllSizes() (files []*entity.File, err error)
+	UpdateSize(fileId string, size int64) error
+	FindAllUsage(page int, size int) (usagePage *entity.UsagePage, err error)
+	FindTotals() (totals *entity.Totals, err error)
 	DeleteFilePermissionByFileId(fileId string) error
+	SavePermission(fileId string, userId string, permission string) error
+	DeletePermission(fileId string, userId string) error
+	SaveGroupPermission(fileId string, group string, permission string) error
+	DeleteGroupPermission(fileId string, group string) error
+}
+
+type FoldersRepository interface {
+	Save(folder *entity.Folder) error
+	FindById(ownerId string, folderId string) (*entity.Folder, error)
+	FindAllByParentId(ownerId string, parentId string) ([]*entity.Folder, error)
+	FindPath(folderId string) ([]*entity.Breadcrumb, error)
+	CountChildren(folderId string) (children int64, err error)
+	Update(ownerId string, folder *entity.Folder) error
+	Delete(ownerId string, folderId string) error
+}
+
+type VersionsRepository interface {
+	Save(version *entity.FileVersion) error
+	FindAllByFileId(fileId string) ([]*entity.FileVersion, error)
+	FindByVersion(fileId string, version int64) (*entity.FileVersion, error)
+	// Delete only removes the row, the blob is left to the caller
+	Delete(versionId string) error
+	// Purge removes the row and queues its blob for deletion in the same transaction
+	Purge(versionId string) error
+	// FindAllSizes lists every version with only VersionId, Size and CreatedAt set
+	FindAllSizes() ([]*entity.FileVersion, error)
+	UpdateSize(versionId string, size int64) error
 }
 
 type TxFilesRepository interface {
 	Begin() (*sql.Tx, error)
 	Commit(tx *sql.Tx) error
-	FindById(tx *sql.Tx, userId string, fileId string) (*entity.File, error)
-	Update(tx *sql.Tx, userId string, file *entity.File) error
+	Rollback(tx *sql.Tx) error
+	FindById(tx *sql.Tx, userId string, groups []string, fileId string) (*entity.File, error)
+	FindUsageByUserId(tx *sql.Tx, userId string) (usage int64, err error)
+	Save(tx *sql.Tx, file *entity.File) error
+	Update(tx *sql.Tx, userId string, groups []string, file *entity.File) error
+	UpdateContent(tx *sql.Tx, userId string, file *entity.File) error
+	SaveVersion(tx *sql.Tx, version *entity.FileVersion) error
+	DeleteVersion(tx *sql.Tx, versionId string) error
+	UpdateOwner(tx *sql.Tx, requesterId string, fileId string, ownerId string) error
+	UpdateOwnerByOwnerId(tx *sql.Tx, requesterId string, ownerId string, newOwnerId string) (updated int64, err error)
 	DeleteFilePermissionByFileId(tx *sql.Tx, fileId string) error
+	DeleteFileLinksByFileId(tx *sql.Tx, fileId string) error
+	SavePermission(tx *sql.Tx, fileId string, userId string, permission string) error
+	DeletePermission(tx *sql.Tx, fileId string, userId string) error
+	DeletePermissionsByOwnerId(tx *sql.Tx, ownerId string, userId string) error
+	SaveOwnerEditorPermissions(tx *sql.Tx, ownerId string) error
+}
+
+type AuditRepository interface {
+	Save(entry *entity.AuditEntry) error
+	FindAll(page int, size int) (auditPage *entity.AuditPage, err error)
+}
+
+type AccessTokensRepository interface {
+	Save(token *entity.AccessToken) error
+	FindAllByUserId(userId string) ([]*entity.AccessToken, error)
+	FindByHash(tokenHash string) (*entity.AccessToken, error)
+	Delete(userId string, tokenId string) error
+	UpdateLastUsed(tokenId string) error
+}
+
+type LinksRepository interface {
+	Save(link *entity.Link) error
+	FindAllByFileId(fileId string) ([]*entity.Link, error)
+	FindByToken(token string) (*entity.Link, *entity.File, error)
+	Delete(fileId string, token string) error
+	IncrementDownloads(token string) (incremented bool, err error)
+}
+
+type TagsRepository interface {
+	Save(fileId string, name string, createdBy string) error
+	Delete(fileId string, name string) error
+	FindAllByFileId(fileId string) ([]string, error)
+	FindAll(userId string, groups []string) ([]*entity.Tag, error)
+}
+
+type MetadataRepository interface {
+	Replace(fileId string, metadata map[string]string) error
+	FindByFileId(fileId string) (map[string]string, error)
+}
+
+type StarsRepository interface {
+	Save(fileId string, userId string) error
+	Delete(fileId string, userId string) error
+	Exists(fileId string, userId string) (bool, error)
+}
+
+type CommentsRepository interface {
+	Save(comment *entity.Comment) error
+	FindById(fileId string, commentId string) (*entity.Comment, error)
+	FindAllByFileId(fileId string) ([]*entity.Comment, error)
+	Update(comment *entity.Comment) error
+	Delete(commentId string) error
+}
+
+type LocksRepository interface {
+	// Save fails with ErrFileLocked while someone else holds an unexpired lock on the file
+	Save(lock *entity.Lock) error
+	// FindByFileId returns nil when the file is not locked or its lock expired
+	FindByFileId(fileId string) (*entity.Lock, error)
+	Delete(fileId string) error
+}
+
+// BlobsRepository counts the references to each stored content. The hash returned as orphan
+// lost its last reference and its content can be removed from the storage
+type BlobsRepository interface {
+	// Acquire points blobId at the content with the given hash, releasing the content it pointed at before
+	Acquire(blobId string, hash string, size int64) (refCount int64, orphan string, err error)
+	Release(blobId string) (orphan string, err error)
+	// Rename moves the reference of fromId to toId, releasing the content toId pointed at before
+	Rename(fromId string, toId string) (orphan string, err error)
+	FindByBlobId(blobId string) (*entity.BlobRef, error)
+	FindAll() ([]*entity.BlobRef, error)
+}
+
+// BlobDeletionsRepository queues the blobs left behind by removed rows until they are removed
+// from the storage, so a failed removal is retried instead of leaving the blob forever
+type BlobDeletionsRepository interface {
+	Save(blobIds ...string) error
+	FindAll() ([]*entity.BlobDeletion, error)
+	Fail(blobId string, reason string) error
+	Delete(blobId string) error
+}
+
+// UploadReservationsRepository holds the space of uploads still being streamed, so concurrent uploads
+// cannot go over the owner quota together. Expired reservations stop counting, which releases the
+// space of uploads that never finished, e.g. because the process died
+type UploadReservationsRepository interface {
+	// Save returns ErrQuotaExceeded when the files, versions and active reservations of the user
+	// plus the new reservation would go over quota
+	Save(reservation *entity.UploadReservation, quota int64) error
+	FindReservedByUserId(userId string) (reserved int64, err error)
+	// FindAllActive lists the reservations that did not expire, whose IDs name the uploads being staged
+	FindAllActive() ([]*entity.UploadReservation, error)
+	Delete(reservationId string) error
 }
@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"errors"
+	"io"
+)
+
+var errNegativeOffset = errors.New("seek to a negative offset")
+
+type blobReader struct {
+	open   func(offset int64) (io.ReadCloser, error)
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+// NewReader exposes a blob of the given size as an io.ReadSeekCloser, so it can be served with
+// http.ServeContent. The blob is only opened on the first read after a seek, starting at the new offset
+func NewReader(store BlobStore, id string, size int64) io.ReadSeekCloser {
+	return NewOffsetReader(func(offset int64) (io.ReadCloser, error) {
+		return store.Get(id, offset, -1)
+	}, size)
+}
+
+// NewOffsetReader is like NewReader for content that is not read straight from a store,
+// where open returns the content of the given size starting at offset
+func NewOffsetReader(open func(offset int64) (io.ReadCloser, error), size int64) io.ReadSeekCloser {
+	return &blobReader{open: open, size: size}
+}
+
+func (b *blobReader) Read(p []byte) (n int, err error) {
+	if b.offset >= b.size {
+		return 0, io.EOF
+	}
+
+	if b.body == nil {
+		if b.body, err = b.open(b.offset); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err = b.body.Read(p)
+	b.offset += int64(n)
+
+	return n, err
+}
+
+func (b *blobReader) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += b.offset
+	case io.SeekEnd:
+		offset += b.size
+	}
+
+	if offset < 0 {
+		return b.offset, errNegativeOffset
+	}
+
+	if offset != b.offset {
+		b.closeBody()
+		b.offset = offset
+	}
+
+	return b.offset, nil
+}
+
+func (b *blobReader) Close() error {
+	return b.closeBody()
+}
+
+func (b *blobReader) closeBody() (err error) {
+	if b.body != nil {
+		err = b.body.Close()
+		b.body = nil
+	}
+
+	return err
+}
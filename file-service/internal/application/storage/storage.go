@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+)
+
+var (
+	ErrBlobDoesNotExists = errors.New("blob with provided ID does not exists")
+	ErrBlobCorrupted     = errors.New("blob content does not match what was stored")
+)
+
+type BlobInfo struct {
+	Id         string
+	Size       int64
+	ModifiedAt time.Time
+}
+
+// BlobStore keeps the content of files and versions, addressed by the file or version ID
+type BlobStore interface {
+	Put(id string, src io.Reader) (written int64, err error)
+	// Get reads length bytes starting at offset. A negative length reads until the end of the blob
+	Get(id string, offset int64, length int64) (content io.ReadCloser, err error)
+	// Delete does not fail when the blob is already gone
+	Delete(id string) error
+	Stat(id string) (info *BlobInfo, err error)
+	List() (blobs []*BlobInfo, err error)
+	Move(fromId string, toId string) error
+}
+
+// Mirror is implemented by stores that keep a copy of every blob on two disks
+type Mirror interface {
+	Status() *entity.StorageStatus
+	// Resync brings every disk in line with the other one, returning how many blobs it copied or deleted
+	Resync() (repaired int, err error)
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+)
+
+type CopyFileUseCase interface {
+	Execute(ctx context.Context, fileId string, folderId string, filename string) (copied *entity.File, err error)
+}
+
+type copyFileUseCase struct {
+	blobStore         storage.BlobStore
+	filesRepository   repository.FilesRepository
+	foldersRepository repository.FoldersRepository
+	createFileUseCase CreateFileUseCase
+}
+
+func NewCopyFileUseCase(blobStore storage.BlobStore, filesRepository repository.FilesRepository, foldersRepository repository.FoldersRepository,
+	createFileUseCase CreateFileUseCase) *copyFileUseCase {
+	return &copyFileUseCase{
+		blobStore:         blobStore,
+		filesRepository:   filesRepository,
+		foldersRepository: foldersRepository,
+		createFileUseCase: createFileUseCase,
+	}
+}
+
+// Execute duplicates the file blob and metadata. The copy belongs to the requester,
+// so it is placed in one of their folders and counts toward their quota
+func (c *copyFileUseCase) Execute(ctx context.Context, fileId string, folderId string, filename string) (copied *entity.File, err error) {
+	user := ctx.Value(m.UserClaimsCtxKey).(jwt.Token)
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+	groups := m.UserGroups(user)
+
+	source, err := c.filesRepository.FindById(user.Subject(), groups, fileId)
+
+	if err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if !source.CanEdit(user.Subject(), groups) {
+		slog.Info("Requester cannot edit file", "traceId", traceId, "fileId", fileId)
+		return nil, facade.ErrNotFileEditor
+	}
+
+	if folderId != "" {
+		if _, err := c.foldersRepository.FindById(user.Subject(), folderId); err != nil {
+			slog.Info("Target folder not found", "traceId", traceId, "folderId", folderId, "error", err)
+			return nil, err
+		}
+	}
+
+	if filename == "" {
+		filename = source.Filename
+	}
+
+	copied = entity.NewFile(filename, source.Size, source.Secret, user.Subject())
+	copied.ParentId = folderId
+	copied.Checksum = source.Checksum
+
+	// checked again when the copy is created, this only avoids copying content that cannot be kept
+	if err := c.createFileUseCase.CheckSpace(copied); err != nil {
+		return nil, err
+	}
+
+	if err := c.copyBlob(source.FileId, copied.FileId); err != nil {
+		slog.Error("Could not copy file in storage", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if err := c.createFileUseCase.Execute(copied); err != nil {
+		if err := c.blobStore.Delete(copied.FileId); err != nil {
+			slog.Error("Could not remove copied file from storage", "traceId", traceId, "fileId", copied.FileId, "error", err)
+		}
+
+		return nil, err
+	}
+
+	slog.Info("File copied successfully", "traceId", traceId, "fileId", fileId, "copyId", copied.FileId)
+	return copied, nil
+}
+
+func (c *copyFileUseCase) copyBlob(sourceId string, targetId string) error {
+	src, err := c.blobStore.Get(sourceId, 0, -1)
+
+	if err != nil {
+		return err
+	}
+
+	defer src.Close()
+
+	_, err = c.blobStore.Put(targetId, src)
+
+	return err
+}
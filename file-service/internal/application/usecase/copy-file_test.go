@@ -0,0 +1,133 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCopyFileUseCase(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Storage.Path = t.TempDir()
+	cfg.Storage.Limit = "1000M"
+
+	blobStore, err := storage.NewLocalBlobStore(cfg.Storage.Path + "/storage")
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(cfg.Storage.Path+"/storage/fileId", []byte("content"), 0600))
+
+	token := jwt.New()
+	err = token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.WithValue(context.Background(),
+		chiMiddleware.RequestIDKey, "trace12345"),
+		middleware.UserClaimsCtxKey, token)
+
+	t.Run("happy path", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+		folders := mocks.NewMockFoldersRepository(mockCtrl)
+
+		fr.EXPECT().FindById("userId", []string{}, "fileId").Return(&entity.File{
+			FileId:   "fileId",
+			Filename: "report.pdf",
+			Owner:    "otherUser",
+			Editors:  []string{"userId"},
+			Size:     7,
+		}, nil)
+		folders.EXPECT().FindById("userId", "folderId").Return(&entity.Folder{FolderId: "folderId"}, nil)
+		fr.EXPECT().FindUsageByUserId("userId").Return(int64(0), nil).Times(2)
+		urr.EXPECT().FindReservedByUserId("userId").Return(int64(0), nil).Times(2)
+		fr.EXPECT().Save(gomock.Any()).Return(nil)
+
+		useCase := usecase.NewCopyFileUseCase(blobStore, fr, folders, usecase.NewCreateFileUseCase(cfg, fr, urr))
+
+		copied, err := useCase.Execute(ctx, "fileId", "folderId", "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "report.pdf", copied.Filename)
+		assert.Equal(t, "userId", copied.Owner)
+		assert.Equal(t, "folderId", copied.ParentId)
+
+		content, err := os.ReadFile(cfg.Storage.Path + "/storage/" + copied.FileId)
+		assert.NoError(t, err)
+		assert.Equal(t, "content", string(content))
+	})
+
+	t.Run("should not copy when requester is a viewer", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		fr.EXPECT().FindById("userId", []string{}, "fileId").Return(&entity.File{
+			FileId:  "fileId",
+			Owner:   "otherUser",
+			Viewers: []string{"userId"},
+		}, nil)
+
+		useCase := usecase.NewCopyFileUseCase(blobStore, fr, nil, usecase.NewCreateFileUseCase(cfg, fr, urr))
+
+		copied, err := useCase.Execute(ctx, "fileId", "", "")
+
+		assert.Equal(t, facade.ErrNotFileEditor, err)
+		assert.Nil(t, copied)
+	})
+
+	t.Run("should not copy into unknown folder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+		folders := mocks.NewMockFoldersRepository(mockCtrl)
+
+		fr.EXPECT().FindById("userId", []string{}, "fileId").Return(&entity.File{FileId: "fileId", Owner: "userId"}, nil)
+		folders.EXPECT().FindById("userId", "folderId").Return(nil, repository.ErrFolderDoesNotExists)
+
+		useCase := usecase.NewCopyFileUseCase(blobStore, fr, folders, usecase.NewCreateFileUseCase(cfg, fr, urr))
+
+		_, err := useCase.Execute(ctx, "fileId", "folderId", "")
+
+		assert.Equal(t, repository.ErrFolderDoesNotExists, err)
+	})
+
+	t.Run("should not copy blob when there is no space available", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		fr.EXPECT().FindById("userId", []string{}, "fileId").Return(&entity.File{
+			FileId: "fileId",
+			Owner:  "userId",
+			Size:   toMb(600),
+		}, nil)
+		fr.EXPECT().FindUsageByUserId("userId").Return(toMb(300), nil)
+		urr.EXPECT().FindReservedByUserId("userId").Return(toMb(300), nil)
+
+		useCase := usecase.NewCopyFileUseCase(blobStore, fr, nil, usecase.NewCreateFileUseCase(cfg, fr, urr))
+
+		copied, err := useCase.Execute(ctx, "fileId", "", "copy.pdf")
+
+		assert.Equal(t, usecase.ErrNotAvailableSpace, err)
+		assert.Nil(t, copied)
+
+		entries, err := os.ReadDir(cfg.Storage.Path + "/storage")
+		assert.NoError(t, err)
+
+		// the source and the copy made in the happy path only
+		assert.Len(t, entries, 2)
+	})
+}
@@ -0,0 +1,164 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/parser"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+)
+
+type CreateVersionUseCase interface {
+	Execute(ctx context.Context, fileId string, content *entity.File) (file *entity.File, err error)
+}
+
+type createVersionUseCase struct {
+	config                  *config.Config
+	blobStore               storage.BlobStore
+	filesRepository         repository.FilesRepository
+	txFilesRepository       repository.TxFilesRepository
+	versionsRepository      repository.VersionsRepository
+	locksRepository         repository.LocksRepository
+	blobDeletionsRepository repository.BlobDeletionsRepository
+}
+
+func NewCreateVersionUseCase(config *config.Config, blobStore storage.BlobStore, filesRepository repository.FilesRepository,
+	txFilesRepository repository.TxFilesRepository, versionsRepository repository.VersionsRepository, locksRepository repository.LocksRepository,
+	blobDeletionsRepository repository.BlobDeletionsRepository) *createVersionUseCase {
+	return &createVersionUseCase{
+		config:                  config,
+		blobStore:               blobStore,
+		filesRepository:         filesRepository,
+		txFilesRepository:       txFilesRepository,
+		versionsRepository:      versionsRepository,
+		locksRepository:         locksRepository,
+		blobDeletionsRepository: blobDeletionsRepository,
+	}
+}
+
+// Execute makes the blob already uploaded under content.FileId the current content of the file.
+// The previous content is kept as the newest version, and the oldest versions are dropped
+// once the file goes over versions.max-per-file. Every version counts toward the owner quota.
+// The rows are updated in a single transaction before the blobs are moved, and they are put
+// back as they were when moving fails
+func (c *createVersionUseCase) Execute(ctx context.Context, fileId string, content *entity.File) (file *entity.File, err error) {
+	user := ctx.Value(m.UserClaimsCtxKey).(jwt.Token)
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+	groups := m.UserGroups(user)
+
+	file, err = c.filesRepository.FindById(user.Subject(), groups, fileId)
+
+	if err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if !file.CanEdit(user.Subject(), groups) {
+		slog.Info("Requester cannot edit file", "traceId", traceId, "fileId", fileId)
+		return nil, facade.ErrNotFileEditor
+	}
+
+	lock, err := c.locksRepository.FindByFileId(fileId)
+
+	if err != nil {
+		slog.Error("Could not find file lock", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if lock.Blocks(user.Subject()) {
+		slog.Info("Refusing new content for file locked by another user", "traceId", traceId, "fileId", fileId)
+		return nil, repository.ErrFileLocked
+	}
+
+	versions, err := c.versionsRepository.FindAllByFileId(fileId)
+
+	if err != nil {
+		slog.Error("Could not find file versions", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	maxVersions := c.config.Versions.MaxPerFile
+	dropped, freed := replacedContent(maxVersions, file, versions)
+
+	usage, err := c.filesRepository.FindUsageByUserId(file.Owner)
+
+	if err != nil {
+		slog.Error("Could not find owner usage", "traceId", traceId, "userId", file.Owner, "error", err)
+		return nil, err
+	}
+
+	available := int64(parser.ParseUsage(c.config.Storage.Limit)) - usage
+
+	if content.Size-freed > available {
+		slog.Info("Could not create version because available storage for owner is insufficient", "traceId", traceId, "userId", file.Owner, "available", available)
+		return nil, ErrNotAvailableSpace
+	}
+
+	previous := &entity.File{FileId: file.FileId, Size: file.Size, Checksum: file.Checksum}
+	moves := []blobMove{{from: content.FileId, to: file.FileId}}
+
+	var archived []*entity.FileVersion
+
+	if maxVersions > 0 {
+		version := entity.NewFileVersion(file.FileId, file.Size, user.Subject())
+		version.Checksum = file.Checksum
+
+		archived = append(archived, version)
+		moves = append([]blobMove{{from: file.FileId, to: version.VersionId}}, moves...)
+	}
+
+	file.Size = content.Size
+	file.Checksum = content.Checksum
+
+	if err := updateContent(c.txFilesRepository, user.Subject(), file, archived, nil); err != nil {
+		slog.Error("Could not update file content", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if err := moveBlobs(traceId, c.blobStore, moves...); err != nil {
+		slog.Error("Could not replace current content in storage", "traceId", traceId, "fileId", fileId, "error", err)
+
+		if err := updateContent(c.txFilesRepository, user.Subject(), previous, nil, archived); err != nil {
+			slog.Error("Could not revert file content", "traceId", traceId, "fileId", fileId, "error", err)
+		}
+
+		return nil, err
+	}
+
+	for _, version := range dropped {
+		if err := c.versionsRepository.Purge(version.VersionId); err != nil {
+			slog.Error("Could not remove exceeding version", "traceId", traceId, "versionId", version.VersionId, "error", err)
+			continue
+		}
+
+		removeBlobs(traceId, c.blobStore, c.blobDeletionsRepository, version.VersionId)
+	}
+
+	slog.Info("File content updated successfully", "traceId", traceId, "fileId", fileId, "dropped", len(dropped))
+	return file, nil
+}
+
+// replacedContent returns the versions dropped when file gets new content and the space given back by
+// them, which includes the current content when no versions are kept. versions are sorted newest first
+func replacedContent(maxVersions int, file *entity.File, versions []*entity.FileVersion) (dropped []*entity.FileVersion, freed int64) {
+	if maxVersions > 0 && len(versions) >= maxVersions {
+		dropped = versions[maxVersions-1:]
+	}
+
+	if maxVersions <= 0 {
+		freed = file.Size
+	}
+
+	for _, version := range dropped {
+		freed += version.Size
+	}
+
+	return dropped, freed
+}
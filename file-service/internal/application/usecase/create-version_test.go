@@ -0,0 +1,194 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCreateVersionUseCase(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Storage.Path = t.TempDir()
+	cfg.Storage.Limit = "1000M"
+	cfg.Versions.MaxPerFile = 2
+
+	blobStore, err := storage.NewLocalBlobStore(cfg.Storage.Path + "/storage")
+	assert.NoError(t, err)
+
+	token := jwt.New()
+	err = token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.WithValue(context.Background(),
+		chiMiddleware.RequestIDKey, "trace12345"),
+		middleware.UserClaimsCtxKey, token)
+
+	t.Run("happy path", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(cfg.Storage.Path+"/storage/fileId", []byte("content"), 0600))
+		assert.NoError(t, os.WriteFile(cfg.Storage.Path+"/storage/stagedId", []byte("new content"), 0600))
+		assert.NoError(t, os.WriteFile(cfg.Storage.Path+"/storage/oldestId", []byte("c"), 0600))
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		tr := mocks.NewMockTxFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		lr := mocks.NewMockLocksRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		fr.EXPECT().FindById("userId", []string{}, "fileId").Return(&entity.File{
+			FileId:  "fileId",
+			Owner:   "otherUser",
+			Editors: []string{"userId"},
+			Size:    7,
+		}, nil)
+		lr.EXPECT().FindByFileId("fileId").Return(entity.NewLock("fileId", "userId", time.Hour), nil)
+		vr.EXPECT().FindAllByFileId("fileId").Return([]*entity.FileVersion{
+			{VersionId: "newestId", Version: 2, Size: 3},
+			{VersionId: "oldestId", Version: 1, Size: 1},
+		}, nil)
+		fr.EXPECT().FindUsageByUserId("otherUser").Return(int64(0), nil)
+		tr.EXPECT().Begin().Return(nil, nil)
+		tr.EXPECT().SaveVersion(nil, gomock.Any()).DoAndReturn(func(_ any, version *entity.FileVersion) error {
+			assert.Equal(t, "fileId", version.FileId)
+			assert.Equal(t, int64(7), version.Size)
+			assert.Equal(t, "userId", version.CreatedBy)
+			// blobs are moved only after the rows are committed
+			assert.NoFileExists(t, cfg.Storage.Path+"/storage/"+version.VersionId)
+			return nil
+		})
+		tr.EXPECT().UpdateContent(nil, "userId", gomock.Any()).Return(nil)
+		tr.EXPECT().Commit(nil).Return(nil)
+		tr.EXPECT().Rollback(nil).Return(nil)
+		vr.EXPECT().Purge("oldestId").Return(nil)
+		bdr.EXPECT().Delete("oldestId").Return(nil)
+
+		file, err := usecase.NewCreateVersionUseCase(cfg, blobStore, fr, tr, vr, lr, bdr).Execute(ctx, "fileId", &entity.File{FileId: "stagedId", Size: 11})
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(11), file.Size)
+
+		content, err := os.ReadFile(cfg.Storage.Path + "/storage/fileId")
+		assert.NoError(t, err)
+		assert.Equal(t, "new content", string(content))
+		assert.NoFileExists(t, cfg.Storage.Path+"/storage/stagedId")
+		assert.NoFileExists(t, cfg.Storage.Path+"/storage/oldestId")
+	})
+
+	t.Run("should return error when requester cannot edit file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		tr := mocks.NewMockTxFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		lr := mocks.NewMockLocksRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		fr.EXPECT().FindById("userId", []string{}, "fileId").Return(&entity.File{
+			FileId:  "fileId",
+			Owner:   "otherUser",
+			Viewers: []string{"userId"},
+		}, nil)
+
+		_, err := usecase.NewCreateVersionUseCase(cfg, blobStore, fr, tr, vr, lr, bdr).Execute(ctx, "fileId", &entity.File{FileId: "stagedId", Size: 11})
+
+		assert.ErrorIs(t, err, facade.ErrNotFileEditor)
+	})
+
+	t.Run("should return error when owner has not enough space", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(cfg.Storage.Path+"/storage/fileId", []byte("content"), 0600))
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		tr := mocks.NewMockTxFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		lr := mocks.NewMockLocksRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		fr.EXPECT().FindById("userId", []string{}, "fileId").Return(&entity.File{FileId: "fileId", Owner: "userId", Size: 7}, nil)
+		lr.EXPECT().FindByFileId("fileId").Return(nil, nil)
+		vr.EXPECT().FindAllByFileId("fileId").Return([]*entity.FileVersion{}, nil)
+		fr.EXPECT().FindUsageByUserId("userId").Return(int64(1000*1024*1024), nil)
+
+		_, err := usecase.NewCreateVersionUseCase(cfg, blobStore, fr, tr, vr, lr, bdr).Execute(ctx, "fileId", &entity.File{FileId: "stagedId", Size: 11})
+
+		assert.ErrorIs(t, err, usecase.ErrNotAvailableSpace)
+		assert.FileExists(t, cfg.Storage.Path+"/storage/fileId")
+	})
+
+	t.Run("should return error when file is locked by another user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		tr := mocks.NewMockTxFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		lr := mocks.NewMockLocksRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		fr.EXPECT().FindById("userId", []string{}, "fileId").Return(&entity.File{FileId: "fileId", Owner: "userId", Size: 7}, nil)
+		lr.EXPECT().FindByFileId("fileId").Return(entity.NewLock("fileId", "otherUser", time.Hour), nil)
+
+		_, err := usecase.NewCreateVersionUseCase(cfg, blobStore, fr, tr, vr, lr, bdr).Execute(ctx, "fileId", &entity.File{FileId: "stagedId", Size: 11})
+
+		assert.ErrorIs(t, err, repository.ErrFileLocked)
+	})
+
+	t.Run("should put rows back when content cannot be moved into place", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(cfg.Storage.Path+"/storage/fileId", []byte("content"), 0600))
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		tr := mocks.NewMockTxFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		lr := mocks.NewMockLocksRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		fr.EXPECT().FindById("userId", []string{}, "fileId").Return(&entity.File{FileId: "fileId", Owner: "userId", Size: 7, Checksum: "old"}, nil)
+		lr.EXPECT().FindByFileId("fileId").Return(nil, nil)
+		vr.EXPECT().FindAllByFileId("fileId").Return([]*entity.FileVersion{}, nil)
+		fr.EXPECT().FindUsageByUserId("userId").Return(int64(0), nil)
+
+		var archived *entity.FileVersion
+
+		tr.EXPECT().Begin().Return(nil, nil).Times(2)
+		tr.EXPECT().SaveVersion(nil, gomock.Any()).DoAndReturn(func(_ any, version *entity.FileVersion) error {
+			archived = version
+			return nil
+		})
+		tr.EXPECT().UpdateContent(nil, "userId", gomock.Any()).DoAndReturn(func(_ any, _ string, file *entity.File) error {
+			assert.Equal(t, int64(11), file.Size)
+			return nil
+		})
+		tr.EXPECT().DeleteVersion(nil, gomock.Any()).DoAndReturn(func(_ any, versionId string) error {
+			assert.Equal(t, archived.VersionId, versionId)
+			return nil
+		})
+		tr.EXPECT().UpdateContent(nil, "userId", gomock.Any()).DoAndReturn(func(_ any, _ string, file *entity.File) error {
+			assert.Equal(t, int64(7), file.Size)
+			assert.Equal(t, "old", file.Checksum)
+			return nil
+		})
+		tr.EXPECT().Commit(nil).Return(nil).Times(2)
+		tr.EXPECT().Rollback(nil).Return(nil).Times(2)
+
+		_, err := usecase.NewCreateVersionUseCase(cfg, blobStore, fr, tr, vr, lr, bdr).Execute(ctx, "fileId", &entity.File{FileId: "missingId", Size: 11})
+
+		assert.Error(t, err)
+
+		content, err := os.ReadFile(cfg.Storage.Path + "/storage/fileId")
+		assert.NoError(t, err)
+		assert.Equal(t, "content", string(content))
+		assert.NoFileExists(t, cfg.Storage.Path+"/storage/"+archived.VersionId)
+	})
+}
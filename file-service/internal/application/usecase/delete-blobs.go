@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+)
+
+type DeleteBlobsUseCase interface {
+	Execute(ctx context.Context) (deleted int, err error)
+}
+
+type deleteBlobsUseCase struct {
+	blobStore               storage.BlobStore
+	blobDeletionsRepository repository.BlobDeletionsRepository
+}
+
+func NewDeleteBlobsUseCase(blobStore storage.BlobStore, blobDeletionsRepository repository.BlobDeletionsRepository) *deleteBlobsUseCase {
+	return &deleteBlobsUseCase{blobStore: blobStore, blobDeletionsRepository: blobDeletionsRepository}
+}
+
+// Execute retries the removal of the blobs that are still queued for deletion,
+// usually because the storage failed when their rows were removed
+func (d *deleteBlobsUseCase) Execute(ctx context.Context) (deleted int, err error) {
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+
+	deletions, err := d.blobDeletionsRepository.FindAll()
+
+	if err != nil {
+		slog.Error("Could not find blobs queued for deletion", "traceId", traceId, "error", err)
+		return 0, err
+	}
+
+	blobIds := make([]string, len(deletions))
+
+	for i, deletion := range deletions {
+		blobIds[i] = deletion.BlobId
+	}
+
+	deleted = removeBlobs(traceId, d.blobStore, d.blobDeletionsRepository, blobIds...)
+
+	if len(blobIds) > 0 {
+		slog.Info("Queued blobs deleted", "traceId", traceId, "deleted", deleted, "pending", len(blobIds)-deleted)
+	}
+
+	return deleted, nil
+}
+
+// removeBlobs removes from storage blobs already queued for deletion and takes them off the queue.
+// The ones that could not be removed stay queued, so the next DeleteBlobsUseCase run retries them
+func removeBlobs(traceId string, blobStore storage.BlobStore, blobDeletionsRepository repository.BlobDeletionsRepository, blobIds ...string) (removed int) {
+	for _, blobId := range blobIds {
+		if err := blobStore.Delete(blobId); err != nil {
+			slog.Error("Could not remove blob from storage, it stays queued for deletion", "traceId", traceId, "blobId", blobId, "error", err)
+
+			if err := blobDeletionsRepository.Fail(blobId, err.Error()); err != nil {
+				slog.Error("Could not record blob deletion failure", "traceId", traceId, "blobId", blobId, "error", err)
+			}
+
+			continue
+		}
+
+		// a blob left queued is only removed again, which storage allows
+		if err := blobDeletionsRepository.Delete(blobId); err != nil {
+			slog.Error("Could not take removed blob off the deletion queue", "traceId", traceId, "blobId", blobId, "error", err)
+		}
+
+		removed++
+	}
+
+	return removed
+}
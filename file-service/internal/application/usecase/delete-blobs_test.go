@@ -0,0 +1,66 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestDeleteBlobsUseCase(t *testing.T) {
+	root := t.TempDir()
+
+	blobStore, err := storage.NewLocalBlobStore(root)
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), chiMiddleware.RequestIDKey, "trace12345")
+
+	t.Run("happy path", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(root+"/queued", []byte("content"), 0600))
+
+		mockCtrl := gomock.NewController(t)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		bdr.EXPECT().FindAll().Return([]*entity.BlobDeletion{{BlobId: "queued", Attempts: 2}, {BlobId: "already-gone"}}, nil)
+		bdr.EXPECT().Delete("queued").Return(nil)
+		bdr.EXPECT().Delete("already-gone").Return(nil)
+
+		deleted, err := usecase.NewDeleteBlobsUseCase(blobStore, bdr).Execute(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, deleted)
+		assert.NoFileExists(t, root+"/queued")
+	})
+
+	t.Run("should keep blob queued when storage fails again", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		bdr.EXPECT().FindAll().Return([]*entity.BlobDeletion{{BlobId: "queued"}}, nil)
+		bdr.EXPECT().Fail("queued", "disk is read-only").Return(nil)
+
+		deleted, err := usecase.NewDeleteBlobsUseCase(&failingDeleteBlobStore{BlobStore: blobStore}, bdr).Execute(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, deleted)
+	})
+
+	t.Run("should return error when queue could not be read", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		bdr.EXPECT().FindAll().Return(nil, errors.New("generic error"))
+
+		_, err := usecase.NewDeleteBlobsUseCase(blobStore, bdr).Execute(ctx)
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+)
+
+type DeleteVersionUseCase interface {
+	Execute(ctx context.Context, fileId string, version int64) (err error)
+}
+
+type deleteVersionUseCase struct {
+	blobStore               storage.BlobStore
+	filesRepository         repository.FilesRepository
+	versionsRepository      repository.VersionsRepository
+	locksRepository         repository.LocksRepository
+	blobDeletionsRepository repository.BlobDeletionsRepository
+}
+
+func NewDeleteVersionUseCase(blobStore storage.BlobStore, filesRepository repository.FilesRepository,
+	versionsRepository repository.VersionsRepository, locksRepository repository.LocksRepository,
+	blobDeletionsRepository repository.BlobDeletionsRepository) *deleteVersionUseCase {
+	return &deleteVersionUseCase{blobStore: blobStore, filesRepository: filesRepository, versionsRepository: versionsRepository,
+		locksRepository: locksRepository, blobDeletionsRepository: blobDeletionsRepository}
+}
+
+func (d *deleteVersionUseCase) Execute(ctx context.Context, fileId string, version int64) (err error) {
+	user := ctx.Value(m.UserClaimsCtxKey).(jwt.Token)
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+	groups := m.UserGroups(user)
+
+	file, err := d.filesRepository.FindById(user.Subject(), groups, fileId)
+
+	if err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	if !file.CanEdit(user.Subject(), groups) {
+		slog.Info("Requester cannot edit file", "traceId", traceId, "fileId", fileId)
+		return facade.ErrNotFileEditor
+	}
+
+	lock, err := d.locksRepository.FindByFileId(fileId)
+
+	if err != nil {
+		slog.Error("Could not find file lock", "traceId", traceId, "fileId", fileId, "error", err)
+		return err
+	}
+
+	if lock.Blocks(user.Subject()) {
+		slog.Info("Refusing to delete version of file locked by another user", "traceId", traceId, "fileId", fileId)
+		return repository.ErrFileLocked
+	}
+
+	target, err := d.versionsRepository.FindByVersion(fileId, version)
+
+	if err != nil {
+		slog.Info("Could not find file version", "traceId", traceId, "fileId", fileId, "version", version, "error", err)
+		return err
+	}
+
+	if err := d.versionsRepository.Purge(target.VersionId); err != nil {
+		slog.Error("Could not delete file version", "traceId", traceId, "versionId", target.VersionId, "error", err)
+		return err
+	}
+
+	removeBlobs(traceId, d.blobStore, d.blobDeletionsRepository, target.VersionId)
+
+	slog.Info("File version deleted successfully", "traceId", traceId, "fileId", fileId, "version", version)
+	return nil
+}
@@ -2,32 +2,35 @@ package usecase
 
 import (
 	"context"
+	"io"
 	"log/slog"
-	"os"
 
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/compression"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
 )
 
 type DownloadFileUseCase interface {
-	Execute(ctx context.Context, fileId string) (file *os.File, err error)
+	Execute(ctx context.Context, fileId string) (file io.ReadSeekCloser, err error)
 }
 
 type downloadFileUseCase struct {
-	config *config.Config
+	blobStore storage.BlobStore
 }
 
-func NewDownloadFileUseCase(config *config.Config) *downloadFileUseCase {
-	return &downloadFileUseCase{config: config}
+func NewDownloadFileUseCase(blobStore storage.BlobStore) *downloadFileUseCase {
+	return &downloadFileUseCase{blobStore: blobStore}
 }
 
-func (d *downloadFileUseCase) Execute(ctx context.Context, fileId string) (file *os.File, err error) {
+// Execute opens the blob stored under fileId, decompressing it when it was stored compressed.
+// The content is only read from the store when the returned reader is read, at the offset it was seeked to
+func (d *downloadFileUseCase) Execute(ctx context.Context, fileId string) (file io.ReadSeekCloser, err error) {
 	traceId := ctx.Value(middleware.RequestIDKey).(string)
 
-	file, err = os.Open(d.config.Storage.Path + "/storage/" + fileId)
+	file, err = compression.Open(d.blobStore, fileId)
 
 	if err != nil {
-		slog.Error("Could not open file in fs", "traceId", traceId, "error", err)
+		slog.Error("Could not open file in storage", "traceId", traceId, "error", err)
 		return
 	}
 
@@ -11,6 +11,7 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,6 +46,9 @@ func TestDownloadFileUseCase(t *testing.T) {
 	fileId, err := createFile(seed)
 	assert.NoError(t, err)
 
+	blobStore, err := storage.NewLocalBlobStore(mockConfig.Storage.Path + "/storage")
+	assert.NoError(t, err)
+
 	ctx := context.Background()
 	ctx = context.WithValue(ctx, middleware.RequestIDKey, "test-trace-id")
 
@@ -55,7 +59,7 @@ func TestDownloadFileUseCase(t *testing.T) {
 	})
 
 	t.Run("happy path", func(t *testing.T) {
-		uc := usecase.NewDownloadFileUseCase(mockConfig)
+		uc := usecase.NewDownloadFileUseCase(blobStore)
 
 		file, err := uc.Execute(ctx, fileId)
 
@@ -73,7 +77,7 @@ func TestDownloadFileUseCase(t *testing.T) {
 	})
 
 	t.Run("should return error when file does not exists", func(t *testing.T) {
-		uc := usecase.NewDownloadFileUseCase(mockConfig)
+		uc := usecase.NewDownloadFileUseCase(blobStore)
 
 		_, err := uc.Execute(ctx, "no-exists")
 
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+)
+
+const purgeBatchSize = 100
+
+type PurgeTrashUseCase interface {
+	Execute(ctx context.Context) (purged int, err error)
+}
+
+type purgeTrashUseCase struct {
+	config                  *config.Config
+	blobStore               storage.BlobStore
+	filesRepository         repository.FilesRepository
+	versionsRepository      repository.VersionsRepository
+	blobDeletionsRepository repository.BlobDeletionsRepository
+}
+
+func NewPurgeTrashUseCase(config *config.Config, blobStore storage.BlobStore, filesRepository repository.FilesRepository,
+	versionsRepository repository.VersionsRepository, blobDeletionsRepository repository.BlobDeletionsRepository) *purgeTrashUseCase {
+	return &purgeTrashUseCase{config: config, blobStore: blobStore, filesRepository: filesRepository, versionsRepository: versionsRepository,
+		blobDeletionsRepository: blobDeletionsRepository}
+}
+
+// Execute permanently removes the files that stayed in the trash longer than the retention period.
+// The row is deleted before the blob, so a file is never listed without its content, and the blobs
+// that could not be removed stay queued for deletion
+func (p *purgeTrashUseCase) Execute(ctx context.Context) (purged int, err error) {
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+
+	deletedBefore := time.Now().AddDate(0, 0, -p.config.Trash.RetentionDays)
+
+	for {
+		fileIds, err := p.filesRepository.FindAllExpiredTrash(deletedBefore, purgeBatchSize)
+
+		if err != nil {
+			slog.Error("Could not find expired trashed files", "traceId", traceId, "error", err)
+			return purged, err
+		}
+
+		for _, fileId := range fileIds {
+			versions, err := p.versionsRepository.FindAllByFileId(fileId)
+
+			if err != nil {
+				slog.Error("Could not find versions of purged file", "traceId", traceId, "fileId", fileId, "error", err)
+				return purged, err
+			}
+
+			if err := p.filesRepository.Purge(fileId); err != nil {
+				slog.Error("Could not purge file from database", "traceId", traceId, "fileId", fileId, "error", err)
+				return purged, err
+			}
+
+			blobIds := []string{fileId}
+
+			for _, version := range versions {
+				blobIds = append(blobIds, version.VersionId)
+			}
+
+			removeBlobs(traceId, p.blobStore, p.blobDeletionsRepository, blobIds...)
+
+			purged++
+		}
+
+		if len(fileIds) < purgeBatchSize {
+			break
+		}
+	}
+
+	if purged > 0 {
+		slog.Info("Trash purged successfully", "traceId", traceId, "purged", purged)
+	}
+
+	return purged, nil
+}
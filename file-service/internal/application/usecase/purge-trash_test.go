@@ -0,0 +1,105 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository/mocks"
+	appStorage "github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPurgeTrashUseCase(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Storage.Path = t.TempDir()
+	cfg.Trash.RetentionDays = 30
+
+	blobStore, err := storage.NewLocalBlobStore(cfg.Storage.Path + "/storage")
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), chiMiddleware.RequestIDKey, "trace12345")
+
+	t.Run("happy path", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(cfg.Storage.Path+"/storage/expired", []byte("content"), 0600))
+		assert.NoError(t, os.WriteFile(cfg.Storage.Path+"/storage/expired-v1", []byte("old content"), 0600))
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		vr.EXPECT().FindAllByFileId("expired").Return([]*entity.FileVersion{{VersionId: "expired-v1"}}, nil)
+		vr.EXPECT().FindAllByFileId("missing-blob").Return([]*entity.FileVersion{}, nil)
+		fr.EXPECT().FindAllExpiredTrash(gomock.Any(), 100).DoAndReturn(func(deletedBefore time.Time, limit int) ([]string, error) {
+			assert.WithinDuration(t, time.Now().AddDate(0, 0, -30), deletedBefore, time.Minute)
+			return []string{"expired", "missing-blob"}, nil
+		})
+		fr.EXPECT().Purge("expired").Return(nil)
+		fr.EXPECT().Purge("missing-blob").Return(nil)
+		bdr.EXPECT().Delete("expired").Return(nil)
+		bdr.EXPECT().Delete("expired-v1").Return(nil)
+		bdr.EXPECT().Delete("missing-blob").Return(nil)
+
+		purged, err := usecase.NewPurgeTrashUseCase(cfg, blobStore, fr, vr, bdr).Execute(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, purged)
+		assert.NoFileExists(t, cfg.Storage.Path+"/storage/expired")
+		assert.NoFileExists(t, cfg.Storage.Path+"/storage/expired-v1")
+	})
+
+	t.Run("should keep blob when row could not be purged", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(cfg.Storage.Path+"/storage/expired", []byte("content"), 0600))
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		vr.EXPECT().FindAllByFileId("expired").Return([]*entity.FileVersion{}, nil)
+		fr.EXPECT().FindAllExpiredTrash(gomock.Any(), 100).Return([]string{"expired"}, nil)
+		fr.EXPECT().Purge("expired").Return(errors.New("generic error"))
+
+		purged, err := usecase.NewPurgeTrashUseCase(cfg, blobStore, fr, vr, bdr).Execute(ctx)
+
+		assert.Error(t, err)
+		assert.Equal(t, 0, purged)
+		assert.FileExists(t, cfg.Storage.Path+"/storage/expired")
+	})
+
+	t.Run("should keep blob queued when storage could not remove it", func(t *testing.T) {
+		failing := &failingDeleteBlobStore{BlobStore: blobStore}
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+
+		vr.EXPECT().FindAllByFileId("expired").Return([]*entity.FileVersion{}, nil)
+		fr.EXPECT().FindAllExpiredTrash(gomock.Any(), 100).Return([]string{"expired"}, nil)
+		fr.EXPECT().Purge("expired").Return(nil)
+		bdr.EXPECT().Fail("expired", "disk is read-only").Return(nil)
+
+		purged, err := usecase.NewPurgeTrashUseCase(cfg, failing, fr, vr, bdr).Execute(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, purged)
+	})
+}
+
+type failingDeleteBlobStore struct {
+	appStorage.BlobStore
+}
+
+func (f *failingDeleteBlobStore) Delete(id string) error {
+	return errors.New("disk is read-only")
+}
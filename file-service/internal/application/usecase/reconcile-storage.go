@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/compression"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+)
+
+// rows and blobs changed more recently than this may belong to a write still in progress, e.g. a version
+// whose row is committed before its blob is moved. Moving a blob keeps its modification time, so the age of
+// a blob only counts for blobs no row points at, and the staged uploads are told apart by their reservation
+const reconcileGracePeriod = time.Hour
+
+type ReconcileStorageUseCase interface {
+	Execute(ctx context.Context, fix bool) (report *entity.StorageReport, err error)
+}
+
+type reconcileStorageUseCase struct {
+	blobStore                    storage.BlobStore
+	filesRepository              repository.FilesRepository
+	versionsRepository           repository.VersionsRepository
+	blobDeletionsRepository      repository.BlobDeletionsRepository
+	uploadReservationsRepository repository.UploadReservationsRepository
+}
+
+func NewReconcileStorageUseCase(blobStore storage.BlobStore, filesRepository repository.FilesRepository,
+	versionsRepository repository.VersionsRepository, blobDeletionsRepository repository.BlobDeletionsRepository,
+	uploadReservationsRepository repository.UploadReservationsRepository) *reconcileStorageUseCase {
+	return &reconcileStorageUseCase{blobStore: blobStore, filesRepository: filesRepository, versionsRepository: versionsRepository,
+		blobDeletionsRepository: blobDeletionsRepository, uploadReservationsRepository: uploadReservationsRepository}
+}
+
+type blobOwner struct {
+	size      int64
+	checksum  string
+	version   bool
+	changedAt time.Time
+}
+
+// Execute compares the file and version rows with the blobs in storage, reporting the blobs no row
+// points at, the rows whose blob is gone and the rows whose size differs from the one of their blob.
+// Blobs queued for deletion, staged by an upload or modified within the grace period are not reported
+// as orphans, and rows created or updated within the grace period are not reported at all.
+// With fix, orphans are removed, files whose blob is gone are flagged as corrupted, versions whose
+// blob is gone are deleted and mismatching sizes are replaced by the size of the blob
+func (r *reconcileStorageUseCase) Execute(ctx context.Context, fix bool) (report *entity.StorageReport, err error) {
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+
+	owners, err := r.findOwners()
+
+	if err != nil {
+		slog.Error("Could not find files and versions to reconcile", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	deletions, err := r.blobDeletionsRepository.FindAll()
+
+	if err != nil {
+		slog.Error("Could not find blobs queued for deletion", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	reservations, err := r.uploadReservationsRepository.FindAllActive()
+
+	if err != nil {
+		slog.Error("Could not find uploads in progress", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	skipped := make(map[string]bool, len(deletions)+len(reservations))
+
+	for _, deletion := range deletions {
+		skipped[deletion.BlobId] = true
+	}
+
+	for _, reservation := range reservations {
+		skipped[reservation.ReservationId] = true
+	}
+
+	blobs, err := r.blobStore.List()
+
+	if err != nil {
+		slog.Error("Could not list blobs in storage", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	report = &entity.StorageReport{OrphanBlobs: []string{}, MissingBlobs: []string{}, SizeMismatches: []*entity.SizeMismatch{}}
+
+	stored := make(map[string]bool, len(blobs))
+
+	for _, blob := range blobs {
+		stored[blob.Id] = true
+
+		if _, ok := owners[blob.Id]; ok || skipped[blob.Id] || time.Since(blob.ModifiedAt) < reconcileGracePeriod {
+			continue
+		}
+
+		report.OrphanBlobs = append(report.OrphanBlobs, blob.Id)
+	}
+
+	for blobId, owner := range owners {
+		if time.Since(owner.changedAt) < reconcileGracePeriod {
+			continue
+		}
+
+		if !stored[blobId] {
+			report.MissingBlobs = append(report.MissingBlobs, blobId)
+			continue
+		}
+
+		size, err := compression.Size(r.blobStore, blobId)
+
+		// removed since it was listed, the row is usually gone too by now
+		if errors.Is(err, storage.ErrBlobDoesNotExists) {
+			continue
+		}
+
+		if err != nil {
+			slog.Error("Could not find blob size", "traceId", traceId, "blobId", blobId, "error", err)
+			continue
+		}
+
+		if size != owner.size {
+			report.SizeMismatches = append(report.SizeMismatches, &entity.SizeMismatch{BlobId: blobId, Expected: owner.size, Actual: size})
+		}
+	}
+
+	sort.Strings(report.OrphanBlobs)
+	sort.Strings(report.MissingBlobs)
+	sort.Slice(report.SizeMismatches, func(i, j int) bool {
+		return report.SizeMismatches[i].BlobId < report.SizeMismatches[j].BlobId
+	})
+
+	slog.Info("Storage reconciled", "traceId", traceId, "orphans", len(report.OrphanBlobs), "missing", len(report.MissingBlobs),
+		"sizeMismatches", len(report.SizeMismatches))
+
+	if !fix || report.Clean() {
+		return report, nil
+	}
+
+	if err := r.fix(traceId, report, owners); err != nil {
+		return report, err
+	}
+
+	report.Fixed = true
+
+	slog.Info("Storage inconsistencies fixed", "traceId", traceId)
+	return report, nil
+}
+
+func (r *reconcileStorageUseCase) findOwners() (map[string]*blobOwner, error) {
+	files, err := r.filesRepository.FindAllSizes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := r.versionsRepository.FindAllSizes()
+
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]*blobOwner, len(files)+len(versions))
+
+	for _, file := range files {
+		changedAt := file.CreatedAt
+
+		if file.UpdatedAt != nil && file.UpdatedAt.After(changedAt) {
+			changedAt = *file.UpdatedAt
+		}
+
+		owners[file.FileId] = &blobOwner{size: file.Size, checksum: file.Checksum, changedAt: changedAt}
+	}
+
+	for _, version := range versions {
+		owners[version.VersionId] = &blobOwner{size: version.Size, version: true, changedAt: version.CreatedAt}
+	}
+
+	return owners, nil
+}
+
+func (r *reconcileStorageUseCase) fix(traceId string, report *entity.StorageReport, owners map[string]*blobOwner) error {
+	if len(report.OrphanBlobs) > 0 {
+		if err := r.blobDeletionsRepository.Save(report.OrphanBlobs...); err != nil {
+			slog.Error("Could not queue orphan blobs for deletion", "traceId", traceId, "error", err)
+			return err
+		}
+
+		removeBlobs(traceId, r.blobStore, r.blobDeletionsRepository, report.OrphanBlobs...)
+	}
+
+	now := time.Now()
+
+	for _, blobId := range report.MissingBlobs {
+		owner := owners[blobId]
+
+		if owner.version {
+			if err := r.versionsRepository.Delete(blobId); err != nil {
+				slog.Error("Could not delete version without content", "traceId", traceId, "versionId", blobId, "error", err)
+				return err
+			}
+
+			continue
+		}
+
+		if err := r.filesRepository.UpdateCorruptedAt(blobId, owner.checksum, &now); err != nil {
+			slog.Error("Could not flag file without content as corrupted", "traceId", traceId, "fileId", blobId, "error", err)
+			return err
+		}
+	}
+
+	for _, mismatch := range report.SizeMismatches {
+		var err error
+
+		if owners[mismatch.BlobId].version {
+			err = r.versionsRepository.UpdateSize(mismatch.BlobId, mismatch.Actual)
+		} else {
+			err = r.filesRepository.UpdateSize(mismatch.BlobId, mismatch.Actual)
+		}
+
+		if err != nil {
+			slog.Error("Could not update size from blob", "traceId", traceId, "blobId", mismatch.BlobId, "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
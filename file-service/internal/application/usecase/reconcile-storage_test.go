@@ -0,0 +1,135 @@
+package usecase_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestReconcileStorageUseCase(t *testing.T) {
+	ctx := context.WithValue(context.Background(), chiMiddleware.RequestIDKey, "trace12345")
+	old := time.Now().Add(-2 * time.Hour)
+	recent := time.Now().Add(-time.Minute)
+
+	setup := func(t *testing.T) string {
+		root := t.TempDir()
+
+		for id, content := range map[string]string{
+			"intact":    "content",
+			"grown":     "content grown",
+			"version":   "old",
+			"orphan":    "orphan",
+			"queued":    "queued",
+			"uploading": "uploading",
+			"staged":    "staged",
+			"replaced":  "replaced content",
+		} {
+			assert.NoError(t, os.WriteFile(root+"/"+id, []byte(content), 0600))
+
+			if id != "uploading" {
+				assert.NoError(t, os.Chtimes(root+"/"+id, old, old))
+			}
+		}
+
+		return root
+	}
+
+	expectRows := func(fr *mocks.MockFilesRepository, vr *mocks.MockVersionsRepository, bdr *mocks.MockBlobDeletionsRepository,
+		urr *mocks.MockUploadReservationsRepository) {
+		fr.EXPECT().FindAllSizes().Return([]*entity.File{
+			{FileId: "intact", Size: 7, CreatedAt: old},
+			{FileId: "grown", Size: 7, CreatedAt: old},
+			{FileId: "lost", Size: 7, Checksum: "checksum", CreatedAt: old},
+			{FileId: "replaced", Size: 3, CreatedAt: old, UpdatedAt: &recent},
+		}, nil)
+		vr.EXPECT().FindAllSizes().Return([]*entity.FileVersion{
+			{VersionId: "version", Size: 3, CreatedAt: old},
+			{VersionId: "lost-version", Size: 3, CreatedAt: old},
+			{VersionId: "archiving", Size: 16, CreatedAt: recent},
+		}, nil)
+		bdr.EXPECT().FindAll().Return([]*entity.BlobDeletion{{BlobId: "queued"}}, nil)
+		urr.EXPECT().FindAllActive().Return([]*entity.UploadReservation{{ReservationId: "staged"}}, nil)
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		blobStore, err := storage.NewLocalBlobStore(setup(t))
+		assert.NoError(t, err)
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		expectRows(fr, vr, bdr, urr)
+
+		report, err := usecase.NewReconcileStorageUseCase(blobStore, fr, vr, bdr, urr).Execute(ctx, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"orphan"}, report.OrphanBlobs)
+		assert.Equal(t, []string{"lost", "lost-version"}, report.MissingBlobs)
+		assert.Equal(t, []*entity.SizeMismatch{{BlobId: "grown", Expected: 7, Actual: 13}}, report.SizeMismatches)
+		assert.False(t, report.Fixed)
+	})
+
+	t.Run("should fix inconsistencies when requested", func(t *testing.T) {
+		root := setup(t)
+
+		blobStore, err := storage.NewLocalBlobStore(root)
+		assert.NoError(t, err)
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		expectRows(fr, vr, bdr, urr)
+		bdr.EXPECT().Save("orphan").Return(nil)
+		bdr.EXPECT().Delete("orphan").Return(nil)
+		fr.EXPECT().UpdateCorruptedAt("lost", "checksum", gomock.Not(gomock.Nil())).Return(nil)
+		vr.EXPECT().Delete("lost-version").Return(nil)
+		fr.EXPECT().UpdateSize("grown", int64(13)).Return(nil)
+
+		report, err := usecase.NewReconcileStorageUseCase(blobStore, fr, vr, bdr, urr).Execute(ctx, true)
+
+		assert.NoError(t, err)
+		assert.True(t, report.Fixed)
+		assert.NoFileExists(t, root+"/orphan")
+		assert.FileExists(t, root+"/queued")
+		assert.FileExists(t, root+"/uploading")
+		assert.FileExists(t, root+"/staged")
+		assert.FileExists(t, root+"/replaced")
+	})
+
+	t.Run("should report nothing when storage is consistent", func(t *testing.T) {
+		blobStore, err := storage.NewLocalBlobStore(t.TempDir())
+		assert.NoError(t, err)
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		bdr := mocks.NewMockBlobDeletionsRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		fr.EXPECT().FindAllSizes().Return([]*entity.File{}, nil)
+		vr.EXPECT().FindAllSizes().Return([]*entity.FileVersion{}, nil)
+		bdr.EXPECT().FindAll().Return([]*entity.BlobDeletion{}, nil)
+		urr.EXPECT().FindAllActive().Return([]*entity.UploadReservation{}, nil)
+
+		report, err := usecase.NewReconcileStorageUseCase(blobStore, fr, vr, bdr, urr).Execute(ctx, true)
+
+		assert.NoError(t, err)
+		assert.True(t, report.Clean())
+		assert.False(t, report.Fixed)
+	})
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"log/slog"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+)
+
+// blobMove takes the blob at from to to, and is undone by moving it back
+type blobMove struct {
+	from string
+	to   string
+}
+
+// moveBlobs makes moves in order. When one fails, the ones already made are undone in reverse order
+func moveBlobs(traceId string, blobStore storage.BlobStore, moves ...blobMove) error {
+	for i, move := range moves {
+		err := blobStore.Move(move.from, move.to)
+
+		if err == nil {
+			continue
+		}
+
+		for j := i - 1; j >= 0; j-- {
+			if err := blobStore.Move(moves[j].to, moves[j].from); err != nil {
+				slog.Error("Could not move blob back", "traceId", traceId, "from", moves[j].to, "to", moves[j].from, "error", err)
+			}
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// updateContent sets the size and checksum of file, saving and deleting the given versions in the same transaction.
+// Blobs are moved only once it is committed, since storage may have to write to the database as well
+func updateContent(txFilesRepository repository.TxFilesRepository, userId string, file *entity.File,
+	saved []*entity.FileVersion, deleted []*entity.FileVersion) error {
+	tx, err := txFilesRepository.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer txFilesRepository.Rollback(tx)
+
+	for _, version := range saved {
+		if err := txFilesRepository.SaveVersion(tx, version); err != nil {
+			return err
+		}
+	}
+
+	for _, version := range deleted {
+		if err := txFilesRepository.DeleteVersion(tx, version.VersionId); err != nil {
+			return err
+		}
+	}
+
+	if err := txFilesRepository.UpdateContent(tx, userId, file); err != nil {
+		return err
+	}
+
+	return txFilesRepository.Commit(tx)
+}
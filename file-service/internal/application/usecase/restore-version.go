@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+)
+
+type RestoreVersionUseCase interface {
+	Execute(ctx context.Context, fileId string, version int64) (file *entity.File, err error)
+}
+
+type restoreVersionUseCase struct {
+	blobStore          storage.BlobStore
+	filesRepository    repository.FilesRepository
+	txFilesRepository  repository.TxFilesRepository
+	versionsRepository repository.VersionsRepository
+	locksRepository    repository.LocksRepository
+}
+
+func NewRestoreVersionUseCase(blobStore storage.BlobStore, filesRepository repository.FilesRepository,
+	txFilesRepository repository.TxFilesRepository, versionsRepository repository.VersionsRepository,
+	locksRepository repository.LocksRepository) *restoreVersionUseCase {
+	return &restoreVersionUseCase{blobStore: blobStore, filesRepository: filesRepository, txFilesRepository: txFilesRepository,
+		versionsRepository: versionsRepository, locksRepository: locksRepository}
+}
+
+// Execute swaps the current content with the given version, which keeps the current content
+// as the newest version. The number of versions and the owner usage stay the same.
+// The rows are updated in a single transaction before the blobs are swapped, and they are put
+// back as they were when swapping fails
+func (r *restoreVersionUseCase) Execute(ctx context.Context, fileId string, version int64) (file *entity.File, err error) {
+	user := ctx.Value(m.UserClaimsCtxKey).(jwt.Token)
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+	groups := m.UserGroups(user)
+
+	file, err = r.filesRepository.FindById(user.Subject(), groups, fileId)
+
+	if err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if !file.CanEdit(user.Subject(), groups) {
+		slog.Info("Requester cannot edit file", "traceId", traceId, "fileId", fileId)
+		return nil, facade.ErrNotFileEditor
+	}
+
+	lock, err := r.locksRepository.FindByFileId(fileId)
+
+	if err != nil {
+		slog.Error("Could not find file lock", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if lock.Blocks(user.Subject()) {
+		slog.Info("Refusing to restore version of file locked by another user", "traceId", traceId, "fileId", fileId)
+		return nil, repository.ErrFileLocked
+	}
+
+	target, err := r.versionsRepository.FindByVersion(fileId, version)
+
+	if err != nil {
+		slog.Info("Could not find file version", "traceId", traceId, "fileId", fileId, "version", version, "error", err)
+		return nil, err
+	}
+
+	archived := entity.NewFileVersion(file.FileId, file.Size, user.Subject())
+	archived.Checksum = file.Checksum
+
+	previous := &entity.File{FileId: file.FileId, Size: file.Size, Checksum: file.Checksum}
+
+	file.Size = target.Size
+	file.Checksum = target.Checksum
+
+	saved := []*entity.FileVersion{archived}
+	deleted := []*entity.FileVersion{target}
+
+	if err := updateContent(r.txFilesRepository, user.Subject(), file, saved, deleted); err != nil {
+		slog.Error("Could not update file content", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	err = moveBlobs(traceId, r.blobStore, blobMove{from: file.FileId, to: archived.VersionId}, blobMove{from: target.VersionId, to: file.FileId})
+
+	if err != nil {
+		slog.Error("Could not restore version content in storage", "traceId", traceId, "fileId", fileId, "version", version, "error", err)
+
+		// the restored version keeps its number, so it is put back where it was
+		if err := updateContent(r.txFilesRepository, user.Subject(), previous, deleted, saved); err != nil {
+			slog.Error("Could not revert file content", "traceId", traceId, "fileId", fileId, "error", err)
+		}
+
+		return nil, err
+	}
+
+	slog.Info("File version restored successfully", "traceId", traceId, "fileId", fileId, "version", version)
+	return file, nil
+}
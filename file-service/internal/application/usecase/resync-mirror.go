@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+)
+
+type ResyncMirrorUseCase interface {
+	Execute(ctx context.Context) (repaired int, err error)
+}
+
+type resyncMirrorUseCase struct {
+	mirror storage.Mirror
+}
+
+func NewResyncMirrorUseCase(mirror storage.Mirror) *resyncMirrorUseCase {
+	return &resyncMirrorUseCase{mirror: mirror}
+}
+
+// Execute copies back the blobs a mirror disk is missing, e.g. after the disk was replaced
+func (r *resyncMirrorUseCase) Execute(ctx context.Context) (repaired int, err error) {
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+
+	repaired, err = r.mirror.Resync()
+
+	if err != nil {
+		slog.Error("Could not resync storage mirror", "traceId", traceId, "repaired", repaired, "error", err)
+		return repaired, err
+	}
+
+	slog.Info("Storage mirror resynced successfully", "traceId", traceId, "repaired", repaired)
+	return repaired, nil
+}
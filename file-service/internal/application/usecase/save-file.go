@@ -16,18 +16,37 @@ var (
 
 type CreateFileUseCase interface {
 	Execute(file *entity.File) (err error)
+	CheckSpace(file *entity.File) (err error)
 }
 
 type createFileUseCase struct {
-	config          *config.Config
-	filesRepository repository.FilesRepository
+	config                       *config.Config
+	filesRepository              repository.FilesRepository
+	uploadReservationsRepository repository.UploadReservationsRepository
 }
 
-func NewCreateFileUseCase(config *config.Config, fr repository.FilesRepository) *createFileUseCase {
-	return &createFileUseCase{filesRepository: fr, config: config}
+func NewCreateFileUseCase(config *config.Config, fr repository.FilesRepository, urr repository.UploadReservationsRepository) *createFileUseCase {
+	return &createFileUseCase{filesRepository: fr, uploadReservationsRepository: urr, config: config}
 }
 
+// Execute creates file when it fits in the space of its owner, where the space reserved
+// by uploads still in progress is taken as used
 func (c *createFileUseCase) Execute(file *entity.File) (err error) {
+	if err = c.CheckSpace(file); err != nil {
+		return
+	}
+
+	if err = c.filesRepository.Save(file); err != nil {
+		slog.Error("Could not create file:", "error", err.Error())
+		return
+	}
+
+	return
+}
+
+// CheckSpace returns ErrNotAvailableSpace when file does not fit in the space of its owner,
+// so callers can refuse it before writing its content
+func (c *createFileUseCase) CheckSpace(file *entity.File) (err error) {
 	usage, err := c.filesRepository.FindUsageByUserId(file.Owner)
 
 	if err != nil {
@@ -35,17 +54,19 @@ func (c *createFileUseCase) Execute(file *entity.File) (err error) {
 		return
 	}
 
-	available := int64(parser.ParseUsage(c.config.Storage.Limit)) - usage
+	reserved, err := c.uploadReservationsRepository.FindReservedByUserId(file.Owner)
+
+	if err != nil {
+		slog.Error("Could not find user reserved space:", "error", err.Error())
+		return
+	}
+
+	available := int64(parser.ParseUsage(c.config.Storage.Limit)) - usage - reserved
 
 	if file.Size > available {
 		slog.Info("Could not create file because available storage for user is insufficient:", "userId", file.Owner, "available", available)
 		return ErrNotAvailableSpace
 	}
 
-	if err = c.filesRepository.Save(file); err != nil {
-		slog.Error("Could not create file:", "error", err.Error())
-		return
-	}
-
 	return
 }
@@ -10,20 +10,24 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
-var mockConfig = &config.Config{Storage: struct {
-	Path  string
-	Limit string
-}{Path: "./", Limit: "1000M"}}
+var mockConfig = func() *config.Config {
+	cfg := &config.Config{}
+	cfg.Storage.Path = "./"
+	cfg.Storage.Limit = "1000M"
+	return cfg
+}()
 
 func TestCreateFileUseCase(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 
 	t.Run("happy path", func(t *testing.T) {
 		mockObj := mocks.NewMockFilesRepository(mockCtrl)
+		reservations := mocks.NewMockUploadReservationsRepository(mockCtrl)
 		mockObj.EXPECT().Save(gomock.Any()).Return(nil)
 		mockObj.EXPECT().FindUsageByUserId("user1").Return(int64(100), nil)
+		reservations.EXPECT().FindReservedByUserId("user1").Return(int64(0), nil)
 
-		useCase := usecase.NewCreateFileUseCase(mockConfig, mockObj)
+		useCase := usecase.NewCreateFileUseCase(mockConfig, mockObj, reservations)
 
 		file := &entity.File{
 			Owner: "user1",
@@ -39,9 +43,11 @@ func TestCreateFileUseCase(t *testing.T) {
 
 	t.Run("upload with file size greather than provided by config", func(t *testing.T) {
 		mockObj := mocks.NewMockFilesRepository(mockCtrl)
+		reservations := mocks.NewMockUploadReservationsRepository(mockCtrl)
 		mockObj.EXPECT().FindUsageByUserId("user2").Return(int64(100), nil)
+		reservations.EXPECT().FindReservedByUserId("user2").Return(int64(0), nil)
 
-		useCase := usecase.NewCreateFileUseCase(mockConfig, mockObj)
+		useCase := usecase.NewCreateFileUseCase(mockConfig, mockObj, reservations)
 
 		file := &entity.File{
 			Owner: "user2",
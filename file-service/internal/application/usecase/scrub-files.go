@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/compression"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+)
+
+const scrubBatchSize = 100
+
+type ScrubFilesUseCase interface {
+	Execute(ctx context.Context) (corrupted int, err error)
+}
+
+type scrubFilesUseCase struct {
+	blobStore       storage.BlobStore
+	filesRepository repository.FilesRepository
+}
+
+func NewScrubFilesUseCase(blobStore storage.BlobStore, filesRepository repository.FilesRepository) *scrubFilesUseCase {
+	return &scrubFilesUseCase{blobStore: blobStore, filesRepository: filesRepository}
+}
+
+// Execute re-hashes the content of every file that has a checksum. Files whose content is missing
+// or no longer matches the checksum are flagged as corrupted, and the flag is cleared from the ones
+// that match again, e.g. after a mirror resync repaired them
+func (s *scrubFilesUseCase) Execute(ctx context.Context) (corrupted int, err error) {
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+
+	scrubbed := 0
+	afterFileId := ""
+
+	for {
+		files, err := s.filesRepository.FindAllChecksummed(afterFileId, scrubBatchSize)
+
+		if err != nil {
+			slog.Error("Could not find files to scrub", "traceId", traceId, "error", err)
+			return corrupted, err
+		}
+
+		for _, file := range files {
+			if err := ctx.Err(); err != nil {
+				return corrupted, err
+			}
+
+			afterFileId = file.FileId
+
+			intact, err := s.verify(file)
+
+			if err != nil {
+				slog.Error("Could not read file content to scrub", "traceId", traceId, "fileId", file.FileId, "error", err)
+				continue
+			}
+
+			scrubbed++
+
+			if !intact {
+				corrupted++
+			}
+
+			if err := s.flag(traceId, file, intact); err != nil {
+				slog.Error("Could not update file integrity", "traceId", traceId, "fileId", file.FileId, "error", err)
+				return corrupted, err
+			}
+		}
+
+		if len(files) < scrubBatchSize {
+			break
+		}
+	}
+
+	slog.Info("Files scrubbed successfully", "traceId", traceId, "scrubbed", scrubbed, "corrupted", corrupted)
+	return corrupted, nil
+}
+
+// verify tells whether the content of the file still hashes to its checksum. Reading errors
+// other than the content being gone or tampered with are returned, since they may be transient
+func (s *scrubFilesUseCase) verify(file *entity.File) (intact bool, err error) {
+	content, err := compression.Open(s.blobStore, file.FileId)
+
+	if errors.Is(err, storage.ErrBlobDoesNotExists) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	defer content.Close()
+
+	hash := sha256.New()
+
+	_, err = io.Copy(hash, content)
+
+	if errors.Is(err, storage.ErrBlobCorrupted) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)) == file.Checksum, nil
+}
+
+func (s *scrubFilesUseCase) flag(traceId string, file *entity.File, intact bool) error {
+	if intact && file.CorruptedAt != nil {
+		slog.Info("File content is intact again", "traceId", traceId, "fileId", file.FileId)
+		return s.filesRepository.UpdateCorruptedAt(file.FileId, file.Checksum, nil)
+	}
+
+	if !intact && file.CorruptedAt == nil {
+		slog.Warn("File content does not match its checksum", "traceId", traceId, "fileId", file.FileId)
+		now := time.Now()
+		return s.filesRepository.UpdateCorruptedAt(file.FileId, file.Checksum, &now)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestScrubFilesUseCase(t *testing.T) {
+	// sha256 of "content"
+	const checksum = "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"
+
+	root := t.TempDir()
+
+	blobStore, err := storage.NewLocalBlobStore(root)
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.Background(), chiMiddleware.RequestIDKey, "trace12345")
+
+	t.Run("happy path", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(root+"/intact", []byte("content"), 0600))
+		assert.NoError(t, os.WriteFile(root+"/rotten", []byte("c0ntent"), 0600))
+		assert.NoError(t, os.WriteFile(root+"/repaired", []byte("content"), 0600))
+
+		corruptedAt := time.Now().Add(-time.Hour)
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+
+		fr.EXPECT().FindAllChecksummed("", 100).Return([]*entity.File{
+			{FileId: "intact", Checksum: checksum},
+			{FileId: "missing", Checksum: checksum},
+			{FileId: "repaired", Checksum: checksum, CorruptedAt: &corruptedAt},
+			{FileId: "rotten", Checksum: checksum},
+		}, nil)
+		fr.EXPECT().UpdateCorruptedAt("missing", checksum, gomock.Not(gomock.Nil())).Return(nil)
+		fr.EXPECT().UpdateCorruptedAt("repaired", checksum, nil).Return(nil)
+		fr.EXPECT().UpdateCorruptedAt("rotten", checksum, gomock.Not(gomock.Nil())).Return(nil)
+
+		corrupted, err := usecase.NewScrubFilesUseCase(blobStore, fr).Execute(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, corrupted)
+	})
+
+	t.Run("should not flag files already flagged", func(t *testing.T) {
+		corruptedAt := time.Now().Add(-time.Hour)
+
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+
+		fr.EXPECT().FindAllChecksummed("", 100).Return([]*entity.File{
+			{FileId: "missing", Checksum: checksum, CorruptedAt: &corruptedAt},
+		}, nil)
+
+		corrupted, err := usecase.NewScrubFilesUseCase(blobStore, fr).Execute(ctx)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, corrupted)
+	})
+
+	t.Run("should return error when files could not be listed", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fr := mocks.NewMockFilesRepository(mockCtrl)
+
+		fr.EXPECT().FindAllChecksummed("", 100).Return(nil, errors.New("generic error"))
+
+		_, err := usecase.NewScrubFilesUseCase(blobStore, fr).Execute(ctx)
+
+		assert.Error(t, err)
+	})
+}
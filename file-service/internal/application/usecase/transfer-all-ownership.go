@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/parser"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+)
+
+type TransferAllOwnershipUseCase interface {
+	Execute(ctx context.Context, ownerId string, recipientId string, keepAsEditor bool) (transferred int64, err error)
+}
+
+type transferAllOwnershipUseCase struct {
+	config *config.Config
+	repo   repository.TxFilesRepository
+}
+
+func NewTransferAllOwnershipUseCase(config *config.Config, repo repository.TxFilesRepository) *transferAllOwnershipUseCase {
+	return &transferAllOwnershipUseCase{config: config, repo: repo}
+}
+
+func (c *transferAllOwnershipUseCase) Execute(ctx context.Context, ownerId string, recipientId string, keepAsEditor bool) (transferred int64, err error) {
+	user := ctx.Value(m.UserClaimsCtxKey).(jwt.Token)
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+
+	if ownerId == recipientId {
+		return 0, ErrAlreadyOwner
+	}
+
+	tx, err := c.repo.Begin()
+
+	if err != nil {
+		slog.Error("Could not initialize transaction", "traceId", traceId, "error", err)
+		return 0, err
+	}
+
+	defer c.repo.Rollback(tx)
+
+	ownerUsage, err := c.repo.FindUsageByUserId(tx, ownerId)
+
+	if err != nil {
+		slog.Error("Could not find owner usage", "traceId", traceId, "error", err)
+		return 0, err
+	}
+
+	recipientUsage, err := c.repo.FindUsageByUserId(tx, recipientId)
+
+	if err != nil {
+		slog.Error("Could not find recipient usage", "traceId", traceId, "error", err)
+		return 0, err
+	}
+
+	available := int64(parser.ParseUsage(c.config.Storage.Limit)) - recipientUsage
+
+	if ownerUsage > available {
+		slog.Info("Could not transfer files because available storage for recipient is insufficient", "traceId", traceId, "recipientId", recipientId, "available", available)
+		return 0, ErrRecipientNotAvailableSpace
+	}
+
+	if err = c.repo.DeletePermissionsByOwnerId(tx, ownerId, recipientId); err != nil {
+		slog.Error("Could not remove recipient permissions", "traceId", traceId, "error", err)
+		return 0, err
+	}
+
+	if keepAsEditor {
+		if err = c.repo.SaveOwnerEditorPermissions(tx, ownerId); err != nil {
+			slog.Error("Could not keep previous owner as editor", "traceId", traceId, "error", err)
+			return 0, err
+		}
+	}
+
+	transferred, err = c.repo.UpdateOwnerByOwnerId(tx, user.Subject(), ownerId, recipientId)
+
+	if err != nil {
+		slog.Error("Could not update files owner", "traceId", traceId, "error", err)
+		return 0, err
+	}
+
+	if err = c.repo.Commit(tx); err != nil {
+		slog.Error("Could not commit transfer ownership transaction", "traceId", traceId, "error", err)
+		return 0, err
+	}
+
+	slog.Info("Files ownership transferred successfully", "traceId", traceId, "ownerId", ownerId, "recipientId", recipientId, "transferred", transferred)
+	return transferred, nil
+}
@@ -0,0 +1,87 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransferAllOwnershipUseCase_Execute(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	token := jwt.New()
+	err := token.Set("sub", "adminId")
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.WithValue(context.Background(),
+		chiMiddleware.RequestIDKey, "trace12345"),
+		middleware.UserClaimsCtxKey, token)
+
+	t.Run("happy path", func(t *testing.T) {
+		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+
+		mockObj.EXPECT().Begin().Return(nil, nil)
+		mockObj.EXPECT().FindUsageByUserId(gomock.Any(), "ownerId").Return(int64(2048), nil)
+		mockObj.EXPECT().FindUsageByUserId(gomock.Any(), "recipientId").Return(int64(0), nil)
+		mockObj.EXPECT().DeletePermissionsByOwnerId(gomock.Any(), "ownerId", "recipientId").Return(nil)
+		mockObj.EXPECT().UpdateOwnerByOwnerId(gomock.Any(), "adminId", "ownerId", "recipientId").Return(int64(3), nil)
+		mockObj.EXPECT().Commit(nil).Return(nil)
+		mockObj.EXPECT().Rollback(nil).Return(nil)
+
+		useCase := usecase.NewTransferAllOwnershipUseCase(mockConfig, mockObj)
+
+		transferred, err := useCase.Execute(ctx, "ownerId", "recipientId", false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), transferred)
+	})
+
+	t.Run("should keep previous owner as editor", func(t *testing.T) {
+		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+
+		mockObj.EXPECT().Begin().Return(nil, nil)
+		mockObj.EXPECT().FindUsageByUserId(gomock.Any(), "ownerId").Return(int64(2048), nil)
+		mockObj.EXPECT().FindUsageByUserId(gomock.Any(), "recipientId").Return(int64(0), nil)
+		mockObj.EXPECT().DeletePermissionsByOwnerId(gomock.Any(), "ownerId", "recipientId").Return(nil)
+		mockObj.EXPECT().SaveOwnerEditorPermissions(gomock.Any(), "ownerId").Return(nil)
+		mockObj.EXPECT().UpdateOwnerByOwnerId(gomock.Any(), "adminId", "ownerId", "recipientId").Return(int64(3), nil)
+		mockObj.EXPECT().Commit(nil).Return(nil)
+		mockObj.EXPECT().Rollback(nil).Return(nil)
+
+		useCase := usecase.NewTransferAllOwnershipUseCase(mockConfig, mockObj)
+
+		_, err := useCase.Execute(ctx, "ownerId", "recipientId", true)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not transfer when recipient has no space available", func(t *testing.T) {
+		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+
+		mockObj.EXPECT().Begin().Return(nil, nil)
+		mockObj.EXPECT().FindUsageByUserId(gomock.Any(), "ownerId").Return(int64(2048), nil)
+		mockObj.EXPECT().FindUsageByUserId(gomock.Any(), "recipientId").Return(int64(1000*1024*1024), nil)
+		mockObj.EXPECT().Rollback(nil).Return(nil)
+
+		useCase := usecase.NewTransferAllOwnershipUseCase(mockConfig, mockObj)
+
+		_, err := useCase.Execute(ctx, "ownerId", "recipientId", false)
+
+		assert.Equal(t, usecase.ErrRecipientNotAvailableSpace, err)
+	})
+
+	t.Run("should not transfer to the same user", func(t *testing.T) {
+		useCase := usecase.NewTransferAllOwnershipUseCase(mockConfig, mocks.NewMockTxFilesRepository(mockCtrl))
+
+		_, err := useCase.Execute(ctx, "ownerId", "ownerId", false)
+
+		assert.Equal(t, usecase.ErrAlreadyOwner, err)
+	})
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/parser"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+)
+
+var (
+	ErrNotFileOwner               = errors.New("only the owner of the file can transfer its ownership")
+	ErrAlreadyOwner               = errors.New("recipient already owns the file")
+	ErrRecipientNotAvailableSpace = errors.New("files are greater than the space available for the recipient user")
+)
+
+type TransferOwnershipUseCase interface {
+	Execute(ctx context.Context, fileId string, recipientId string, keepAsEditor bool) (fileMetadata *entity.File, err error)
+}
+
+type transferOwnershipUseCase struct {
+	config *config.Config
+	repo   repository.TxFilesRepository
+}
+
+func NewTransferOwnershipUseCase(config *config.Config, repo repository.TxFilesRepository) *transferOwnershipUseCase {
+	return &transferOwnershipUseCase{config: config, repo: repo}
+}
+
+func (c *transferOwnershipUseCase) Execute(ctx context.Context, fileId string, recipientId string, keepAsEditor bool) (fileMetadata *entity.File, err error) {
+	user := ctx.Value(m.UserClaimsCtxKey).(jwt.Token)
+	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+
+	tx, err := c.repo.Begin()
+
+	if err != nil {
+		slog.Error("Could not initialize transaction", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	defer c.repo.Rollback(tx)
+
+	file, err := c.repo.FindById(tx, user.Subject(), m.UserGroups(user), fileId)
+
+	if err != nil {
+		slog.Error("Could not find file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if file.Owner != user.Subject() {
+		slog.Info("Requester is not the owner of the file", "traceId", traceId, "fileId", fileId)
+		return nil, ErrNotFileOwner
+	}
+
+	if recipientId == file.Owner {
+		return nil, ErrAlreadyOwner
+	}
+
+	usage, err := c.repo.FindUsageByUserId(tx, recipientId)
+
+	if err != nil {
+		slog.Error("Could not find recipient usage", "traceId", traceId, "error", err)
+		return nil, err
+	}
+
+	available := int64(parser.ParseUsage(c.config.Storage.Limit)) - usage
+
+	if file.Size > available {
+		slog.Info("Could not transfer file because available storage for recipient is insufficient", "traceId", traceId, "recipientId", recipientId, "available", available)
+		return nil, ErrRecipientNotAvailableSpace
+	}
+
+	// the recipient may already have access to the file, which an owner must not have
+	if err = c.repo.DeletePermission(tx, fileId, recipientId); err != nil {
+		slog.Error("Could not remove recipient permission", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if err = c.repo.UpdateOwner(tx, user.Subject(), fileId, recipientId); err != nil {
+		slog.Error("Could not update file owner", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if keepAsEditor && !file.Secret {
+		if err = c.repo.SavePermission(tx, fileId, user.Subject(), entity.PermissionEditor); err != nil {
+			slog.Error("Could not keep previous owner as editor", "traceId", traceId, "fileId", fileId, "error", err)
+			return nil, err
+		}
+	}
+
+	fileMetadata, err = c.repo.FindById(tx, recipientId, nil, fileId)
+
+	if err != nil {
+		slog.Error("Could not find transferred file", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	if err = c.repo.Commit(tx); err != nil {
+		slog.Error("Could not commit transfer ownership transaction", "traceId", traceId, "fileId", fileId, "error", err)
+		return nil, err
+	}
+
+	slog.Info("File ownership transferred successfully", "traceId", traceId, "fileId", fileId, "recipientId", recipientId)
+	return fileMetadata, nil
+}
@@ -0,0 +1,94 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTransferOwnershipUseCase_Execute(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	ctx := context.WithValue(context.WithValue(context.Background(),
+		chiMiddleware.RequestIDKey, "trace12345"),
+		middleware.UserClaimsCtxKey, token)
+
+	t.Run("happy path keeping previous owner as editor", func(t *testing.T) {
+		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+
+		mockObj.EXPECT().Begin().Return(nil, nil)
+		mockObj.EXPECT().FindById(gomock.Any(), "userId", []string{}, "fileId").Return(&entity.File{
+			FileId: "fileId",
+			Owner:  "userId",
+			Size:   1024,
+		}, nil)
+		mockObj.EXPECT().FindUsageByUserId(gomock.Any(), "recipientId").Return(int64(0), nil)
+		mockObj.EXPECT().DeletePermission(gomock.Any(), "fileId", "recipientId").Return(nil)
+		mockObj.EXPECT().UpdateOwner(gomock.Any(), "userId", "fileId", "recipientId").Return(nil)
+		mockObj.EXPECT().SavePermission(gomock.Any(), "fileId", "userId", entity.PermissionEditor).Return(nil)
+		mockObj.EXPECT().FindById(gomock.Any(), "recipientId", nil, "fileId").Return(&entity.File{
+			FileId:  "fileId",
+			Owner:   "recipientId",
+			Editors: []string{"userId"},
+		}, nil)
+		mockObj.EXPECT().Commit(nil).Return(nil)
+		mockObj.EXPECT().Rollback(nil).Return(nil)
+
+		useCase := usecase.NewTransferOwnershipUseCase(mockConfig, mockObj)
+
+		fileMetadata, err := useCase.Execute(ctx, "fileId", "recipientId", true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "recipientId", fileMetadata.Owner)
+	})
+
+	t.Run("should not transfer when requester is not the owner", func(t *testing.T) {
+		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+
+		mockObj.EXPECT().Begin().Return(nil, nil)
+		mockObj.EXPECT().FindById(gomock.Any(), "userId", []string{}, "fileId").Return(&entity.File{
+			FileId: "fileId",
+			Owner:  "otherUser",
+		}, nil)
+		mockObj.EXPECT().Rollback(nil).Return(nil)
+
+		useCase := usecase.NewTransferOwnershipUseCase(mockConfig, mockObj)
+
+		fileMetadata, err := useCase.Execute(ctx, "fileId", "recipientId", false)
+
+		assert.Equal(t, usecase.ErrNotFileOwner, err)
+		assert.Nil(t, fileMetadata)
+	})
+
+	t.Run("should not transfer when recipient has no space available", func(t *testing.T) {
+		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+
+		mockObj.EXPECT().Begin().Return(nil, nil)
+		mockObj.EXPECT().FindById(gomock.Any(), "userId", []string{}, "fileId").Return(&entity.File{
+			FileId: "fileId",
+			Owner:  "userId",
+			Size:   1024,
+		}, nil)
+		mockObj.EXPECT().FindUsageByUserId(gomock.Any(), "recipientId").Return(int64(1000*1024*1024), nil)
+		mockObj.EXPECT().Rollback(nil).Return(nil)
+
+		useCase := usecase.NewTransferOwnershipUseCase(mockConfig, mockObj)
+
+		fileMetadata, err := useCase.Execute(ctx, "fileId", "recipientId", false)
+
+		assert.Equal(t, usecase.ErrRecipientNotAvailableSpace, err)
+		assert.Nil(t, fileMetadata)
+	})
+}
@@ -16,16 +16,30 @@ type UpdateFileUseCase interface {
 }
 
 type updateFileUseCase struct {
-	repo repository.TxFilesRepository
+	repo            repository.TxFilesRepository
+	locksRepository repository.LocksRepository
 }
 
-func NewUpdateFileUseCase(repo repository.TxFilesRepository) *updateFileUseCase {
-	return &updateFileUseCase{repo: repo}
+func NewUpdateFileUseCase(repo repository.TxFilesRepository, locksRepository repository.LocksRepository) *updateFileUseCase {
+	return &updateFileUseCase{repo: repo, locksRepository: locksRepository}
 }
 
 func (c *updateFileUseCase) Execute(ctx context.Context, file *entity.File) (fileMetadata *entity.File, err error) {
 	user := ctx.Value(m.UserClaimsCtxKey).(jwt.Token)
 	traceId := ctx.Value(chiMiddleware.RequestIDKey).(string)
+	groups := m.UserGroups(user)
+
+	lock, err := c.locksRepository.FindByFileId(file.FileId)
+
+	if err != nil {
+		slog.Error("Could not find file lock", "traceId", traceId, "fileId", file.FileId, "error", err)
+		return nil, err
+	}
+
+	if lock.Blocks(user.Subject()) {
+		slog.Info("Refusing to update file locked by another user", "traceId", traceId, "fileId", file.FileId)
+		return nil, repository.ErrFileLocked
+	}
 
 	tx, err := c.repo.Begin()
 
@@ -34,7 +48,7 @@ func (c *updateFileUseCase) Execute(ctx context.Context, file *entity.File) (fil
 		return nil, err
 	}
 
-	fileMetadata, err = c.repo.FindById(tx, user.Subject(), file.FileId)
+	fileMetadata, err = c.repo.FindById(tx, user.Subject(), groups, file.FileId)
 
 	if err != nil {
 		slog.Error("Could not find file", "traceId", traceId, "fileId", file.FileId, "error", err)
@@ -50,9 +64,14 @@ func (c *updateFileUseCase) Execute(ctx context.Context, file *entity.File) (fil
 			slog.Error("Could not remove permissions to set file secret", "traceId", traceId, "fileId", fileMetadata.FileId, "error", err)
 			return nil, err
 		}
+
+		if err := c.repo.DeleteFileLinksByFileId(tx, fileMetadata.FileId); err != nil {
+			slog.Error("Could not remove links to set file secret", "traceId", traceId, "fileId", fileMetadata.FileId, "error", err)
+			return nil, err
+		}
 	}
 
-	if err = c.repo.Update(tx, user.Subject(), fileMetadata); err != nil {
+	if err = c.repo.Update(tx, user.Subject(), groups, fileMetadata); err != nil {
 		slog.Error("Could not update file", "traceId", traceId, "fileId", file.FileId, "error", err)
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
@@ -33,16 +34,18 @@ func TestUpdateFileUseCase_Execute(t *testing.T) {
 
 	t.Run("ValidFileUpdate", func(t *testing.T) {
 		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+		locksMock := mocks.NewMockLocksRepository(mockCtrl)
+		locksMock.EXPECT().FindByFileId(gomock.Any()).Return(nil, nil)
 
-		mockObj.EXPECT().FindById(gomock.Any(), "userId", "validFile").Return(&entity.File{
+		mockObj.EXPECT().FindById(gomock.Any(), "userId", []string{}, "validFile").Return(&entity.File{
 			FileId:   "validFile",
 			Filename: "updated.txt",
 		}, nil)
-		mockObj.EXPECT().Update(gomock.Any(), "userId", gomock.Any()).Return(nil)
+		mockObj.EXPECT().Update(gomock.Any(), "userId", []string{}, gomock.Any()).Return(nil)
 		mockObj.EXPECT().Begin().Return(nil, nil)
 		mockObj.EXPECT().Commit(nil).Return(nil)
 
-		useCase := usecase.NewUpdateFileUseCase(mockObj)
+		useCase := usecase.NewUpdateFileUseCase(mockObj, locksMock)
 
 		file := &entity.File{
 			FileId:   "validFile",
@@ -58,11 +61,13 @@ func TestUpdateFileUseCase_Execute(t *testing.T) {
 
 	t.Run("FileNotFound", func(t *testing.T) {
 		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+		locksMock := mocks.NewMockLocksRepository(mockCtrl)
+		locksMock.EXPECT().FindByFileId(gomock.Any()).Return(nil, nil)
 
 		mockObj.EXPECT().Begin().Return(nil, nil)
-		mockObj.EXPECT().FindById(gomock.Any(), "userId", "nonexistentFile").Return(nil, repository.ErrFileDoesNotExists)
+		mockObj.EXPECT().FindById(gomock.Any(), "userId", []string{}, "nonexistentFile").Return(nil, repository.ErrFileDoesNotExists)
 
-		useCase := usecase.NewUpdateFileUseCase(mockObj)
+		useCase := usecase.NewUpdateFileUseCase(mockObj, locksMock)
 
 		file := &entity.File{
 			FileId:   "nonexistentFile",
@@ -77,15 +82,17 @@ func TestUpdateFileUseCase_Execute(t *testing.T) {
 
 	t.Run("FailedToUpdateFile", func(t *testing.T) {
 		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+		locksMock := mocks.NewMockLocksRepository(mockCtrl)
+		locksMock.EXPECT().FindByFileId(gomock.Any()).Return(nil, nil)
 
-		mockObj.EXPECT().FindById(gomock.Any(), "userId", "failedFile").Return(&entity.File{
+		mockObj.EXPECT().FindById(gomock.Any(), "userId", []string{}, "failedFile").Return(&entity.File{
 			FileId:   "failedFile",
 			Filename: "updated.txt",
 		}, nil)
 		mockObj.EXPECT().Begin().Return(nil, nil)
-		mockObj.EXPECT().Update(gomock.Any(), "userId", gomock.Any()).Return(errors.New("generic error"))
+		mockObj.EXPECT().Update(gomock.Any(), "userId", []string{}, gomock.Any()).Return(errors.New("generic error"))
 
-		useCase := usecase.NewUpdateFileUseCase(mockObj)
+		useCase := usecase.NewUpdateFileUseCase(mockObj, locksMock)
 
 		file := &entity.File{
 			FileId:   "failedFile",
@@ -97,4 +104,42 @@ func TestUpdateFileUseCase_Execute(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, fileMetadata)
 	})
+
+	t.Run("FileLockedByAnotherUser", func(t *testing.T) {
+		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+		locksMock := mocks.NewMockLocksRepository(mockCtrl)
+
+		locksMock.EXPECT().FindByFileId("lockedFile").Return(entity.NewLock("lockedFile", "otherUser", time.Hour), nil)
+
+		useCase := usecase.NewUpdateFileUseCase(mockObj, locksMock)
+
+		fileMetadata, err := useCase.Execute(ctx, &entity.File{FileId: "lockedFile", Filename: "updated.txt"})
+
+		assert.ErrorIs(t, err, repository.ErrFileLocked)
+		assert.Nil(t, fileMetadata)
+	})
+
+	t.Run("should stop sharing file that becomes secret", func(t *testing.T) {
+		mockObj := mocks.NewMockTxFilesRepository(mockCtrl)
+		locksMock := mocks.NewMockLocksRepository(mockCtrl)
+		locksMock.EXPECT().FindByFileId(gomock.Any()).Return(nil, nil)
+
+		mockObj.EXPECT().Begin().Return(nil, nil)
+		mockObj.EXPECT().FindById(gomock.Any(), "userId", []string{}, "sharedFile").Return(&entity.File{
+			FileId:   "sharedFile",
+			Filename: "shared.txt",
+		}, nil)
+		// public links would otherwise keep serving the file after it is secret
+		mockObj.EXPECT().DeleteFilePermissionByFileId(gomock.Any(), "sharedFile").Return(nil)
+		mockObj.EXPECT().DeleteFileLinksByFileId(gomock.Any(), "sharedFile").Return(nil)
+		mockObj.EXPECT().Update(gomock.Any(), "userId", []string{}, gomock.Any()).Return(nil)
+		mockObj.EXPECT().Commit(nil).Return(nil)
+
+		useCase := usecase.NewUpdateFileUseCase(mockObj, locksMock)
+
+		fileMetadata, err := useCase.Execute(ctx, &entity.File{FileId: "sharedFile", Filename: "shared.txt", Secret: true})
+
+		assert.NoError(t, err)
+		assert.True(t, fileMetadata.Secret)
+	})
 }
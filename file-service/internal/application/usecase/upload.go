@@ -2,45 +2,250 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"io"
 	"log/slog"
-	"os"
+	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/compression"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/parser"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
 )
 
+const compressionZstd = "zstd"
+
+// long enough for a slow upload to finish, reservations of uploads that never do are dropped after it
+const uploadReservationDuration = 24 * time.Hour
+
+var (
+	ErrUploadSizeMismatch = errors.New("uploaded content size differs from the declared size")
+)
+
 type UploadFileUseCase interface {
-	Execute(ctx context.Context, file *entity.File, src io.Reader) (err error)
+	Execute(ctx context.Context, file *entity.File, src io.Reader, replaced *entity.File) (uploaded *entity.File, err error)
 }
 
 type uploadFileUseCase struct {
-	config *config.Config
+	config                       *config.Config
+	blobStore                    storage.BlobStore
+	txFilesRepository            repository.TxFilesRepository
+	versionsRepository           repository.VersionsRepository
+	uploadReservationsRepository repository.UploadReservationsRepository
+	createVersionUseCase         CreateVersionUseCase
 }
 
-func NewUploadFileUseCase(config *config.Config) *uploadFileUseCase {
-	return &uploadFileUseCase{config: config}
+func NewUploadFileUseCase(config *config.Config, blobStore storage.BlobStore, txFilesRepository repository.TxFilesRepository,
+	versionsRepository repository.VersionsRepository, uploadReservationsRepository repository.UploadReservationsRepository,
+	createVersionUseCase CreateVersionUseCase) *uploadFileUseCase {
+	return &uploadFileUseCase{
+		config:                       config,
+		blobStore:                    blobStore,
+		txFilesRepository:            txFilesRepository,
+		versionsRepository:           versionsRepository,
+		uploadReservationsRepository: uploadReservationsRepository,
+		createVersionUseCase:         createVersionUseCase,
+	}
 }
 
-func (u *uploadFileUseCase) Execute(ctx context.Context, file *entity.File, src io.Reader) (err error) {
+// Execute stores src as the content of file, which must carry the size declared by the client.
+// That size is reserved from the owner quota before anything is written, less the space given back
+// by the content and versions dropped when replaced is set, and src is staged under
+// the reservation ID until it is verified to have exactly the declared size. Only then the staged
+// content is moved to file.FileId and the file row created, or it becomes the new content of replaced
+// when it is set. Whatever fails, nothing is left at the ID of the file.
+//
+// file.Size and file.Checksum end up with the amount of bytes read from src and their hex encoded SHA-256.
+// When storage.compression is zstd, the content is compressed unless its type is compressed already,
+// and file.Size keeps the size before compression, since that is what counts toward the quota
+func (u *uploadFileUseCase) Execute(ctx context.Context, file *entity.File, src io.Reader, replaced *entity.File) (uploaded *entity.File, err error) {
 	traceId := ctx.Value(middleware.RequestIDKey).(string)
 
-	filerep, err := os.Create(u.config.Storage.Path + "/storage/" + file.FileId)
+	owner := file.Owner
+
+	if replaced != nil {
+		owner = replaced.Owner
+	}
+
+	declared := file.Size
+	reserved := declared
+
+	if replaced != nil {
+		// the replacement only grows usage by what is not given back, and the quota is enforced
+		// again when it becomes the new content
+		versions, err := u.versionsRepository.FindAllByFileId(replaced.FileId)
+
+		if err != nil {
+			slog.Error("Could not find file versions", "traceId", traceId, "fileId", replaced.FileId, "error", err)
+			return nil, err
+		}
+
+		_, freed := replacedContent(u.config.Versions.MaxPerFile, replaced, versions)
+		reserved = max(declared-freed, 0)
+	}
+
+	reservation := entity.NewUploadReservation(owner, reserved, uploadReservationDuration)
+
+	err = u.uploadReservationsRepository.Save(reservation, int64(parser.ParseUsage(u.config.Storage.Limit)))
+
+	if err == repository.ErrQuotaExceeded {
+		slog.Info("Could not reserve space for upload because available storage for owner is insufficient", "traceId", traceId, "userId", owner, "size", reserved)
+		return nil, ErrNotAvailableSpace
+	}
 
 	if err != nil {
-		slog.Error("Could not create file in fs", "traceId", traceId, "error", err)
-		return
+		slog.Error("Could not reserve space for upload", "traceId", traceId, "userId", owner, "error", err)
+		return nil, err
+	}
+
+	defer u.release(traceId, reservation)
+
+	// the file ID identifies the staged content only, so nothing is written at it before the content is verified
+	staged := &entity.File{FileId: reservation.ReservationId, Filename: file.Filename}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if err := u.blobStore.Delete(staged.FileId); err != nil {
+			slog.Error("Could not remove staged upload from storage", "traceId", traceId, "blobId", staged.FileId, "error", err)
+		}
+	}()
+
+	// one byte over the declared size is enough to tell the content is bigger than declared
+	if err = u.write(traceId, staged, io.LimitReader(src, declared+1)); err != nil {
+		return nil, err
 	}
 
-	defer filerep.Close()
+	if staged.Size != declared {
+		slog.Info("Refusing upload whose size differs from the declared one", "traceId", traceId, "declared", declared, "size", staged.Size)
+		return nil, ErrUploadSizeMismatch
+	}
+
+	file.Size = staged.Size
+	file.Checksum = staged.Checksum
 
-	_, err = io.Copy(filerep, src)
+	if replaced != nil {
+		return u.createVersionUseCase.Execute(ctx, replaced.FileId, staged)
+	}
+
+	if err = u.commit(traceId, staged.FileId, file); err != nil {
+		return nil, err
+	}
+
+	slog.Info("File uploaded successfully", "traceId", traceId, "fileId", file.FileId)
+	return file, nil
+}
+
+// write streams src under file.FileId, setting file.Size and file.Checksum
+func (u *uploadFileUseCase) write(traceId string, file *entity.File, src io.Reader) error {
+	hash := sha256.New()
+	src = io.TeeReader(src, hash)
+
+	if u.config.Storage.Compression != compressionZstd {
+		written, err := u.blobStore.Put(file.FileId, src)
+
+		if err != nil {
+			slog.Error("Could not write file to storage", "traceId", traceId, "error", err)
+			return err
+		}
+
+		file.Size = written
+		file.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+		return nil
+	}
+
+	method, src, err := compression.Choose(file.Filename, src)
 
 	if err != nil {
 		slog.Error("Could not read file buffer", "traceId", traceId, "error", err)
-		return
+		return err
 	}
 
-	return
+	var size int64
+
+	content := compression.Encode(src, method, &size)
+
+	defer content.Close()
+
+	if _, err := u.blobStore.Put(file.FileId, content); err != nil {
+		slog.Error("Could not write file to storage", "traceId", traceId, "error", err)
+		return err
+	}
+
+	file.Size = size
+	file.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	slog.Info("File written to storage", "traceId", traceId, "fileId", file.FileId, "compression", method.String())
+
+	return nil
+}
+
+// commit moves the staged content to the file ID and then creates the file row, moving the content back when
+// the row is not committed. The move happens before the transaction starts, since the blob store may write to
+// the database itself, so a crash in between leaves content no row points at rather than a file without content.
+// The quota is checked again in case the reservation expired
+func (u *uploadFileUseCase) commit(traceId string, stagedId string, file *entity.File) (err error) {
+	if err := u.blobStore.Move(stagedId, file.FileId); err != nil {
+		slog.Error("Could not move staged upload into place", "traceId", traceId, "fileId", file.FileId, "error", err)
+		return err
+	}
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if err := u.blobStore.Move(file.FileId, stagedId); err != nil {
+			slog.Error("Could not move content of uncommitted file back", "traceId", traceId, "fileId", file.FileId, "error", err)
+		}
+	}()
+
+	tx, err := u.txFilesRepository.Begin()
+
+	if err != nil {
+		slog.Error("Could not initialize transaction", "traceId", traceId, "error", err)
+		return err
+	}
+
+	defer u.txFilesRepository.Rollback(tx)
+
+	usage, err := u.txFilesRepository.FindUsageByUserId(tx, file.Owner)
+
+	if err != nil {
+		slog.Error("Could not find owner usage", "traceId", traceId, "userId", file.Owner, "error", err)
+		return err
+	}
+
+	if file.Size > int64(parser.ParseUsage(u.config.Storage.Limit))-usage {
+		slog.Info("Could not create file because available storage for owner is insufficient", "traceId", traceId, "userId", file.Owner)
+		return ErrNotAvailableSpace
+	}
+
+	if err := u.txFilesRepository.Save(tx, file); err != nil {
+		slog.Error("Could not create file", "traceId", traceId, "error", err)
+		return err
+	}
+
+	if err := u.txFilesRepository.Commit(tx); err != nil {
+		slog.Error("Could not commit file creation", "traceId", traceId, "fileId", file.FileId, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// release gives the reserved space back. By now the content either counts toward the quota
+// through its row or was discarded, and a reservation left behind only holds space until it expires
+func (u *uploadFileUseCase) release(traceId string, reservation *entity.UploadReservation) {
+	if err := u.uploadReservationsRepository.Delete(reservation.ReservationId); err != nil {
+		slog.Error("Could not release upload reservation", "traceId", traceId, "reservationId", reservation.ReservationId, "error", err)
+	}
 }
@@ -2,39 +2,364 @@ package usecase_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/google/uuid"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository/mocks"
+	appStorage "github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db"
+	infraRepository "github.com/murilo-bracero/raspstore/file-service/internal/infra/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 )
 
 func TestUploadFileUseCase(t *testing.T) {
-	mockConfig.Storage.Path = os.TempDir()
+	cfg := &config.Config{}
+	cfg.Storage.Limit = "1000M"
 
-	eFile := &entity.File{
-		FileId: uuid.NewString(),
-	}
+	ctx := context.WithValue(context.Background(), middleware.RequestIDKey, "test-trace-id")
 
-	file, err := os.CreateTemp(os.TempDir()+"/storage/", "upload.*.txt")
+	t.Run("happy path", func(t *testing.T) {
+		root := t.TempDir()
+		blobStore, err := storage.NewLocalBlobStore(root)
+		assert.NoError(t, err)
 
-	assert.NoError(t, err)
+		mockCtrl := gomock.NewController(t)
+		tr := mocks.NewMockTxFilesRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
 
-	ctx := context.Background()
-	ctx = context.WithValue(ctx, middleware.RequestIDKey, "test-trace-id")
+		file := entity.NewFile("upload.txt", 7, false, "userId")
 
-	t.Run("happy path", func(t *testing.T) {
-		uc := usecase.NewUploadFileUseCase(mockConfig)
+		var reservationId string
 
-		err := uc.Execute(ctx, eFile, file)
+		urr.EXPECT().Save(gomock.Any(), toMb(1000)).DoAndReturn(func(reservation *entity.UploadReservation, quota int64) error {
+			assert.Equal(t, "userId", reservation.UserId)
+			assert.Equal(t, int64(7), reservation.Size)
+			reservationId = reservation.ReservationId
+			return nil
+		})
+		tr.EXPECT().Begin().Return(nil, nil)
+		tr.EXPECT().FindUsageByUserId(nil, "userId").Return(int64(0), nil)
+		tr.EXPECT().Save(nil, file).Return(nil)
+		tr.EXPECT().Commit(nil).DoAndReturn(func(_ any) error {
+			// the content is in place before the row is committed, so a committed file always has content
+			assert.FileExists(t, root+"/"+file.FileId)
+			assert.NoFileExists(t, root+"/"+reservationId)
+			return nil
+		})
+		tr.EXPECT().Rollback(nil).Return(nil)
+		urr.EXPECT().Delete(gomock.Any()).DoAndReturn(func(id string) error {
+			assert.Equal(t, reservationId, id)
+			return nil
+		})
+
+		uploaded, err := usecase.NewUploadFileUseCase(cfg, blobStore, tr, nil, urr, nil).Execute(ctx, file, strings.NewReader("content"), nil)
 
 		assert.NoError(t, err)
+		assert.Equal(t, file, uploaded)
+		assert.Equal(t, int64(7), uploaded.Size)
+		assert.Equal(t, "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73", uploaded.Checksum)
+		assert.FileExists(t, root+"/"+file.FileId)
+		assert.NoFileExists(t, root+"/"+reservationId)
+	})
 
-		_, err = os.Lstat(mockConfig.Storage.Path + "/storage/" + eFile.FileId)
+	t.Run("compresses content when enabled", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Storage.Limit = "1000M"
+		cfg.Storage.Compression = "zstd"
 
+		root := t.TempDir()
+		blobStore, err := storage.NewLocalBlobStore(root)
 		assert.NoError(t, err)
+
+		mockCtrl := gomock.NewController(t)
+		tr := mocks.NewMockTxFilesRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		content := strings.Repeat("id,name,created_at\n", 1000)
+		compressed := entity.NewFile("export.csv", int64(len(content)), false, "userId")
+
+		urr.EXPECT().Save(gomock.Any(), toMb(1000)).Return(nil)
+		tr.EXPECT().Begin().Return(nil, nil)
+		tr.EXPECT().FindUsageByUserId(nil, "userId").Return(int64(0), nil)
+		tr.EXPECT().Save(nil, compressed).Return(nil)
+		tr.EXPECT().Commit(nil).Return(nil)
+		tr.EXPECT().Rollback(nil).Return(nil)
+		urr.EXPECT().Delete(gomock.Any()).Return(nil)
+
+		_, err = usecase.NewUploadFileUseCase(cfg, blobStore, tr, nil, urr, nil).Execute(ctx, compressed, strings.NewReader(content), nil)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(content)), compressed.Size)
+
+		// the checksum is taken from the content as uploaded, not as stored
+		sum := sha256.Sum256([]byte(content))
+		assert.Equal(t, hex.EncodeToString(sum[:]), compressed.Checksum)
+
+		stat, err := os.Stat(root + "/" + compressed.FileId)
+		assert.NoError(t, err)
+		assert.Less(t, stat.Size(), compressed.Size)
+
+		downloaded, err := usecase.NewDownloadFileUseCase(blobStore).Execute(ctx, compressed.FileId)
+		assert.NoError(t, err)
+
+		defer downloaded.Close()
+
+		read, err := io.ReadAll(downloaded)
+		assert.NoError(t, err)
+		assert.Equal(t, content, string(read))
+	})
+
+	t.Run("should not write anything when space cannot be reserved", func(t *testing.T) {
+		root := t.TempDir()
+		blobStore, err := storage.NewLocalBlobStore(root)
+		assert.NoError(t, err)
+
+		mockCtrl := gomock.NewController(t)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		urr.EXPECT().Save(gomock.Any(), toMb(1000)).Return(repository.ErrQuotaExceeded)
+
+		file := entity.NewFile("upload.txt", toMb(1001), false, "userId")
+
+		_, err = usecase.NewUploadFileUseCase(cfg, blobStore, nil, nil, urr, nil).Execute(ctx, file, strings.NewReader("content"), nil)
+
+		assert.ErrorIs(t, err, usecase.ErrNotAvailableSpace)
+		assertEmptyDir(t, root)
 	})
+
+	t.Run("should discard content whose size differs from the declared one", func(t *testing.T) {
+		root := t.TempDir()
+		blobStore, err := storage.NewLocalBlobStore(root)
+		assert.NoError(t, err)
+
+		for _, declared := range []int64{3, 10} {
+			mockCtrl := gomock.NewController(t)
+			urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+			urr.EXPECT().Save(gomock.Any(), toMb(1000)).Return(nil)
+			urr.EXPECT().Delete(gomock.Any()).Return(nil)
+
+			file := entity.NewFile("upload.txt", declared, false, "userId")
+
+			_, err = usecase.NewUploadFileUseCase(cfg, blobStore, nil, nil, urr, nil).Execute(ctx, file, strings.NewReader("content"), nil)
+
+			assert.ErrorIs(t, err, usecase.ErrUploadSizeMismatch)
+			assertEmptyDir(t, root)
+		}
+	})
+
+	t.Run("should not leave content behind when commit fails", func(t *testing.T) {
+		root := t.TempDir()
+		blobStore, err := storage.NewLocalBlobStore(root)
+		assert.NoError(t, err)
+
+		mockCtrl := gomock.NewController(t)
+		tr := mocks.NewMockTxFilesRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		file := entity.NewFile("upload.txt", 7, false, "userId")
+
+		urr.EXPECT().Save(gomock.Any(), toMb(1000)).Return(nil)
+		tr.EXPECT().Begin().Return(nil, nil)
+		tr.EXPECT().FindUsageByUserId(nil, "userId").Return(int64(0), nil)
+		tr.EXPECT().Save(nil, file).Return(nil)
+		tr.EXPECT().Commit(nil).Return(errors.New("database is locked"))
+		tr.EXPECT().Rollback(nil).Return(nil)
+		urr.EXPECT().Delete(gomock.Any()).Return(nil)
+
+		_, err = usecase.NewUploadFileUseCase(cfg, blobStore, tr, nil, urr, nil).Execute(ctx, file, strings.NewReader("content"), nil)
+
+		assert.Error(t, err)
+		assertEmptyDir(t, root)
+	})
+
+	t.Run("should not create the file when content cannot be moved into place", func(t *testing.T) {
+		root := t.TempDir()
+		blobStore, err := storage.NewLocalBlobStore(root)
+		assert.NoError(t, err)
+
+		mockCtrl := gomock.NewController(t)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		urr.EXPECT().Save(gomock.Any(), toMb(1000)).Return(nil)
+		urr.EXPECT().Delete(gomock.Any()).Return(nil)
+
+		file := entity.NewFile("upload.txt", 7, false, "userId")
+
+		_, err = usecase.NewUploadFileUseCase(cfg, &failingMoveBlobStore{blobStore}, nil, nil, urr, nil).
+			Execute(ctx, file, strings.NewReader("content"), nil)
+
+		assert.Error(t, err)
+		assertEmptyDir(t, root)
+	})
+
+	t.Run("should move content into place with deduplication on the database", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Storage.Limit = "1000M"
+		cfg.Storage.Path = t.TempDir()
+		cfg.Storage.Dedup = true
+
+		// migrations are looked up relative to the module root
+		wd, err := os.Getwd()
+		assert.NoError(t, err)
+		assert.NoError(t, os.Chdir("../../.."))
+
+		defer os.Chdir(wd)
+
+		conn, err := db.NewSqliteDatabaseConnection(cfg)
+		assert.NoError(t, err)
+
+		defer conn.Close()
+
+		blobStore, _, err := storage.NewBlobStore(ctx, cfg, infraRepository.NewBlobsRepository(ctx, conn.Db()))
+		assert.NoError(t, err)
+
+		tr := infraRepository.NewTxFilesRepository(ctx, conn.Db())
+		urr := infraRepository.NewUploadReservationsRepository(ctx, conn.Db())
+
+		file := entity.NewFile("upload.txt", 7, false, "userId")
+
+		// the blob store writes to the database as well, which must not happen while the file row is not committed
+		_, err = usecase.NewUploadFileUseCase(cfg, blobStore, tr, nil, urr, nil).Execute(ctx, file, strings.NewReader("content"), nil)
+
+		if !assert.NoError(t, err) {
+			return
+		}
+
+		found, err := infraRepository.NewFilesRepository(ctx, conn.Db()).FindById("userId", []string{}, file.FileId)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(7), found.Size)
+
+		downloaded, err := usecase.NewDownloadFileUseCase(blobStore).Execute(ctx, file.FileId)
+		assert.NoError(t, err)
+
+		defer downloaded.Close()
+
+		read, err := io.ReadAll(downloaded)
+		assert.NoError(t, err)
+		assert.Equal(t, "content", string(read))
+	})
+
+	t.Run("should replace content of existing file", func(t *testing.T) {
+		root := t.TempDir()
+		blobStore, err := storage.NewLocalBlobStore(root)
+		assert.NoError(t, err)
+
+		mockCtrl := gomock.NewController(t)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		var reservationId string
+
+		vr.EXPECT().FindAllByFileId("existingId").Return([]*entity.FileVersion{}, nil)
+		urr.EXPECT().Save(gomock.Any(), toMb(1000)).DoAndReturn(func(reservation *entity.UploadReservation, quota int64) error {
+			// the space is taken from the owner of the replaced file, not from the uploader
+			assert.Equal(t, "ownerId", reservation.UserId)
+			// without versions the current content is given back
+			assert.Equal(t, int64(2), reservation.Size)
+			reservationId = reservation.ReservationId
+			return nil
+		})
+		urr.EXPECT().Delete(gomock.Any()).Return(nil)
+
+		createVersion := &stagedVersionUseCaseMock{}
+		file := entity.NewFile("upload.txt", 7, false, "userId")
+
+		uploaded, err := usecase.NewUploadFileUseCase(cfg, blobStore, nil, vr, urr, createVersion).
+			Execute(ctx, file, strings.NewReader("content"), &entity.File{FileId: "existingId", Owner: "ownerId", Size: 5})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "existingId", uploaded.FileId)
+		assert.Equal(t, "existingId", createVersion.fileId)
+		assert.Equal(t, reservationId, createVersion.content.FileId)
+		assert.Equal(t, int64(7), createVersion.content.Size)
+	})
+
+	t.Run("should reserve only what the replacement adds over the dropped versions", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Storage.Limit = "1000M"
+		cfg.Versions.MaxPerFile = 2
+
+		blobStore, err := storage.NewLocalBlobStore(t.TempDir())
+		assert.NoError(t, err)
+
+		mockCtrl := gomock.NewController(t)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		vr.EXPECT().FindAllByFileId("existingId").Return([]*entity.FileVersion{{Size: 3}, {Size: 4}}, nil)
+		urr.EXPECT().Save(gomock.Any(), toMb(1000)).DoAndReturn(func(reservation *entity.UploadReservation, quota int64) error {
+			// the current content is kept as a version, and only the oldest version is dropped to make room for it
+			assert.Equal(t, int64(3), reservation.Size)
+			return nil
+		})
+		urr.EXPECT().Delete(gomock.Any()).Return(nil)
+
+		file := entity.NewFile("upload.txt", 7, false, "userId")
+
+		_, err = usecase.NewUploadFileUseCase(cfg, blobStore, nil, vr, urr, &stagedVersionUseCaseMock{}).
+			Execute(ctx, file, strings.NewReader("content"), &entity.File{FileId: "existingId", Owner: "ownerId", Size: 5})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should not reserve space for a replacement smaller than what it gives back", func(t *testing.T) {
+		blobStore, err := storage.NewLocalBlobStore(t.TempDir())
+		assert.NoError(t, err)
+
+		mockCtrl := gomock.NewController(t)
+		vr := mocks.NewMockVersionsRepository(mockCtrl)
+		urr := mocks.NewMockUploadReservationsRepository(mockCtrl)
+
+		vr.EXPECT().FindAllByFileId("existingId").Return([]*entity.FileVersion{}, nil)
+		urr.EXPECT().Save(gomock.Any(), toMb(1000)).DoAndReturn(func(reservation *entity.UploadReservation, quota int64) error {
+			assert.Equal(t, int64(0), reservation.Size)
+			return nil
+		})
+		urr.EXPECT().Delete(gomock.Any()).Return(nil)
+
+		file := entity.NewFile("upload.txt", 7, false, "userId")
+
+		_, err = usecase.NewUploadFileUseCase(cfg, blobStore, nil, vr, urr, &stagedVersionUseCaseMock{}).
+			Execute(ctx, file, strings.NewReader("content"), &entity.File{FileId: "existingId", Owner: "ownerId", Size: toMb(1)})
+
+		assert.NoError(t, err)
+	})
+}
+
+func assertEmptyDir(t *testing.T, dir string) {
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+type stagedVersionUseCaseMock struct {
+	fileId  string
+	content *entity.File
+}
+
+func (s *stagedVersionUseCaseMock) Execute(ctx context.Context, fileId string, content *entity.File) (*entity.File, error) {
+	s.fileId = fileId
+	s.content = content
+
+	return &entity.File{FileId: fileId, Size: content.Size, Checksum: content.Checksum}, nil
+}
+
+type failingMoveBlobStore struct {
+	appStorage.BlobStore
+}
+
+func (f *failingMoveBlobStore) Move(fromId string, toId string) error {
+	return errors.New("disk is read-only")
 }
@@ -2,21 +2,47 @@ package usecase
 
 import (
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
 )
 
 type UseCases struct {
-	CreateFileUseCase   CreateFileUseCase
-	UpdateFileUseCase   UpdateFileUseCase
-	UploadUseCase       UploadFileUseCase
-	DownloadFileUseCase DownloadFileUseCase
+	CreateFileUseCase           CreateFileUseCase
+	UpdateFileUseCase           UpdateFileUseCase
+	UploadUseCase               UploadFileUseCase
+	CopyFileUseCase             CopyFileUseCase
+	DownloadFileUseCase         DownloadFileUseCase
+	TransferOwnershipUseCase    TransferOwnershipUseCase
+	TransferAllOwnershipUseCase TransferAllOwnershipUseCase
+	PurgeTrashUseCase           PurgeTrashUseCase
+	CreateVersionUseCase        CreateVersionUseCase
+	RestoreVersionUseCase       RestoreVersionUseCase
+	DeleteVersionUseCase        DeleteVersionUseCase
+	ScrubFilesUseCase           ScrubFilesUseCase
+	DeleteBlobsUseCase          DeleteBlobsUseCase
+	ReconcileStorageUseCase     ReconcileStorageUseCase
 }
 
-func InitUseCases(config *config.Config, repo repository.FilesRepository, txRepo repository.TxFilesRepository) *UseCases {
+func InitUseCases(config *config.Config, blobStore storage.BlobStore, repo repository.FilesRepository, txRepo repository.TxFilesRepository,
+	foldersRepo repository.FoldersRepository, versionsRepo repository.VersionsRepository, locksRepo repository.LocksRepository,
+	blobDeletionsRepo repository.BlobDeletionsRepository, uploadReservationsRepo repository.UploadReservationsRepository) *UseCases {
+	createFileUseCase := NewCreateFileUseCase(config, repo, uploadReservationsRepo)
+	createVersionUseCase := NewCreateVersionUseCase(config, blobStore, repo, txRepo, versionsRepo, locksRepo, blobDeletionsRepo)
+
 	return &UseCases{
-		CreateFileUseCase:   NewCreateFileUseCase(config, repo),
-		UpdateFileUseCase:   NewUpdateFileUseCase(txRepo),
-		UploadUseCase:       NewUploadFileUseCase(config),
-		DownloadFileUseCase: NewDownloadFileUseCase(config),
+		CreateFileUseCase:           createFileUseCase,
+		UpdateFileUseCase:           NewUpdateFileUseCase(txRepo, locksRepo),
+		UploadUseCase:               NewUploadFileUseCase(config, blobStore, txRepo, versionsRepo, uploadReservationsRepo, createVersionUseCase),
+		CopyFileUseCase:             NewCopyFileUseCase(blobStore, repo, foldersRepo, createFileUseCase),
+		DownloadFileUseCase:         NewDownloadFileUseCase(blobStore),
+		TransferOwnershipUseCase:    NewTransferOwnershipUseCase(config, txRepo),
+		TransferAllOwnershipUseCase: NewTransferAllOwnershipUseCase(config, txRepo),
+		PurgeTrashUseCase:           NewPurgeTrashUseCase(config, blobStore, repo, versionsRepo, blobDeletionsRepo),
+		CreateVersionUseCase:        createVersionUseCase,
+		RestoreVersionUseCase:       NewRestoreVersionUseCase(blobStore, repo, txRepo, versionsRepo, locksRepo),
+		DeleteVersionUseCase:        NewDeleteVersionUseCase(blobStore, repo, versionsRepo, locksRepo, blobDeletionsRepo),
+		ScrubFilesUseCase:           NewScrubFilesUseCase(blobStore, repo),
+		DeleteBlobsUseCase:          NewDeleteBlobsUseCase(blobStore, blobDeletionsRepo),
+		ReconcileStorageUseCase:     NewReconcileStorageUseCase(blobStore, repo, versionsRepo, blobDeletionsRepo, uploadReservationsRepo),
 	}
 }
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditEntry struct {
+	AuditId      string    `json:"auditId"`
+	UserId       string    `json:"userId"`
+	Action       string    `json:"action"`
+	TargetUserId string    `json:"targetUserId,omitempty"`
+	TargetFileId string    `json:"targetFileId,omitempty"`
+	Status       int       `json:"status"`
+	TraceId      string    `json:"traceId"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func NewAuditEntry(userId string, action string, targetUserId string, targetFileId string, status int, traceId string) *AuditEntry {
+	return &AuditEntry{
+		AuditId:      uuid.NewString(),
+		UserId:       userId,
+		Action:       action,
+		TargetUserId: targetUserId,
+		TargetFileId: targetFileId,
+		Status:       status,
+		TraceId:      traceId,
+		CreatedAt:    time.Now(),
+	}
+}
+
+type AuditPage struct {
+	Content []*AuditEntry
+	Count   int
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// BlobRef points the ID of a file or version at the content it holds. Identical contents
+// share the same Hash, so they are stored only once
+type BlobRef struct {
+	BlobId    string
+	Hash      string
+	Size      int64
+	CreatedAt time.Time
+}
+
+// BlobDeletion is a blob whose file or version row is gone but that may still be in the storage
+type BlobDeletion struct {
+	BlobId    string
+	Attempts  int64
+	LastError string
+	CreatedAt time.Time
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment is a note left on a file. A reply points to the comment it answers through ParentId
+// and is listed inside Replies of that comment
+type Comment struct {
+	CommentId string     `json:"commentId"`
+	FileId    string     `json:"fileId"`
+	ParentId  string     `json:"parentId,omitempty"`
+	Content   string     `json:"content"`
+	CreatedAt time.Time  `json:"createdAt"`
+	CreatedBy string     `json:"createdBy"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+	Replies   []*Comment `json:"replies"`
+}
+
+func NewComment(fileId string, parentId string, content string, createdBy string) *Comment {
+	return &Comment{
+		CommentId: uuid.NewString(),
+		FileId:    fileId,
+		ParentId:  parentId,
+		Content:   content,
+		CreatedAt: time.Now(),
+		CreatedBy: createdBy,
+		Replies:   []*Comment{},
+	}
+}
@@ -1,37 +1,73 @@
 package entity
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const (
+	PermissionViewer = "VIEWER"
+	PermissionEditor = "EDITOR"
+)
+
 type File struct {
-	FileId    string     `json:"fileId,omitempty" bson:"file_id"`
-	Filename  string     `json:"filename,omitempty"`
-	Size      int64      `json:"size,omitempty"`
-	Secret    bool       `json:"secret" bson:"is_secret"`
-	Owner     string     `json:"owner,omitempty"`
-	Editors   []string   `json:"editors"`
-	Viewers   []string   `json:"viewers"`
-	CreatedAt time.Time  `json:"createdAt,omitempty" bson:"created_at"`
-	UpdatedAt *time.Time `json:"updatedAt,omitempty" bson:"updated_at"`
-	CreatedBy string     `json:"createdBy,omitempty" bson:"created_by"`
-	UpdatedBy *string    `json:"updatedBy,omitempty" bson:"updated_by"`
+	FileId       string            `json:"fileId,omitempty" bson:"file_id"`
+	Filename     string            `json:"filename,omitempty"`
+	Size         int64             `json:"size,omitempty"`
+	Secret       bool              `json:"secret" bson:"is_secret"`
+	Owner        string            `json:"owner,omitempty"`
+	ParentId     string            `json:"parentId,omitempty"`
+	Editors      []string          `json:"editors"`
+	Viewers      []string          `json:"viewers"`
+	EditorGroups []string          `json:"editorGroups"`
+	ViewerGroups []string          `json:"viewerGroups"`
+	Permission   string            `json:"permission,omitempty"`
+	Tags         []string          `json:"tags,omitempty"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+	Starred      bool              `json:"starred"`
+	Lock         *Lock             `json:"lock,omitempty"`
+	Checksum     string            `json:"checksum,omitempty"`
+	CorruptedAt  *time.Time        `json:"corruptedAt,omitempty"`
+	CreatedAt    time.Time         `json:"createdAt,omitempty" bson:"created_at"`
+	UpdatedAt    *time.Time        `json:"updatedAt,omitempty" bson:"updated_at"`
+	CreatedBy    string            `json:"createdBy,omitempty" bson:"created_by"`
+	UpdatedBy    *string           `json:"updatedBy,omitempty" bson:"updated_by"`
+	DeletedAt    *time.Time        `json:"deletedAt,omitempty"`
+	DeletedBy    *string           `json:"deletedBy,omitempty"`
 }
 
 func NewFile(filename string, size int64, secret bool, ownerId string) *File {
 	return &File{
-		FileId:    uuid.NewString(),
-		Filename:  filename,
-		Size:      size,
-		Secret:    secret,
-		CreatedAt: time.Now(),
-		Viewers:   []string{},
-		Editors:   []string{},
-		CreatedBy: ownerId,
-		Owner:     ownerId,
+		FileId:       uuid.NewString(),
+		Filename:     filename,
+		Size:         size,
+		Secret:       secret,
+		CreatedAt:    time.Now(),
+		Viewers:      []string{},
+		Editors:      []string{},
+		ViewerGroups: []string{},
+		EditorGroups: []string{},
+		CreatedBy:    ownerId,
+		Owner:        ownerId,
+	}
+}
+
+// CanEdit tells whether the user owns the file or was granted EDITOR over it,
+// directly or through one of their groups
+func (f *File) CanEdit(userId string, groups []string) bool {
+	if f.Owner == userId || slices.Contains(f.Editors, userId) {
+		return true
+	}
+
+	for _, group := range groups {
+		if slices.Contains(f.EditorGroups, group) {
+			return true
+		}
 	}
+
+	return false
 }
 
 type FilePage struct {
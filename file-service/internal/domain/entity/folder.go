@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RootFolderId can be used to filter files that are not inside any folder
+const RootFolderId = "root"
+
+type Folder struct {
+	FolderId  string        `json:"folderId"`
+	Name      string        `json:"name"`
+	ParentId  string        `json:"parentId,omitempty"`
+	Owner     string        `json:"owner"`
+	Path      []*Breadcrumb `json:"path,omitempty"`
+	CreatedAt time.Time     `json:"createdAt"`
+	UpdatedAt *time.Time    `json:"updatedAt,omitempty"`
+	CreatedBy string        `json:"createdBy"`
+	UpdatedBy *string       `json:"updatedBy,omitempty"`
+}
+
+// Breadcrumb is one step of the path from the root to a folder
+type Breadcrumb struct {
+	FolderId string `json:"folderId"`
+	Name     string `json:"name"`
+}
+
+func NewFolder(name string, parentId string, ownerId string) *Folder {
+	return &Folder{
+		FolderId:  uuid.NewString(),
+		Name:      name,
+		ParentId:  parentId,
+		Owner:     ownerId,
+		CreatedAt: time.Now(),
+		CreatedBy: ownerId,
+	}
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+)
+
+const linkTokenSize = 24
+
+type Link struct {
+	Token        string     `json:"token"`
+	FileId       string     `json:"fileId"`
+	PasswordHash string     `json:"-"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+	MaxDownloads *int64     `json:"maxDownloads,omitempty"`
+	Downloads    int64      `json:"downloads"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	CreatedBy    string     `json:"createdBy"`
+}
+
+func NewLink(fileId string, createdBy string, expiresAt *time.Time, maxDownloads *int64) (*Link, error) {
+	token := make([]byte, linkTokenSize)
+
+	if _, err := rand.Read(token); err != nil {
+		return nil, err
+	}
+
+	return &Link{
+		Token:        base64.RawURLEncoding.EncodeToString(token),
+		FileId:       fileId,
+		ExpiresAt:    expiresAt,
+		MaxDownloads: maxDownloads,
+		CreatedAt:    time.Now(),
+		CreatedBy:    createdBy,
+	}, nil
+}
+
+func (l *Link) Expired() bool {
+	return l.ExpiresAt != nil && time.Now().After(*l.ExpiresAt)
+}
+
+func (l *Link) Exhausted() bool {
+	return l.MaxDownloads != nil && l.Downloads >= *l.MaxDownloads
+}
+
+func (l *Link) Protected() bool {
+	return l.PasswordHash != ""
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// Lock gives LockedBy exclusive editing of a file until ExpiresAt
+type Lock struct {
+	FileId    string    `json:"fileId"`
+	LockedBy  string    `json:"lockedBy"`
+	LockedAt  time.Time `json:"lockedAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+func NewLock(fileId string, lockedBy string, duration time.Duration) *Lock {
+	now := time.Now()
+
+	return &Lock{
+		FileId:    fileId,
+		LockedBy:  lockedBy,
+		LockedAt:  now,
+		ExpiresAt: now.Add(duration),
+	}
+}
+
+// Blocks tells whether the lock keeps userId from changing the file. A nil or expired lock blocks no one
+func (l *Lock) Blocks(userId string) bool {
+	return l != nil && l.LockedBy != userId && time.Now().Before(l.ExpiresAt)
+}
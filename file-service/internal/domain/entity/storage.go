@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// DiskStatus is the health of one of the disks of a mirrored storage. A disk stops being
+// healthy when an operation on it fails or a blob is found missing on it, and becomes
+// healthy again once a resync reaches it and repairs every missing blob
+type DiskStatus struct {
+	Path     string     `json:"path"`
+	Healthy  bool       `json:"healthy"`
+	Blobs    int        `json:"blobs"`
+	Error    string     `json:"error,omitempty"`
+	FailedAt *time.Time `json:"failedAt,omitempty"`
+}
+
+type StorageStatus struct {
+	Mirrored     bool          `json:"mirrored"`
+	Disks        []*DiskStatus `json:"disks"`
+	LastResyncAt *time.Time    `json:"lastResyncAt,omitempty"`
+	Repaired     int           `json:"repaired"`
+}
+
+// StorageReport lists where the database and the blob storage disagree. Blobs are referred to
+// by the ID of the file or version they hold
+type StorageReport struct {
+	OrphanBlobs    []string        `json:"orphanBlobs"`
+	MissingBlobs   []string        `json:"missingBlobs"`
+	SizeMismatches []*SizeMismatch `json:"sizeMismatches"`
+	Fixed          bool            `json:"fixed"`
+}
+
+type SizeMismatch struct {
+	BlobId   string `json:"blobId"`
+	Expected int64  `json:"expected"`
+	Actual   int64  `json:"actual"`
+}
+
+func (r *StorageReport) Clean() bool {
+	return len(r.OrphanBlobs) == 0 && len(r.MissingBlobs) == 0 && len(r.SizeMismatches) == 0
+}
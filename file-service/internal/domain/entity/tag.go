@@ -0,0 +1,14 @@
+package entity
+
+import "strings"
+
+// Tag is a label that can be attached to any file, counted over the files visible to the requester
+type Tag struct {
+	Name  string `json:"name"`
+	Files int64  `json:"files"`
+}
+
+// NormalizeTag makes "Holidays " and "holidays" the same tag
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
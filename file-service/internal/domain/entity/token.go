@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ScopeFull       = "FULL"
+	ScopeReadOnly   = "READ_ONLY"
+	ScopeUploadOnly = "UPLOAD_ONLY"
+)
+
+// AccessTokenPrefix tells personal access tokens apart from the JWTs issued by the identity provider
+const AccessTokenPrefix = "rsp_"
+
+const accessTokenSize = 32
+
+type AccessToken struct {
+	TokenId    string     `json:"tokenId"`
+	UserId     string     `json:"userId"`
+	Name       string     `json:"name"`
+	Scope      string     `json:"scope"`
+	Groups     []string   `json:"groups"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	TokenHash  string     `json:"-"`
+	// Token holds the raw token only right after it is created, it is never stored
+	Token string `json:"-"`
+}
+
+func NewAccessToken(userId string, name string, scope string, groups []string, expiresAt *time.Time) (*AccessToken, error) {
+	secret := make([]byte, accessTokenSize)
+
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+
+	token := AccessTokenPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return &AccessToken{
+		TokenId:   uuid.NewString(),
+		UserId:    userId,
+		Name:      name,
+		Scope:     scope,
+		Groups:    groups,
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+		TokenHash: HashAccessToken(token),
+		Token:     token,
+	}, nil
+}
+
+// HashAccessToken uses a plain SHA-256, tokens are random enough to not need
+// a slow hash, and a deterministic one allows looking them up by hash
+func HashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsAccessToken(token string) bool {
+	return strings.HasPrefix(token, AccessTokenPrefix)
+}
+
+func (t *AccessToken) Expired() bool {
+	return t.ExpiresAt != nil && time.Now().After(*t.ExpiresAt)
+}
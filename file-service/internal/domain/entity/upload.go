@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UploadReservation holds Size bytes of the UserId quota for an upload still being streamed.
+// The content is staged under ReservationId until it is moved to the ID of the file
+type UploadReservation struct {
+	ReservationId string
+	UserId        string
+	Size          int64
+	ExpiresAt     time.Time
+}
+
+func NewUploadReservation(userId string, size int64, duration time.Duration) *UploadReservation {
+	return &UploadReservation{
+		ReservationId: uuid.NewString(),
+		UserId:        userId,
+		Size:          size,
+		ExpiresAt:     time.Now().Add(duration),
+	}
+}
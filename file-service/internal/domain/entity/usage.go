@@ -0,0 +1,18 @@
+package entity
+
+type Usage struct {
+	UserId string `json:"userId"`
+	Files  int64  `json:"files"`
+	Size   int64  `json:"size"`
+}
+
+type UsagePage struct {
+	Content []*Usage
+	Count   int
+}
+
+type Totals struct {
+	Users int64 `json:"users"`
+	Files int64 `json:"files"`
+	Size  int64 `json:"size"`
+}
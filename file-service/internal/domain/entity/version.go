@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileVersion is a previous content of a file. Its blob is stored under VersionId,
+// while the current content stays under the file ID
+type FileVersion struct {
+	VersionId string    `json:"-"`
+	FileId    string    `json:"fileId"`
+	Version   int64     `json:"version"`
+	Size      int64     `json:"size"`
+	Checksum  string    `json:"checksum,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	CreatedBy string    `json:"createdBy"`
+}
+
+func NewFileVersion(fileId string, size int64, createdBy string) *FileVersion {
+	return &FileVersion{
+		VersionId: uuid.NewString(),
+		FileId:    fileId,
+		Size:      size,
+		CreatedAt: time.Now(),
+		CreatedBy: createdBy,
+	}
+}
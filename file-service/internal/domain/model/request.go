@@ -1,6 +1,69 @@
 package model
 
+import "time"
+
 type UpdateFileRequest struct {
 	Filename string `json:"filename,omitempty"`
 	Secret   bool   `json:"secret"`
 }
+
+type CreatePermissionRequest struct {
+	UserId     string `json:"userId,omitempty"`
+	Group      string `json:"group,omitempty"`
+	Permission string `json:"permission,omitempty"`
+}
+
+type TransferOwnershipRequest struct {
+	UserId       string `json:"userId,omitempty"`
+	KeepAsEditor bool   `json:"keepAsEditor"`
+}
+
+type MoveFileRequest struct {
+	FolderId string `json:"folderId,omitempty"`
+}
+
+type CopyFileRequest struct {
+	FolderId string `json:"folderId,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// UpdateMetadataRequest is the whole metadata of the file, keyed by property name
+type UpdateMetadataRequest map[string]string
+
+type AddTagRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+type CreateCommentRequest struct {
+	ParentId string `json:"parentId,omitempty"`
+	Content  string `json:"content,omitempty"`
+}
+
+type UpdateCommentRequest struct {
+	Content string `json:"content,omitempty"`
+}
+
+type LockFileRequest struct {
+	Minutes int `json:"minutes,omitempty"`
+}
+
+type CreateFolderRequest struct {
+	Name     string `json:"name,omitempty"`
+	ParentId string `json:"parentId,omitempty"`
+}
+
+type UpdateFolderRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+type CreateAccessTokenRequest struct {
+	Name      string     `json:"name,omitempty"`
+	Scope     string     `json:"scope,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type CreateLinkRequest struct {
+	Password     string     `json:"password,omitempty"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+	MaxDownloads *int64     `json:"maxDownloads,omitempty"`
+}
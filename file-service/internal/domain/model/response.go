@@ -13,14 +13,71 @@ type FilePageResponse struct {
 }
 
 type FileContent struct {
-	FileId    string     `json:"fileId,omitempty"`
-	Filename  string     `json:"filename,omitempty"`
-	Size      int64      `json:"size,omitempty"`
-	Owner     string     `json:"owner,omitempty"`
-	CreatedAt time.Time  `json:"createdAt,omitempty"`
-	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
-	CreatedBy string     `json:"createdBy,omitempty"`
-	UpdatedBy *string    `json:"updatedBy,omitempty"`
+	FileId     string     `json:"fileId,omitempty"`
+	Filename   string     `json:"filename,omitempty"`
+	Size       int64      `json:"size,omitempty"`
+	Owner      string     `json:"owner,omitempty"`
+	ParentId   string     `json:"parentId,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt,omitempty"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+	CreatedBy  string     `json:"createdBy,omitempty"`
+	UpdatedBy  *string    `json:"updatedBy,omitempty"`
+	Permission string     `json:"permission,omitempty"`
+	SharedBy   string     `json:"sharedBy,omitempty"`
+	Starred    bool       `json:"starred"`
+	DeletedAt  *time.Time `json:"deletedAt,omitempty"`
+	DeletedBy  *string    `json:"deletedBy,omitempty"`
+}
+
+type FilePermissionsResponse struct {
+	FileId       string   `json:"fileId,omitempty"`
+	Owner        string   `json:"owner,omitempty"`
+	Editors      []string `json:"editors"`
+	Viewers      []string `json:"viewers"`
+	EditorGroups []string `json:"editorGroups"`
+	ViewerGroups []string `json:"viewerGroups"`
+}
+
+type LinkResponse struct {
+	Token        string     `json:"token"`
+	FileId       string     `json:"fileId"`
+	Url          string     `json:"url"`
+	Protected    bool       `json:"protected"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+	MaxDownloads *int64     `json:"maxDownloads,omitempty"`
+	Downloads    int64      `json:"downloads"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+type FolderResponse struct {
+	FolderId  string                `json:"folderId"`
+	Name      string                `json:"name"`
+	ParentId  string                `json:"parentId,omitempty"`
+	Path      []*BreadcrumbResponse `json:"path,omitempty"`
+	CreatedAt time.Time             `json:"createdAt"`
+	UpdatedAt *time.Time            `json:"updatedAt,omitempty"`
+}
+
+type BreadcrumbResponse struct {
+	FolderId string `json:"folderId"`
+	Name     string `json:"name"`
+}
+
+type FileVersionResponse struct {
+	Version   int64     `json:"version"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+	CreatedBy string    `json:"createdBy"`
+}
+
+type AccessTokenResponse struct {
+	TokenId    string     `json:"tokenId"`
+	Name       string     `json:"name"`
+	Scope      string     `json:"scope"`
+	Token      string     `json:"token,omitempty"`
+	ExpiresAt  *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
 }
 
 type ErrorResponse struct {
@@ -32,3 +89,46 @@ type UploadSuccessResponse struct {
 	Filename string `json:"filename,omitempty"`
 	OwnerId  string `json:"ownerId,omitempty"`
 }
+
+type TransferOwnershipResponse struct {
+	Transferred int64 `json:"transferred"`
+}
+
+type UsagePageResponse struct {
+	Size          int             `json:"size"`
+	TotalElements int             `json:"totalElements"`
+	Page          int             `json:"page"`
+	Next          string          `json:"next"`
+	Content       []*UsageContent `json:"content"`
+}
+
+type UsageContent struct {
+	UserId string `json:"userId"`
+	Files  int64  `json:"files"`
+	Size   int64  `json:"size"`
+}
+
+type TotalsResponse struct {
+	Users int64 `json:"users"`
+	Files int64 `json:"files"`
+	Size  int64 `json:"size"`
+}
+
+type AuditPageResponse struct {
+	Size          int             `json:"size"`
+	TotalElements int             `json:"totalElements"`
+	Page          int             `json:"page"`
+	Next          string          `json:"next"`
+	Content       []*AuditContent `json:"content"`
+}
+
+type AuditContent struct {
+	AuditId      string    `json:"auditId"`
+	UserId       string    `json:"userId"`
+	Action       string    `json:"action"`
+	TargetUserId string    `json:"targetUserId,omitempty"`
+	TargetFileId string    `json:"targetFileId,omitempty"`
+	Status       int       `json:"status"`
+	TraceId      string    `json:"traceId"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
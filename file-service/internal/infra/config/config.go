@@ -12,8 +12,25 @@ import (
 
 type Config struct {
 	Storage struct {
-		Path  string
-		Limit string
+		Path                         string
+		Limit                        string
+		Backend                      string
+		Dedup                        bool
+		Compression                  string
+		EncryptionKey                string `yaml:"encryption-key"`
+		DeletionRetryIntervalMinutes int    `yaml:"deletion-retry-interval-minutes"`
+		Mirror                       struct {
+			Path                  string
+			ResyncIntervalMinutes int `yaml:"resync-interval-minutes"`
+		}
+		S3 struct {
+			Endpoint  string
+			Region    string
+			Bucket    string
+			AccessKey string `yaml:"access-key"`
+			SecretKey string `yaml:"secret-key"`
+			Prefix    string
+		}
 	}
 	Server struct {
 		ReadHeaderTimeout int `yaml:"read-header-timeout"`
@@ -21,6 +38,26 @@ type Config struct {
 	}
 	Auth struct {
 		PublicKeyUrl string `yaml:"public-key-url"`
+		AdminClaim   string `yaml:"admin-claim"`
+		AdminRole    string `yaml:"admin-role"`
+	}
+	Trash struct {
+		RetentionDays        int `yaml:"retention-days"`
+		PurgeIntervalMinutes int `yaml:"purge-interval-minutes"`
+	}
+	Scrub struct {
+		IntervalMinutes int `yaml:"interval-minutes"`
+	}
+	Fsck struct {
+		IntervalMinutes int `yaml:"interval-minutes"`
+		Fix             bool
+	}
+	Versions struct {
+		MaxPerFile int `yaml:"max-per-file"`
+	}
+	Locks struct {
+		DefaultMinutes int `yaml:"default-minutes"`
+		MaxMinutes     int `yaml:"max-minutes"`
 	}
 }
 
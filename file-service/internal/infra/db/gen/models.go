@@ -8,21 +8,150 @@ import (
 	"database/sql"
 )
 
+type AccessToken struct {
+	TokenID    string
+	UserID     string
+	Name       string
+	TokenHash  string
+	Scope      string
+	Groups     string
+	ExpiresAt  sql.NullInt64
+	CreatedAt  int64
+	LastUsedAt sql.NullInt64
+}
+
+type AdminAudit struct {
+	AuditID      string
+	UserID       string
+	Action       string
+	TargetUserID sql.NullString
+	TargetFileID sql.NullString
+	Status       int64
+	TraceID      string
+	CreatedAt    int64
+}
+
+type Blob struct {
+	Hash      string
+	Size      int64
+	RefCount  int64
+	CreatedAt int64
+}
+
+type BlobDeletion struct {
+	BlobID    string
+	Attempts  int64
+	LastError sql.NullString
+	CreatedAt int64
+}
+
+type BlobsRef struct {
+	BlobID    string
+	Hash      string
+	CreatedAt int64
+}
+
 type File struct {
+	FileID      string
+	FileName    string
+	Size        int64
+	IsSecret    bool
+	OwnerID     string
+	CreatedAt   int64
+	UpdatedAt   sql.NullInt64
+	CreatedBy   string
+	UpdatedBy   sql.NullString
+	ParentID    sql.NullString
+	DeletedAt   sql.NullInt64
+	DeletedBy   sql.NullString
+	Checksum    sql.NullString
+	CorruptedAt sql.NullInt64
+}
+
+type FilesComment struct {
+	CommentID string
 	FileID    string
-	FileName  string
-	Size      int64
-	IsSecret  bool
-	OwnerID   string
+	ParentID  sql.NullString
+	Content   string
 	CreatedAt int64
-	UpdatedAt sql.NullInt64
 	CreatedBy string
-	UpdatedBy sql.NullString
+	UpdatedAt sql.NullInt64
+}
+
+type FilesLink struct {
+	Token        string
+	FileID       string
+	PasswordHash sql.NullString
+	ExpiresAt    sql.NullInt64
+	MaxDownloads sql.NullInt64
+	Downloads    int64
+	CreatedAt    int64
+	CreatedBy    string
+}
+
+type FilesLock struct {
+	FileID    string
+	LockedBy  string
+	LockedAt  int64
+	ExpiresAt int64
+}
+
+type FilesMetadatum struct {
+	FileID string
+	Key    string
+	Value  string
 }
 
 type FilesPermission struct {
 	PermissionID string
 	FileID       string
 	Permission   string
-	UserID       string
+	UserID       sql.NullString
+	GroupName    sql.NullString
+}
+
+type FilesStar struct {
+	FileID    string
+	UserID    string
+	CreatedAt int64
+}
+
+type FilesTag struct {
+	FileID    string
+	TagID     string
+	CreatedAt int64
+	CreatedBy string
+}
+
+type FilesVersion struct {
+	VersionID string
+	FileID    string
+	Version   int64
+	Size      int64
+	CreatedAt int64
+	CreatedBy string
+	Checksum  sql.NullString
+}
+
+type Folder struct {
+	FolderID  string
+	Name      string
+	ParentID  sql.NullString
+	OwnerID   string
+	CreatedAt int64
+	UpdatedAt sql.NullInt64
+	CreatedBy string
+	UpdatedBy sql.NullString
+}
+
+type Tag struct {
+	TagID string
+	Name  string
+}
+
+type UploadReservation struct {
+	ReservationID string
+	UserID        string
+	Size          int64
+	ExpiresAt     int64
 }
@@ -10,9 +10,148 @@ import (
 	"database/sql"
 )
 
+const acquireBlob = `-- name: AcquireBlob :one
+INSERT INTO blobs (hash, size, ref_count, created_at)
+VALUES (?1, ?2, 1, ?3)
+ON CONFLICT (hash) DO UPDATE SET ref_count = blobs.ref_count + 1
+RETURNING ref_count
+`
+
+type AcquireBlobParams struct {
+	Hash      string
+	Size      int64
+	CreatedAt int64
+}
+
+func (q *Queries) AcquireBlob(ctx context.Context, arg AcquireBlobParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, acquireBlob, arg.Hash, arg.Size, arg.CreatedAt)
+	var ref_count int64
+	err := row.Scan(&ref_count)
+	return ref_count, err
+}
+
+const countFileStars = `-- name: CountFileStars :one
+SELECT COUNT(*) FROM files_stars WHERE file_id = ? AND user_id = ?
+`
+
+type CountFileStarsParams struct {
+	FileID string
+	UserID string
+}
+
+func (q *Queries) CountFileStars(ctx context.Context, arg CountFileStarsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countFileStars, arg.FileID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countFolderChildren = `-- name: CountFolderChildren :one
+SELECT
+    (SELECT COUNT(*) FROM folders fo WHERE fo.parent_id = ?1) +
+    (SELECT COUNT(*) FROM files f WHERE f.parent_id = ?1 AND f.deleted_at IS NULL) AS children
+`
+
+func (q *Queries) CountFolderChildren(ctx context.Context, folderID sql.NullString) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countFolderChildren, folderID)
+	var children int64
+	err := row.Scan(&children)
+	return children, err
+}
+
+const createAccessToken = `-- name: CreateAccessToken :exec
+INSERT INTO access_tokens (token_id, user_id, name, token_hash, scope, groups, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateAccessTokenParams struct {
+	TokenID   string
+	UserID    string
+	Name      string
+	TokenHash string
+	Scope     string
+	Groups    string
+	ExpiresAt sql.NullInt64
+	CreatedAt int64
+}
+
+func (q *Queries) CreateAccessToken(ctx context.Context, arg CreateAccessTokenParams) error {
+	_, err := q.db.ExecContext(ctx, createAccessToken,
+		arg.TokenID,
+		arg.UserID,
+		arg.Name,
+		arg.TokenHash,
+		arg.Scope,
+		arg.Groups,
+		arg.ExpiresAt,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createAdminAudit = `-- name: CreateAdminAudit :exec
+INSERT INTO admin_audit (audit_id, user_id, action, target_user_id, target_file_id, status, trace_id, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateAdminAuditParams struct {
+	AuditID      string
+	UserID       string
+	Action       string
+	TargetUserID sql.NullString
+	TargetFileID sql.NullString
+	Status       int64
+	TraceID      string
+	CreatedAt    int64
+}
+
+func (q *Queries) CreateAdminAudit(ctx context.Context, arg CreateAdminAuditParams) error {
+	_, err := q.db.ExecContext(ctx, createAdminAudit,
+		arg.AuditID,
+		arg.UserID,
+		arg.Action,
+		arg.TargetUserID,
+		arg.TargetFileID,
+		arg.Status,
+		arg.TraceID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const createBlobDeletion = `-- name: CreateBlobDeletion :exec
+INSERT INTO blob_deletions (blob_id, created_at) VALUES (?, ?)
+ON CONFLICT (blob_id) DO NOTHING
+`
+
+type CreateBlobDeletionParams struct {
+	BlobID    string
+	CreatedAt int64
+}
+
+func (q *Queries) CreateBlobDeletion(ctx context.Context, arg CreateBlobDeletionParams) error {
+	_, err := q.db.ExecContext(ctx, createBlobDeletion, arg.BlobID, arg.CreatedAt)
+	return err
+}
+
+const createBlobRef = `-- name: CreateBlobRef :exec
+INSERT INTO blobs_refs (blob_id, hash, created_at) VALUES (?, ?, ?)
+`
+
+type CreateBlobRefParams struct {
+	BlobID    string
+	Hash      string
+	CreatedAt int64
+}
+
+func (q *Queries) CreateBlobRef(ctx context.Context, arg CreateBlobRefParams) error {
+	_, err := q.db.ExecContext(ctx, createBlobRef, arg.BlobID, arg.Hash, arg.CreatedAt)
+	return err
+}
+
 const createFile = `-- name: CreateFile :exec
-INSERT INTO files (file_id, file_name, size, is_secret, owner_id, created_at, created_by)
-VALUES (?, ?, ?, ?, ?, ?, ?)
+INSERT INTO files (file_id, file_name, size, is_secret, owner_id, created_at, created_by, parent_id, checksum)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateFileParams struct {
@@ -23,6 +162,8 @@ type CreateFileParams struct {
 	OwnerID   string
 	CreatedAt int64
 	CreatedBy string
+	ParentID  sql.NullString
+	Checksum  sql.NullString
 }
 
 func (q *Queries) CreateFile(ctx context.Context, arg CreateFileParams) error {
@@ -34,103 +175,1973 @@ func (q *Queries) CreateFile(ctx context.Context, arg CreateFileParams) error {
 		arg.OwnerID,
 		arg.CreatedAt,
 		arg.CreatedBy,
+		arg.ParentID,
+		arg.Checksum,
 	)
 	return err
 }
 
-const deleteFileByID = `-- name: DeleteFileByID :exec
-DELETE FROM files
-WHERE file_id IN (
-    SELECT f.file_id 
-    FROM files f
-    LEFT JOIN files_permissions fp ON f.file_id = fp.file_id AND fp.permission = 'EDITOR' 
-    WHERE f.file_id = ?1
-    AND (
-        f.owner_id = ?2 OR
-        fp.user_id = ?2
-    )
-)
+const createFileBlobDeletions = `-- name: CreateFileBlobDeletions :exec
+INSERT INTO blob_deletions (blob_id, created_at)
+SELECT f.file_id, ?1 FROM files f WHERE f.file_id = ?2
+UNION ALL
+SELECT v.version_id, ?1 FROM files_versions v WHERE v.file_id = ?2
+ON CONFLICT (blob_id) DO NOTHING
 `
 
-type DeleteFileByIDParams struct {
-	FileID  string
-	OwnerID string
+type CreateFileBlobDeletionsParams struct {
+	CreatedAt int64
+	FileID    string
 }
 
-func (q *Queries) DeleteFileByID(ctx context.Context, arg DeleteFileByIDParams) error {
-	_, err := q.db.ExecContext(ctx, deleteFileByID, arg.FileID, arg.OwnerID)
+func (q *Queries) CreateFileBlobDeletions(ctx context.Context, arg CreateFileBlobDeletionsParams) error {
+	_, err := q.db.ExecContext(ctx, createFileBlobDeletions, arg.CreatedAt, arg.FileID)
 	return err
 }
 
-const deleteFilePermissionByFileID = `-- name: DeleteFilePermissionByFileID :exec
-DELETE FROM files_permissions WHERE file_id = ?
+const createFileComment = `-- name: CreateFileComment :exec
+INSERT INTO files_comments (comment_id, file_id, parent_id, content, created_at, created_by)
+VALUES (?, ?, ?, ?, ?, ?)
 `
 
-func (q *Queries) DeleteFilePermissionByFileID(ctx context.Context, fileID string) error {
-	_, err := q.db.ExecContext(ctx, deleteFilePermissionByFileID, fileID)
+type CreateFileCommentParams struct {
+	CommentID string
+	FileID    string
+	ParentID  sql.NullString
+	Content   string
+	CreatedAt int64
+	CreatedBy string
+}
+
+func (q *Queries) CreateFileComment(ctx context.Context, arg CreateFileCommentParams) error {
+	_, err := q.db.ExecContext(ctx, createFileComment,
+		arg.CommentID,
+		arg.FileID,
+		arg.ParentID,
+		arg.Content,
+		arg.CreatedAt,
+		arg.CreatedBy,
+	)
 	return err
 }
 
-const findAllFiles = `-- name: FindAllFiles :many
-SELECT f.file_id, f.file_name, f.size, f.is_secret, f.owner_id, f.created_at, f.updated_at, f.created_by, f.updated_by, COUNT() OVER() AS totalCount
-FROM files f
-LEFT JOIN files_permissions fp ON f.file_id = fp.file_id
-WHERE (f.owner_id = ?1 OR fp.user_id = ?1)
-AND f.file_name LIKE ?2
-AND f.is_secret = ?3
-ORDER BY f.created_at DESC
-LIMIT ?4
-OFFSET ?5
+const createFileGroupPermission = `-- name: CreateFileGroupPermission :exec
+INSERT INTO files_permissions (permission_id, file_id, permission, group_name)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (file_id, group_name) DO UPDATE SET permission = excluded.permission
 `
 
-type FindAllFilesParams struct {
-	OwnerID  string
-	FileName string
-	IsSecret bool
-	Limit    int64
-	Offset   int64
+type CreateFileGroupPermissionParams struct {
+	PermissionID string
+	FileID       string
+	Permission   string
+	GroupName    sql.NullString
 }
 
-type FindAllFilesRow struct {
-	FileID     string
-	FileName   string
-	Size       int64
-	IsSecret   bool
-	OwnerID    string
-	CreatedAt  int64
-	UpdatedAt  sql.NullInt64
-	CreatedBy  string
-	UpdatedBy  sql.NullString
-	Totalcount int64
+func (q *Queries) CreateFileGroupPermission(ctx context.Context, arg CreateFileGroupPermissionParams) error {
+	_, err := q.db.ExecContext(ctx, createFileGroupPermission,
+		arg.PermissionID,
+		arg.FileID,
+		arg.Permission,
+		arg.GroupName,
+	)
+	return err
 }
 
-func (q *Queries) FindAllFiles(ctx context.Context, arg FindAllFilesParams) ([]FindAllFilesRow, error) {
-	rows, err := q.db.QueryContext(ctx, findAllFiles,
+const createFileLink = `-- name: CreateFileLink :exec
+INSERT INTO files_links (token, file_id, password_hash, expires_at, max_downloads, created_at, created_by)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateFileLinkParams struct {
+	Token        string
+	FileID       string
+	PasswordHash sql.NullString
+	ExpiresAt    sql.NullInt64
+	MaxDownloads sql.NullInt64
+	CreatedAt    int64
+	CreatedBy    string
+}
+
+func (q *Queries) CreateFileLink(ctx context.Context, arg CreateFileLinkParams) error {
+	_, err := q.db.ExecContext(ctx, createFileLink,
+		arg.Token,
+		arg.FileID,
+		arg.PasswordHash,
+		arg.ExpiresAt,
+		arg.MaxDownloads,
+		arg.CreatedAt,
+		arg.CreatedBy,
+	)
+	return err
+}
+
+const createFileLock = `-- name: CreateFileLock :execrows
+INSERT INTO files_locks (file_id, locked_by, locked_at, expires_at)
+VALUES (?1, ?2, ?3, ?4)
+ON CONFLICT (file_id) DO UPDATE SET
+    locked_by = excluded.locked_by,
+    locked_at = excluded.locked_at,
+    expires_at = excluded.expires_at
+WHERE files_locks.locked_by = excluded.locked_by OR files_locks.expires_at <= excluded.locked_at
+`
+
+type CreateFileLockParams struct {
+	FileID    string
+	LockedBy  string
+	LockedAt  int64
+	ExpiresAt int64
+}
+
+func (q *Queries) CreateFileLock(ctx context.Context, arg CreateFileLockParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createFileLock,
+		arg.FileID,
+		arg.LockedBy,
+		arg.LockedAt,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const createFileMetadata = `-- name: CreateFileMetadata :exec
+INSERT INTO files_metadata (file_id, key, value) VALUES (?, ?, ?)
+`
+
+type CreateFileMetadataParams struct {
+	FileID string
+	Key    string
+	Value  string
+}
+
+func (q *Queries) CreateFileMetadata(ctx context.Context, arg CreateFileMetadataParams) error {
+	_, err := q.db.ExecContext(ctx, createFileMetadata, arg.FileID, arg.Key, arg.Value)
+	return err
+}
+
+const createFilePermission = `-- name: CreateFilePermission :exec
+INSERT INTO files_permissions (permission_id, file_id, permission, user_id)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (file_id, user_id) DO UPDATE SET permission = excluded.permission
+`
+
+type CreateFilePermissionParams struct {
+	PermissionID string
+	FileID       string
+	Permission   string
+	UserID       sql.NullString
+}
+
+func (q *Queries) CreateFilePermission(ctx context.Context, arg CreateFilePermissionParams) error {
+	_, err := q.db.ExecContext(ctx, createFilePermission,
+		arg.PermissionID,
+		arg.FileID,
+		arg.Permission,
+		arg.UserID,
+	)
+	return err
+}
+
+const createFileStar = `-- name: CreateFileStar :exec
+INSERT INTO files_stars (file_id, user_id, created_at) VALUES (?, ?, ?)
+ON CONFLICT (file_id, user_id) DO NOTHING
+`
+
+type CreateFileStarParams struct {
+	FileID    string
+	UserID    string
+	CreatedAt int64
+}
+
+func (q *Queries) CreateFileStar(ctx context.Context, arg CreateFileStarParams) error {
+	_, err := q.db.ExecContext(ctx, createFileStar, arg.FileID, arg.UserID, arg.CreatedAt)
+	return err
+}
+
+const createFileTag = `-- name: CreateFileTag :exec
+INSERT INTO files_tags (file_id, tag_id, created_at, created_by)
+SELECT ?1, t.tag_id, ?2, ?3
+FROM tags t
+WHERE t.name = ?4
+ON CONFLICT (file_id, tag_id) DO NOTHING
+`
+
+type CreateFileTagParams struct {
+	FileID    string
+	CreatedAt int64
+	CreatedBy string
+	Name      string
+}
+
+func (q *Queries) CreateFileTag(ctx context.Context, arg CreateFileTagParams) error {
+	_, err := q.db.ExecContext(ctx, createFileTag,
+		arg.FileID,
+		arg.CreatedAt,
+		arg.CreatedBy,
+		arg.Name,
+	)
+	return err
+}
+
+const createFileVersion = `-- name: CreateFileVersion :one
+INSERT INTO files_versions (version_id, file_id, version, size, created_at, created_by, checksum)
+SELECT ?1, ?2, COALESCE(MAX(version), 0) + 1, ?3, ?4, ?5, ?6
+FROM files_versions
+WHERE file_id = ?2
+RETURNING version
+`
+
+type CreateFileVersionParams struct {
+	VersionID string
+	FileID    string
+	Size      int64
+	CreatedAt int64
+	CreatedBy string
+	Checksum  sql.NullString
+}
+
+func (q *Queries) CreateFileVersion(ctx context.Context, arg CreateFileVersionParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createFileVersion,
+		arg.VersionID,
+		arg.FileID,
+		arg.Size,
+		arg.CreatedAt,
+		arg.CreatedBy,
+		arg.Checksum,
+	)
+	var version int64
+	err := row.Scan(&version)
+	return version, err
+}
+
+const createFolder = `-- name: CreateFolder :exec
+INSERT INTO folders (folder_id, name, parent_id, owner_id, created_at, created_by)
+VALUES (?, ?, ?, ?, ?, ?)
+`
+
+type CreateFolderParams struct {
+	FolderID  string
+	Name      string
+	ParentID  sql.NullString
+	OwnerID   string
+	CreatedAt int64
+	CreatedBy string
+}
+
+func (q *Queries) CreateFolder(ctx context.Context, arg CreateFolderParams) error {
+	_, err := q.db.ExecContext(ctx, createFolder,
+		arg.FolderID,
+		arg.Name,
+		arg.ParentID,
 		arg.OwnerID,
-		arg.FileName,
-		arg.IsSecret,
-		arg.Limit,
-		arg.Offset,
+		arg.CreatedAt,
+		arg.CreatedBy,
+	)
+	return err
+}
+
+const createNumberedFileVersion = `-- name: CreateNumberedFileVersion :exec
+INSERT INTO files_versions (version_id, file_id, version, size, created_at, created_by, checksum)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+`
+
+type CreateNumberedFileVersionParams struct {
+	VersionID string
+	FileID    string
+	Version   int64
+	Size      int64
+	CreatedAt int64
+	CreatedBy string
+	Checksum  sql.NullString
+}
+
+func (q *Queries) CreateNumberedFileVersion(ctx context.Context, arg CreateNumberedFileVersionParams) error {
+	_, err := q.db.ExecContext(ctx, createNumberedFileVersion,
+		arg.VersionID,
+		arg.FileID,
+		arg.Version,
+		arg.Size,
+		arg.CreatedAt,
+		arg.CreatedBy,
+		arg.Checksum,
+	)
+	return err
+}
+
+const createOwnerEditorPermissions = `-- name: CreateOwnerEditorPermissions :exec
+INSERT INTO files_permissions (permission_id, file_id, permission, user_id)
+SELECT lower(hex(randomblob(16))), file_id, 'EDITOR', owner_id
+FROM files
+WHERE owner_id = ? AND is_secret = FALSE
+ON CONFLICT (file_id, user_id) DO NOTHING
+`
+
+func (q *Queries) CreateOwnerEditorPermissions(ctx context.Context, ownerID string) error {
+	_, err := q.db.ExecContext(ctx, createOwnerEditorPermissions, ownerID)
+	return err
+}
+
+const createTag = `-- name: CreateTag :exec
+INSERT INTO tags (tag_id, name) VALUES (?, ?)
+ON CONFLICT (name) DO NOTHING
+`
+
+type CreateTagParams struct {
+	TagID string
+	Name  string
+}
+
+func (q *Queries) CreateTag(ctx context.Context, arg CreateTagParams) error {
+	_, err := q.db.ExecContext(ctx, createTag, arg.TagID, arg.Name)
+	return err
+}
+
+const createUploadReservation = `-- name: CreateUploadReservation :execrows
+INSERT INTO upload_reservations (reservation_id, user_id, size, expires_at)
+SELECT ?1, ?2, ?3, ?4
+WHERE (
+    SELECT COALESCE(SUM(f.size), 0) FROM files f WHERE f.owner_id = ?2
+) + (
+    SELECT COALESCE(SUM(v.size), 0)
+    FROM files_versions v
+    INNER JOIN files fv ON v.file_id = fv.file_id
+    WHERE fv.owner_id = ?2
+) + (
+    SELECT COALESCE(SUM(r.size), 0) FROM upload_reservations r WHERE r.user_id = ?2 AND r.expires_at > ?5
+) + ?3 <= ?6
+`
+
+type CreateUploadReservationParams struct {
+	ReservationID string
+	UserID        string
+	Size          int64
+	ExpiresAt     int64
+	Now           int64
+	Quota         int64
+}
+
+func (q *Queries) CreateUploadReservation(ctx context.Context, arg CreateUploadReservationParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createUploadReservation,
+		arg.ReservationID,
+		arg.UserID,
+		arg.Size,
+		arg.ExpiresAt,
+		arg.Now,
+		arg.Quota,
 	)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	var items []FindAllFilesRow
-	for rows.Next() {
-		var i FindAllFilesRow
-		if err := rows.Scan(
-			&i.FileID,
-			&i.FileName,
-			&i.Size,
-			&i.IsSecret,
-			&i.OwnerID,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.CreatedBy,
-			&i.UpdatedBy,
-			&i.Totalcount,
-		); err != nil {
+	return result.RowsAffected()
+}
+
+const deleteAccessToken = `-- name: DeleteAccessToken :execrows
+DELETE FROM access_tokens WHERE token_id = ? AND user_id = ?
+`
+
+type DeleteAccessTokenParams struct {
+	TokenID string
+	UserID  string
+}
+
+func (q *Queries) DeleteAccessToken(ctx context.Context, arg DeleteAccessTokenParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteAccessToken, arg.TokenID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteBlobDeletion = `-- name: DeleteBlobDeletion :exec
+DELETE FROM blob_deletions WHERE blob_id = ?
+`
+
+func (q *Queries) DeleteBlobDeletion(ctx context.Context, blobID string) error {
+	_, err := q.db.ExecContext(ctx, deleteBlobDeletion, blobID)
+	return err
+}
+
+const deleteBlobRef = `-- name: DeleteBlobRef :exec
+DELETE FROM blobs_refs WHERE blob_id = ?
+`
+
+func (q *Queries) DeleteBlobRef(ctx context.Context, blobID string) error {
+	_, err := q.db.ExecContext(ctx, deleteBlobRef, blobID)
+	return err
+}
+
+const deleteExpiredUploadReservations = `-- name: DeleteExpiredUploadReservations :exec
+DELETE FROM upload_reservations WHERE expires_at <= ?
+`
+
+func (q *Queries) DeleteExpiredUploadReservations(ctx context.Context, expiresAt int64) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredUploadReservations, expiresAt)
+	return err
+}
+
+const deleteFileByID = `-- name: DeleteFileByID :exec
+DELETE FROM files WHERE file_id = ?
+`
+
+func (q *Queries) DeleteFileByID(ctx context.Context, fileID string) error {
+	_, err := q.db.ExecContext(ctx, deleteFileByID, fileID)
+	return err
+}
+
+const deleteFileCommentsByFileID = `-- name: DeleteFileCommentsByFileID :exec
+DELETE FROM files_comments WHERE file_id = ?
+`
+
+func (q *Queries) DeleteFileCommentsByFileID(ctx context.Context, fileID string) error {
+	_, err := q.db.ExecContext(ctx, deleteFileCommentsByFileID, fileID)
+	return err
+}
+
+const deleteFileCommentThread = `-- name: DeleteFileCommentThread :exec
+WITH RECURSIVE thread(comment_id) AS (
+    SELECT ?1
+    UNION ALL
+    SELECT c.comment_id FROM files_comments c JOIN thread t ON c.parent_id = t.comment_id
+)
+DELETE FROM files_comments WHERE comment_id IN (SELECT comment_id FROM thread)
+`
+
+func (q *Queries) DeleteFileCommentThread(ctx context.Context, commentID interface{}) error {
+	_, err := q.db.ExecContext(ctx, deleteFileCommentThread, commentID)
+	return err
+}
+
+const deleteFileLink = `-- name: DeleteFileLink :exec
+DELETE FROM files_links WHERE file_id = ? AND token = ?
+`
+
+type DeleteFileLinkParams struct {
+	FileID string
+	Token  string
+}
+
+func (q *Queries) DeleteFileLink(ctx context.Context, arg DeleteFileLinkParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFileLink, arg.FileID, arg.Token)
+	return err
+}
+
+const deleteFileLinksByFileID = `-- name: DeleteFileLinksByFileID :exec
+DELETE FROM files_links WHERE file_id = ?
+`
+
+func (q *Queries) DeleteFileLinksByFileID(ctx context.Context, fileID string) error {
+	_, err := q.db.ExecContext(ctx, deleteFileLinksByFileID, fileID)
+	return err
+}
+
+const deleteFileLock = `-- name: DeleteFileLock :exec
+DELETE FROM files_locks WHERE file_id = ?
+`
+
+func (q *Queries) DeleteFileLock(ctx context.Context, fileID string) error {
+	_, err := q.db.ExecContext(ctx, deleteFileLock, fileID)
+	return err
+}
+
+const deleteFileMetadataByFileID = `-- name: DeleteFileMetadataByFileID :exec
+DELETE FROM files_metadata WHERE file_id = ?
+`
+
+func (q *Queries) DeleteFileMetadataByFileID(ctx context.Context, fileID string) error {
+	_, err := q.db.ExecContext(ctx, deleteFileMetadataByFileID, fileID)
+	return err
+}
+
+const deleteFilePermissionByFileID = `-- name: DeleteFilePermissionByFileID :exec
+DELETE FROM files_permissions WHERE file_id = ?
+`
+
+func (q *Queries) DeleteFilePermissionByFileID(ctx context.Context, fileID string) error {
+	_, err := q.db.ExecContext(ctx, deleteFilePermissionByFileID, fileID)
+	return err
+}
+
+const deleteFilePermissionByFileIDAndGroupName = `-- name: DeleteFilePermissionByFileIDAndGroupName :exec
+DELETE FROM files_permissions WHERE file_id = ? AND group_name = ?
+`
+
+type DeleteFilePermissionByFileIDAndGroupNameParams struct {
+	FileID    string
+	GroupName sql.NullString
+}
+
+func (q *Queries) DeleteFilePermissionByFileIDAndGroupName(ctx context.Context, arg DeleteFilePermissionByFileIDAndGroupNameParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFilePermissionByFileIDAndGroupName, arg.FileID, arg.GroupName)
+	return err
+}
+
+const deleteFilePermissionByFileIDAndUserID = `-- name: DeleteFilePermissionByFileIDAndUserID :exec
+DELETE FROM files_permissions WHERE file_id = ? AND user_id = ?
+`
+
+type DeleteFilePermissionByFileIDAndUserIDParams struct {
+	FileID string
+	UserID sql.NullString
+}
+
+func (q *Queries) DeleteFilePermissionByFileIDAndUserID(ctx context.Context, arg DeleteFilePermissionByFileIDAndUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFilePermissionByFileIDAndUserID, arg.FileID, arg.UserID)
+	return err
+}
+
+const deleteFilePermissionsByOwnerIDAndUserID = `-- name: DeleteFilePermissionsByOwnerIDAndUserID :exec
+DELETE FROM files_permissions
+WHERE user_id = ?
+AND file_id IN (SELECT file_id FROM files WHERE owner_id = ?)
+`
+
+type DeleteFilePermissionsByOwnerIDAndUserIDParams struct {
+	UserID  sql.NullString
+	OwnerID string
+}
+
+func (q *Queries) DeleteFilePermissionsByOwnerIDAndUserID(ctx context.Context, arg DeleteFilePermissionsByOwnerIDAndUserIDParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFilePermissionsByOwnerIDAndUserID, arg.UserID, arg.OwnerID)
+	return err
+}
+
+const deleteFileStar = `-- name: DeleteFileStar :exec
+DELETE FROM files_stars WHERE file_id = ? AND user_id = ?
+`
+
+type DeleteFileStarParams struct {
+	FileID string
+	UserID string
+}
+
+func (q *Queries) DeleteFileStar(ctx context.Context, arg DeleteFileStarParams) error {
+	_, err := q.db.ExecContext(ctx, deleteFileStar, arg.FileID, arg.UserID)
+	return err
+}
+
+const deleteFileStarsByFileID = `-- name: DeleteFileStarsByFileID :exec
+DELETE FROM files_stars WHERE file_id = ?
+`
+
+func (q *Queries) DeleteFileStarsByFileID(ctx context.Context, fileID string) error {
+	_, err := q.db.ExecContext(ctx, deleteFileStarsByFileID, fileID)
+	return err
+}
+
+const deleteFileTag = `-- name: DeleteFileTag :execrows
+DELETE FROM files_tags
+WHERE file_id = ?1
+AND tag_id = (SELECT tag_id FROM tags WHERE name = ?2)
+`
+
+type DeleteFileTagParams struct {
+	FileID string
+	Name   string
+}
+
+func (q *Queries) DeleteFileTag(ctx context.Context, arg DeleteFileTagParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteFileTag, arg.FileID, arg.Name)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteFileTagsByFileID = `-- name: DeleteFileTagsByFileID :exec
+DELETE FROM files_tags WHERE file_id = ?
+`
+
+func (q *Queries) DeleteFileTagsByFileID(ctx context.Context, fileID string) error {
+	_, err := q.db.ExecContext(ctx, deleteFileTagsByFileID, fileID)
+	return err
+}
+
+const deleteFileVersionByID = `-- name: DeleteFileVersionByID :exec
+DELETE FROM files_versions WHERE version_id = ?
+`
+
+func (q *Queries) DeleteFileVersionByID(ctx context.Context, versionID string) error {
+	_, err := q.db.ExecContext(ctx, deleteFileVersionByID, versionID)
+	return err
+}
+
+const deleteFileVersionsByFileID = `-- name: DeleteFileVersionsByFileID :exec
+DELETE FROM files_versions WHERE file_id = ?
+`
+
+func (q *Queries) DeleteFileVersionsByFileID(ctx context.Context, fileID string) error {
+	_, err := q.db.ExecContext(ctx, deleteFileVersionsByFileID, fileID)
+	return err
+}
+
+const deleteFolderByID = `-- name: DeleteFolderByID :execrows
+DELETE FROM folders WHERE folder_id = ? AND owner_id = ?
+`
+
+type DeleteFolderByIDParams struct {
+	FolderID string
+	OwnerID  string
+}
+
+func (q *Queries) DeleteFolderByID(ctx context.Context, arg DeleteFolderByIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteFolderByID, arg.FolderID, arg.OwnerID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deleteUnreferencedBlob = `-- name: DeleteUnreferencedBlob :exec
+DELETE FROM blobs WHERE hash = ? AND ref_count <= 0
+`
+
+func (q *Queries) DeleteUnreferencedBlob(ctx context.Context, hash string) error {
+	_, err := q.db.ExecContext(ctx, deleteUnreferencedBlob, hash)
+	return err
+}
+
+const deleteUploadReservation = `-- name: DeleteUploadReservation :exec
+DELETE FROM upload_reservations WHERE reservation_id = ?
+`
+
+func (q *Queries) DeleteUploadReservation(ctx context.Context, reservationID string) error {
+	_, err := q.db.ExecContext(ctx, deleteUploadReservation, reservationID)
+	return err
+}
+
+const findAccessTokenByHash = `-- name: FindAccessTokenByHash :one
+SELECT token_id, user_id, name, token_hash, scope, groups, expires_at, created_at, last_used_at FROM access_tokens WHERE token_hash = ?
+`
+
+func (q *Queries) FindAccessTokenByHash(ctx context.Context, tokenHash string) (AccessToken, error) {
+	row := q.db.QueryRowContext(ctx, findAccessTokenByHash, tokenHash)
+	var i AccessToken
+	err := row.Scan(
+		&i.TokenID,
+		&i.UserID,
+		&i.Name,
+		&i.TokenHash,
+		&i.Scope,
+		&i.Groups,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+	)
+	return i, err
+}
+
+const findAccessTokensByUserID = `-- name: FindAccessTokensByUserID :many
+SELECT token_id, user_id, name, token_hash, scope, groups, expires_at, created_at, last_used_at FROM access_tokens WHERE user_id = ? ORDER BY created_at DESC
+`
+
+func (q *Queries) FindAccessTokensByUserID(ctx context.Context, userID string) ([]AccessToken, error) {
+	rows, err := q.db.QueryContext(ctx, findAccessTokensByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AccessToken
+	for rows.Next() {
+		var i AccessToken
+		if err := rows.Scan(
+			&i.TokenID,
+			&i.UserID,
+			&i.Name,
+			&i.TokenHash,
+			&i.Scope,
+			&i.Groups,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findActiveUploadReservations = `-- name: FindActiveUploadReservations :many
+SELECT reservation_id, user_id, size, expires_at FROM upload_reservations WHERE expires_at > ?
+`
+
+func (q *Queries) FindActiveUploadReservations(ctx context.Context, expiresAt int64) ([]UploadReservation, error) {
+	rows, err := q.db.QueryContext(ctx, findActiveUploadReservations, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []UploadReservation
+	for rows.Next() {
+		var i UploadReservation
+		if err := rows.Scan(
+			&i.ReservationID,
+			&i.UserID,
+			&i.Size,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllAdminAudits = `-- name: FindAllAdminAudits :many
+SELECT audit_id, user_id, action, target_user_id, target_file_id, status, trace_id, created_at, COUNT() OVER() AS totalCount
+FROM admin_audit
+ORDER BY created_at DESC
+LIMIT ?
+OFFSET ?
+`
+
+type FindAllAdminAuditsParams struct {
+	Limit  int64
+	Offset int64
+}
+
+type FindAllAdminAuditsRow struct {
+	AuditID      string
+	UserID       string
+	Action       string
+	TargetUserID sql.NullString
+	TargetFileID sql.NullString
+	Status       int64
+	TraceID      string
+	CreatedAt    int64
+	Totalcount   int64
+}
+
+func (q *Queries) FindAllAdminAudits(ctx context.Context, arg FindAllAdminAuditsParams) ([]FindAllAdminAuditsRow, error) {
+	rows, err := q.db.QueryContext(ctx, findAllAdminAudits, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAllAdminAuditsRow
+	for rows.Next() {
+		var i FindAllAdminAuditsRow
+		if err := rows.Scan(
+			&i.AuditID,
+			&i.UserID,
+			&i.Action,
+			&i.TargetUserID,
+			&i.TargetFileID,
+			&i.Status,
+			&i.TraceID,
+			&i.CreatedAt,
+			&i.Totalcount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllBlobDeletions = `-- name: FindAllBlobDeletions :many
+SELECT blob_id, attempts, last_error, created_at FROM blob_deletions ORDER BY created_at
+`
+
+func (q *Queries) FindAllBlobDeletions(ctx context.Context) ([]BlobDeletion, error) {
+	rows, err := q.db.QueryContext(ctx, findAllBlobDeletions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BlobDeletion
+	for rows.Next() {
+		var i BlobDeletion
+		if err := rows.Scan(
+			&i.BlobID,
+			&i.Attempts,
+			&i.LastError,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllBlobRefs = `-- name: FindAllBlobRefs :many
+SELECT r.blob_id, r.hash, b.size, r.created_at FROM blobs_refs r JOIN blobs b ON b.hash = r.hash ORDER BY r.blob_id
+`
+
+type FindAllBlobRefsRow struct {
+	BlobID    string
+	Hash      string
+	Size      int64
+	CreatedAt int64
+}
+
+func (q *Queries) FindAllBlobRefs(ctx context.Context) ([]FindAllBlobRefsRow, error) {
+	rows, err := q.db.QueryContext(ctx, findAllBlobRefs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAllBlobRefsRow
+	for rows.Next() {
+		var i FindAllBlobRefsRow
+		if err := rows.Scan(
+			&i.BlobID,
+			&i.Hash,
+			&i.Size,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllFiles = `-- name: FindAllFiles :many
+SELECT f.file_id, f.file_name, f.size, f.is_secret, f.owner_id, f.created_at, f.updated_at, f.created_by, f.updated_by, f.parent_id, f.deleted_at, f.deleted_by, f.checksum, f.corrupted_at, CAST(EXISTS (
+    SELECT 1 FROM files_stars fs WHERE fs.file_id = f.file_id AND fs.user_id = ?1
+) AS BOOLEAN) AS starred, COUNT() OVER() AS totalCount
+FROM files f
+WHERE (f.owner_id = ?1 OR EXISTS (
+    SELECT 1
+    FROM files_permissions fp
+    WHERE fp.file_id = f.file_id AND (
+        fp.user_id = ?1 OR
+        fp.group_name IN (SELECT value FROM json_each(?2))
+    )
+))
+AND f.file_name LIKE ?3
+AND f.is_secret = ?4
+AND f.deleted_at IS NULL
+AND (
+    CAST(?5 AS TEXT) = '' OR
+    (?5 = 'root' AND f.parent_id IS NULL) OR
+    f.parent_id = ?5
+)
+AND (
+    CAST(?6 AS TEXT) = '' OR EXISTS (
+        SELECT 1
+        FROM files_tags ft
+        JOIN tags t ON t.tag_id = ft.tag_id
+        WHERE ft.file_id = f.file_id AND t.name = ?6
+    )
+)
+AND NOT EXISTS (
+    SELECT 1
+    FROM json_each(?7) m
+    WHERE NOT EXISTS (
+        SELECT 1
+        FROM files_metadata fm
+        WHERE fm.file_id = f.file_id AND fm.key = m.key AND fm.value = m.value
+    )
+)
+AND (
+    CAST(?8 AS BOOLEAN) = FALSE OR EXISTS (
+        SELECT 1 FROM files_stars fs WHERE fs.file_id = f.file_id AND fs.user_id = ?1
+    )
+)
+ORDER BY f.created_at DESC
+LIMIT ?9
+OFFSET ?10
+`
+
+type FindAllFilesParams struct {
+	OwnerID  string
+	Groups   interface{}
+	FileName string
+	IsSecret bool
+	FolderID string
+	Tag      string
+	Metadata interface{}
+	Starred  bool
+	Limit    int64
+	Offset   int64
+}
+
+type FindAllFilesRow struct {
+	FileID      string
+	FileName    string
+	Size        int64
+	IsSecret    bool
+	OwnerID     string
+	CreatedAt   int64
+	UpdatedAt   sql.NullInt64
+	CreatedBy   string
+	UpdatedBy   sql.NullString
+	ParentID    sql.NullString
+	DeletedAt   sql.NullInt64
+	DeletedBy   sql.NullString
+	Checksum    sql.NullString
+	CorruptedAt sql.NullInt64
+	Starred     bool
+	Totalcount  int64
+}
+
+func (q *Queries) FindAllFiles(ctx context.Context, arg FindAllFilesParams) ([]FindAllFilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, findAllFiles,
+		arg.OwnerID,
+		arg.Groups,
+		arg.FileName,
+		arg.IsSecret,
+		arg.FolderID,
+		arg.Tag,
+		arg.Metadata,
+		arg.Starred,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAllFilesRow
+	for rows.Next() {
+		var i FindAllFilesRow
+		if err := rows.Scan(
+			&i.FileID,
+			&i.FileName,
+			&i.Size,
+			&i.IsSecret,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.ParentID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.Checksum,
+			&i.CorruptedAt,
+			&i.Starred,
+			&i.Totalcount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllFilesByOwnerID = `-- name: FindAllFilesByOwnerID :many
+SELECT f.file_id, f.file_name, f.size, f.is_secret, f.owner_id, f.created_at, f.updated_at, f.created_by, f.updated_by, f.parent_id, f.deleted_at, f.deleted_by, f.checksum, f.corrupted_at, COUNT() OVER() AS totalCount
+FROM files f
+WHERE f.owner_id = ?
+AND f.file_name LIKE ?
+ORDER BY f.created_at DESC
+LIMIT ?
+OFFSET ?
+`
+
+type FindAllFilesByOwnerIDParams struct {
+	OwnerID  string
+	FileName string
+	Limit    int64
+	Offset   int64
+}
+
+type FindAllFilesByOwnerIDRow struct {
+	FileID      string
+	FileName    string
+	Size        int64
+	IsSecret    bool
+	OwnerID     string
+	CreatedAt   int64
+	UpdatedAt   sql.NullInt64
+	CreatedBy   string
+	UpdatedBy   sql.NullString
+	ParentID    sql.NullString
+	DeletedAt   sql.NullInt64
+	DeletedBy   sql.NullString
+	Checksum    sql.NullString
+	CorruptedAt sql.NullInt64
+	Totalcount  int64
+}
+
+func (q *Queries) FindAllFilesByOwnerID(ctx context.Context, arg FindAllFilesByOwnerIDParams) ([]FindAllFilesByOwnerIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, findAllFilesByOwnerID,
+		arg.OwnerID,
+		arg.FileName,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAllFilesByOwnerIDRow
+	for rows.Next() {
+		var i FindAllFilesByOwnerIDRow
+		if err := rows.Scan(
+			&i.FileID,
+			&i.FileName,
+			&i.Size,
+			&i.IsSecret,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.ParentID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.Checksum,
+			&i.CorruptedAt,
+			&i.Totalcount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllFileSizes = `-- name: FindAllFileSizes :many
+SELECT file_id, size, checksum, created_at, updated_at FROM files
+`
+
+type FindAllFileSizesRow struct {
+	FileID    string
+	Size      int64
+	Checksum  sql.NullString
+	CreatedAt int64
+	UpdatedAt sql.NullInt64
+}
+
+func (q *Queries) FindAllFileSizes(ctx context.Context) ([]FindAllFileSizesRow, error) {
+	rows, err := q.db.QueryContext(ctx, findAllFileSizes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAllFileSizesRow
+	for rows.Next() {
+		var i FindAllFileSizesRow
+		if err := rows.Scan(
+			&i.FileID,
+			&i.Size,
+			&i.Checksum,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllFileVersionSizes = `-- name: FindAllFileVersionSizes :many
+SELECT version_id, size, created_at FROM files_versions
+`
+
+type FindAllFileVersionSizesRow struct {
+	VersionID string
+	Size      int64
+	CreatedAt int64
+}
+
+func (q *Queries) FindAllFileVersionSizes(ctx context.Context) ([]FindAllFileVersionSizesRow, error) {
+	rows, err := q.db.QueryContext(ctx, findAllFileVersionSizes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAllFileVersionSizesRow
+	for rows.Next() {
+		var i FindAllFileVersionSizesRow
+		if err := rows.Scan(&i.VersionID, &i.Size, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllSharedFiles = `-- name: FindAllSharedFiles :many
+SELECT f.file_id, f.file_name, f.size, f.is_secret, f.owner_id, f.created_at, f.updated_at, f.created_by, f.updated_by, f.parent_id, f.deleted_at, f.deleted_by, f.checksum, f.corrupted_at, CAST(MIN(fp.permission) AS TEXT) AS permission, CAST(EXISTS (
+    SELECT 1 FROM files_stars fs WHERE fs.file_id = f.file_id AND fs.user_id = ?1
+) AS BOOLEAN) AS starred, COUNT() OVER() AS totalCount
+FROM files f
+INNER JOIN files_permissions fp ON f.file_id = fp.file_id
+WHERE (
+    fp.user_id = ?1 OR
+    fp.group_name IN (SELECT value FROM json_each(?2))
+)
+AND f.owner_id != ?1
+AND f.is_secret = FALSE
+AND f.deleted_at IS NULL
+GROUP BY f.file_id
+ORDER BY f.created_at DESC
+LIMIT ?3
+OFFSET ?4
+`
+
+type FindAllSharedFilesParams struct {
+	UserID string
+	Groups interface{}
+	Limit  int64
+	Offset int64
+}
+
+type FindAllSharedFilesRow struct {
+	FileID      string
+	FileName    string
+	Size        int64
+	IsSecret    bool
+	OwnerID     string
+	CreatedAt   int64
+	UpdatedAt   sql.NullInt64
+	CreatedBy   string
+	UpdatedBy   sql.NullString
+	ParentID    sql.NullString
+	DeletedAt   sql.NullInt64
+	DeletedBy   sql.NullString
+	Checksum    sql.NullString
+	CorruptedAt sql.NullInt64
+	Permission  string
+	Starred     bool
+	Totalcount  int64
+}
+
+func (q *Queries) FindAllSharedFiles(ctx context.Context, arg FindAllSharedFilesParams) ([]FindAllSharedFilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, findAllSharedFiles,
+		arg.UserID,
+		arg.Groups,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAllSharedFilesRow
+	for rows.Next() {
+		var i FindAllSharedFilesRow
+		if err := rows.Scan(
+			&i.FileID,
+			&i.FileName,
+			&i.Size,
+			&i.IsSecret,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.ParentID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.Checksum,
+			&i.CorruptedAt,
+			&i.Permission,
+			&i.Starred,
+			&i.Totalcount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllTags = `-- name: FindAllTags :many
+SELECT t.name, COUNT(*) AS files
+FROM tags t
+JOIN files_tags ft ON ft.tag_id = t.tag_id
+JOIN files f ON f.file_id = ft.file_id
+WHERE (f.owner_id = ?1 OR EXISTS (
+    SELECT 1
+    FROM files_permissions fp
+    WHERE fp.file_id = f.file_id AND (
+        fp.user_id = ?1 OR
+        fp.group_name IN (SELECT value FROM json_each(?2))
+    )
+))
+AND f.deleted_at IS NULL
+GROUP BY t.name
+ORDER BY t.name
+`
+
+type FindAllTagsParams struct {
+	OwnerID string
+	Groups  interface{}
+}
+
+type FindAllTagsRow struct {
+	Name  string
+	Files int64
+}
+
+func (q *Queries) FindAllTags(ctx context.Context, arg FindAllTagsParams) ([]FindAllTagsRow, error) {
+	rows, err := q.db.QueryContext(ctx, findAllTags, arg.OwnerID, arg.Groups)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAllTagsRow
+	for rows.Next() {
+		var i FindAllTagsRow
+		if err := rows.Scan(&i.Name, &i.Files); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllTrashedFiles = `-- name: FindAllTrashedFiles :many
+SELECT f.file_id, f.file_name, f.size, f.is_secret, f.owner_id, f.created_at, f.updated_at, f.created_by, f.updated_by, f.parent_id, f.deleted_at, f.deleted_by, f.checksum, f.corrupted_at, COUNT() OVER() AS totalCount
+FROM files f
+WHERE f.owner_id = ?
+AND f.deleted_at IS NOT NULL
+ORDER BY f.deleted_at DESC
+LIMIT ?
+OFFSET ?
+`
+
+type FindAllTrashedFilesParams struct {
+	OwnerID string
+	Limit   int64
+	Offset  int64
+}
+
+type FindAllTrashedFilesRow struct {
+	FileID      string
+	FileName    string
+	Size        int64
+	IsSecret    bool
+	OwnerID     string
+	CreatedAt   int64
+	UpdatedAt   sql.NullInt64
+	CreatedBy   string
+	UpdatedBy   sql.NullString
+	ParentID    sql.NullString
+	DeletedAt   sql.NullInt64
+	DeletedBy   sql.NullString
+	Checksum    sql.NullString
+	CorruptedAt sql.NullInt64
+	Totalcount  int64
+}
+
+func (q *Queries) FindAllTrashedFiles(ctx context.Context, arg FindAllTrashedFilesParams) ([]FindAllTrashedFilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, findAllTrashedFiles, arg.OwnerID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAllTrashedFilesRow
+	for rows.Next() {
+		var i FindAllTrashedFilesRow
+		if err := rows.Scan(
+			&i.FileID,
+			&i.FileName,
+			&i.Size,
+			&i.IsSecret,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.ParentID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.Checksum,
+			&i.CorruptedAt,
+			&i.Totalcount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findAllUsages = `-- name: FindAllUsages :many
+SELECT owner_id, COUNT(*) AS files, CAST(SUM(size) + COALESCE((
+    SELECT SUM(v.size)
+    FROM files_versions v
+    INNER JOIN files fv ON v.file_id = fv.file_id
+    WHERE fv.owner_id = files.owner_id
+), 0) AS INTEGER) AS total_size, COUNT() OVER() AS totalCount
+FROM files
+GROUP BY owner_id
+ORDER BY total_size DESC
+LIMIT ?
+OFFSET ?
+`
+
+type FindAllUsagesParams struct {
+	Limit  int64
+	Offset int64
+}
+
+type FindAllUsagesRow struct {
+	OwnerID    string
+	Files      int64
+	TotalSize  int64
+	Totalcount int64
+}
+
+func (q *Queries) FindAllUsages(ctx context.Context, arg FindAllUsagesParams) ([]FindAllUsagesRow, error) {
+	rows, err := q.db.QueryContext(ctx, findAllUsages, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindAllUsagesRow
+	for rows.Next() {
+		var i FindAllUsagesRow
+		if err := rows.Scan(
+			&i.OwnerID,
+			&i.Files,
+			&i.TotalSize,
+			&i.Totalcount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findBlobRef = `-- name: FindBlobRef :one
+SELECT r.blob_id, r.hash, b.size, r.created_at FROM blobs_refs r JOIN blobs b ON b.hash = r.hash WHERE r.blob_id = ?
+`
+
+type FindBlobRefRow struct {
+	BlobID    string
+	Hash      string
+	Size      int64
+	CreatedAt int64
+}
+
+func (q *Queries) FindBlobRef(ctx context.Context, blobID string) (FindBlobRefRow, error) {
+	row := q.db.QueryRowContext(ctx, findBlobRef, blobID)
+	var i FindBlobRefRow
+	err := row.Scan(
+		&i.BlobID,
+		&i.Hash,
+		&i.Size,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const findChecksummedFiles = `-- name: FindChecksummedFiles :many
+SELECT file_id, checksum, corrupted_at FROM files
+WHERE checksum IS NOT NULL
+AND file_id > ?1
+ORDER BY file_id
+LIMIT ?2
+`
+
+type FindChecksummedFilesParams struct {
+	AfterFileID string
+	Limit       int64
+}
+
+type FindChecksummedFilesRow struct {
+	FileID      string
+	Checksum    sql.NullString
+	CorruptedAt sql.NullInt64
+}
+
+func (q *Queries) FindChecksummedFiles(ctx context.Context, arg FindChecksummedFilesParams) ([]FindChecksummedFilesRow, error) {
+	rows, err := q.db.QueryContext(ctx, findChecksummedFiles, arg.AfterFileID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindChecksummedFilesRow
+	for rows.Next() {
+		var i FindChecksummedFilesRow
+		if err := rows.Scan(&i.FileID, &i.Checksum, &i.CorruptedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findExpiredTrashedFileIDs = `-- name: FindExpiredTrashedFileIDs :many
+SELECT file_id FROM files
+WHERE deleted_at IS NOT NULL
+AND deleted_at < ?1
+ORDER BY deleted_at
+LIMIT ?2
+`
+
+type FindExpiredTrashedFileIDsParams struct {
+	DeletedBefore sql.NullInt64
+	Limit         int64
+}
+
+func (q *Queries) FindExpiredTrashedFileIDs(ctx context.Context, arg FindExpiredTrashedFileIDsParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findExpiredTrashedFileIDs, arg.DeletedBefore, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var file_id string
+		if err := rows.Scan(&file_id); err != nil {
+			return nil, err
+		}
+		items = append(items, file_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findFileByID = `-- name: FindFileByID :many
+SELECT f.file_id, f.file_name, f.size, f.is_secret, f.owner_id, f.created_at, f.updated_at, f.created_by, f.updated_by, f.parent_id, f.deleted_at, f.deleted_by, f.checksum, f.corrupted_at, fp.permission_id, fp.file_id, fp.permission, fp.user_id, fp.group_name
+FROM files f
+LEFT JOIN files_permissions fp ON f.file_id = fp.file_id
+WHERE f.file_id = ?1
+AND (
+    f.owner_id = ?2 OR (f.file_id IN (
+        SELECT ffp.file_id
+        FROM files_permissions ffp
+        WHERE ffp.file_id = f.file_id AND (
+            ffp.user_id = ?2 OR
+            ffp.group_name IN (SELECT value FROM json_each(?3))
+        )
+    ) AND f.is_secret = FALSE)
+)
+AND f.deleted_at IS NULL
+`
+
+type FindFileByIDParams struct {
+	FileID  string
+	OwnerID string
+	Groups  interface{}
+}
+
+type FindFileByIDRow struct {
+	FileID       string
+	FileName     string
+	Size         int64
+	IsSecret     bool
+	OwnerID      string
+	CreatedAt    int64
+	UpdatedAt    sql.NullInt64
+	CreatedBy    string
+	UpdatedBy    sql.NullString
+	ParentID     sql.NullString
+	DeletedAt    sql.NullInt64
+	DeletedBy    sql.NullString
+	Checksum     sql.NullString
+	CorruptedAt  sql.NullInt64
+	PermissionID sql.NullString
+	FileID_2     sql.NullString
+	Permission   sql.NullString
+	UserID       sql.NullString
+	GroupName    sql.NullString
+}
+
+func (q *Queries) FindFileByID(ctx context.Context, arg FindFileByIDParams) ([]FindFileByIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, findFileByID, arg.FileID, arg.OwnerID, arg.Groups)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindFileByIDRow
+	for rows.Next() {
+		var i FindFileByIDRow
+		if err := rows.Scan(
+			&i.FileID,
+			&i.FileName,
+			&i.Size,
+			&i.IsSecret,
+			&i.OwnerID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CreatedBy,
+			&i.UpdatedBy,
+			&i.ParentID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.Checksum,
+			&i.CorruptedAt,
+			&i.PermissionID,
+			&i.FileID_2,
+			&i.Permission,
+			&i.UserID,
+			&i.GroupName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findFileByName = `-- name: FindFileByName :one
+SELECT file_id, file_name, size, is_secret, owner_id, created_at, updated_at, created_by, updated_by, parent_id, deleted_at, deleted_by, checksum, corrupted_at FROM files
+WHERE owner_id = ?1
+AND file_name = ?2
+AND COALESCE(parent_id, '') = CAST(?3 AS TEXT)
+AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1
+`
+
+type FindFileByNameParams struct {
+	OwnerID  string
+	FileName string
+	ParentID string
+}
+
+func (q *Queries) FindFileByName(ctx context.Context, arg FindFileByNameParams) (File, error) {
+	row := q.db.QueryRowContext(ctx, findFileByName, arg.OwnerID, arg.FileName, arg.ParentID)
+	var i File
+	err := row.Scan(
+		&i.FileID,
+		&i.FileName,
+		&i.Size,
+		&i.IsSecret,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+		&i.ParentID,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.Checksum,
+		&i.CorruptedAt,
+	)
+	return i, err
+}
+
+const findFileComment = `-- name: FindFileComment :one
+SELECT comment_id, file_id, parent_id, content, created_at, created_by, updated_at FROM files_comments WHERE file_id = ? AND comment_id = ?
+`
+
+type FindFileCommentParams struct {
+	FileID    string
+	CommentID string
+}
+
+func (q *Queries) FindFileComment(ctx context.Context, arg FindFileCommentParams) (FilesComment, error) {
+	row := q.db.QueryRowContext(ctx, findFileComment, arg.FileID, arg.CommentID)
+	var i FilesComment
+	err := row.Scan(
+		&i.CommentID,
+		&i.FileID,
+		&i.ParentID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const findFileCommentsByFileID = `-- name: FindFileCommentsByFileID :many
+SELECT comment_id, file_id, parent_id, content, created_at, created_by, updated_at FROM files_comments WHERE file_id = ? ORDER BY created_at
+`
+
+func (q *Queries) FindFileCommentsByFileID(ctx context.Context, fileID string) ([]FilesComment, error) {
+	rows, err := q.db.QueryContext(ctx, findFileCommentsByFileID, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FilesComment
+	for rows.Next() {
+		var i FilesComment
+		if err := rows.Scan(
+			&i.CommentID,
+			&i.FileID,
+			&i.ParentID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findFileLinkByToken = `-- name: FindFileLinkByToken :one
+SELECT fl.token, fl.file_id, fl.password_hash, fl.expires_at, fl.max_downloads, fl.downloads, fl.created_at, fl.created_by, f.file_name, f.size
+FROM files_links fl
+INNER JOIN files f ON fl.file_id = f.file_id
+WHERE fl.token = ?
+AND f.deleted_at IS NULL
+AND f.is_secret = FALSE
+`
+
+type FindFileLinkByTokenRow struct {
+	Token        string
+	FileID       string
+	PasswordHash sql.NullString
+	ExpiresAt    sql.NullInt64
+	MaxDownloads sql.NullInt64
+	Downloads    int64
+	CreatedAt    int64
+	CreatedBy    string
+	FileName     string
+	Size         int64
+}
+
+func (q *Queries) FindFileLinkByToken(ctx context.Context, token string) (FindFileLinkByTokenRow, error) {
+	row := q.db.QueryRowContext(ctx, findFileLinkByToken, token)
+	var i FindFileLinkByTokenRow
+	err := row.Scan(
+		&i.Token,
+		&i.FileID,
+		&i.PasswordHash,
+		&i.ExpiresAt,
+		&i.MaxDownloads,
+		&i.Downloads,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.FileName,
+		&i.Size,
+	)
+	return i, err
+}
+
+const findFileLinksByFileID = `-- name: FindFileLinksByFileID :many
+SELECT token, file_id, password_hash, expires_at, max_downloads, downloads, created_at, created_by FROM files_links
+WHERE file_id = ?
+ORDER BY created_at DESC
+`
+
+func (q *Queries) FindFileLinksByFileID(ctx context.Context, fileID string) ([]FilesLink, error) {
+	rows, err := q.db.QueryContext(ctx, findFileLinksByFileID, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FilesLink
+	for rows.Next() {
+		var i FilesLink
+		if err := rows.Scan(
+			&i.Token,
+			&i.FileID,
+			&i.PasswordHash,
+			&i.ExpiresAt,
+			&i.MaxDownloads,
+			&i.Downloads,
+			&i.CreatedAt,
+			&i.CreatedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findFileLock = `-- name: FindFileLock :one
+SELECT file_id, locked_by, locked_at, expires_at FROM files_locks WHERE file_id = ? AND expires_at > ?
+`
+
+type FindFileLockParams struct {
+	FileID    string
+	ExpiresAt int64
+}
+
+func (q *Queries) FindFileLock(ctx context.Context, arg FindFileLockParams) (FilesLock, error) {
+	row := q.db.QueryRowContext(ctx, findFileLock, arg.FileID, arg.ExpiresAt)
+	var i FilesLock
+	err := row.Scan(
+		&i.FileID,
+		&i.LockedBy,
+		&i.LockedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const findFileMetadataByFileID = `-- name: FindFileMetadataByFileID :many
+SELECT file_id, key, value FROM files_metadata WHERE file_id = ? ORDER BY key
+`
+
+func (q *Queries) FindFileMetadataByFileID(ctx context.Context, fileID string) ([]FilesMetadatum, error) {
+	rows, err := q.db.QueryContext(ctx, findFileMetadataByFileID, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FilesMetadatum
+	for rows.Next() {
+		var i FilesMetadatum
+		if err := rows.Scan(&i.FileID, &i.Key, &i.Value); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findFileTagsByFileID = `-- name: FindFileTagsByFileID :many
+SELECT t.name
+FROM files_tags ft
+JOIN tags t ON t.tag_id = ft.tag_id
+WHERE ft.file_id = ?
+ORDER BY t.name
+`
+
+func (q *Queries) FindFileTagsByFileID(ctx context.Context, fileID string) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, findFileTagsByFileID, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		items = append(items, name)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findFileVersion = `-- name: FindFileVersion :one
+SELECT version_id, file_id, version, size, created_at, created_by, checksum FROM files_versions WHERE file_id = ? AND version = ?
+`
+
+type FindFileVersionParams struct {
+	FileID  string
+	Version int64
+}
+
+func (q *Queries) FindFileVersion(ctx context.Context, arg FindFileVersionParams) (FilesVersion, error) {
+	row := q.db.QueryRowContext(ctx, findFileVersion, arg.FileID, arg.Version)
+	var i FilesVersion
+	err := row.Scan(
+		&i.VersionID,
+		&i.FileID,
+		&i.Version,
+		&i.Size,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.Checksum,
+	)
+	return i, err
+}
+
+const findFileVersionsByFileID = `-- name: FindFileVersionsByFileID :many
+SELECT version_id, file_id, version, size, created_at, created_by, checksum FROM files_versions WHERE file_id = ? ORDER BY version DESC
+`
+
+func (q *Queries) FindFileVersionsByFileID(ctx context.Context, fileID string) ([]FilesVersion, error) {
+	rows, err := q.db.QueryContext(ctx, findFileVersionsByFileID, fileID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FilesVersion
+	for rows.Next() {
+		var i FilesVersion
+		if err := rows.Scan(
+			&i.VersionID,
+			&i.FileID,
+			&i.Version,
+			&i.Size,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.Checksum,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findFolderByID = `-- name: FindFolderByID :one
+SELECT folder_id, name, parent_id, owner_id, created_at, updated_at, created_by, updated_by FROM folders WHERE folder_id = ? AND owner_id = ?
+`
+
+type FindFolderByIDParams struct {
+	FolderID string
+	OwnerID  string
+}
+
+func (q *Queries) FindFolderByID(ctx context.Context, arg FindFolderByIDParams) (Folder, error) {
+	row := q.db.QueryRowContext(ctx, findFolderByID, arg.FolderID, arg.OwnerID)
+	var i Folder
+	err := row.Scan(
+		&i.FolderID,
+		&i.Name,
+		&i.ParentID,
+		&i.OwnerID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.CreatedBy,
+		&i.UpdatedBy,
+	)
+	return i, err
+}
+
+const findFolderPath = `-- name: FindFolderPath :many
+WITH RECURSIVE path (folder_id, name, parent_id, depth) AS (
+    SELECT fo.folder_id, fo.name, fo.parent_id, 0
+    FROM folders fo
+    WHERE fo.folder_id = ?
+    UNION ALL
+    SELECT fo.folder_id, fo.name, fo.parent_id, p.depth + 1
+    FROM folders fo
+    INNER JOIN path p ON fo.folder_id = p.parent_id
+)
+SELECT path.folder_id, path.name
+FROM path
+ORDER BY path.depth DESC
+`
+
+type FindFolderPathRow struct {
+	FolderID string
+	Name     string
+}
+
+func (q *Queries) FindFolderPath(ctx context.Context, folderID string) ([]FindFolderPathRow, error) {
+	rows, err := q.db.QueryContext(ctx, findFolderPath, folderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FindFolderPathRow
+	for rows.Next() {
+		var i FindFolderPathRow
+		if err := rows.Scan(&i.FolderID, &i.Name); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -144,64 +2155,36 @@ func (q *Queries) FindAllFiles(ctx context.Context, arg FindAllFilesParams) ([]F
 	return items, nil
 }
 
-const findFileByID = `-- name: FindFileByID :many
-SELECT f.file_id, f.file_name, f.size, f.is_secret, f.owner_id, f.created_at, f.updated_at, f.created_by, f.updated_by, fp.permission_id, fp.file_id, fp.permission, fp.user_id
-FROM files f
-LEFT JOIN files_permissions fp ON f.file_id = fp.file_id
-WHERE f.file_id = ?1
-AND (
-    f.owner_id = ?2 OR (f.file_id IN (
-        SELECT ffp.file_id
-        FROM files_permissions ffp
-        WHERE ffp.file_id = f.file_id AND ffp.user_id = ?2
-    ) AND f.is_secret = FALSE)
-)
+const findFoldersByParentID = `-- name: FindFoldersByParentID :many
+SELECT folder_id, name, parent_id, owner_id, created_at, updated_at, created_by, updated_by FROM folders
+WHERE owner_id = ?1
+AND COALESCE(parent_id, '') = CAST(?2 AS TEXT)
+ORDER BY name
 `
 
-type FindFileByIDParams struct {
-	FileID  string
-	OwnerID string
-}
-
-type FindFileByIDRow struct {
-	FileID       string
-	FileName     string
-	Size         int64
-	IsSecret     bool
-	OwnerID      string
-	CreatedAt    int64
-	UpdatedAt    sql.NullInt64
-	CreatedBy    string
-	UpdatedBy    sql.NullString
-	PermissionID sql.NullString
-	FileID_2     sql.NullString
-	Permission   sql.NullString
-	UserID       sql.NullString
+type FindFoldersByParentIDParams struct {
+	OwnerID  string
+	ParentID string
 }
 
-func (q *Queries) FindFileByID(ctx context.Context, arg FindFileByIDParams) ([]FindFileByIDRow, error) {
-	rows, err := q.db.QueryContext(ctx, findFileByID, arg.FileID, arg.OwnerID)
+func (q *Queries) FindFoldersByParentID(ctx context.Context, arg FindFoldersByParentIDParams) ([]Folder, error) {
+	rows, err := q.db.QueryContext(ctx, findFoldersByParentID, arg.OwnerID, arg.ParentID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []FindFileByIDRow
+	var items []Folder
 	for rows.Next() {
-		var i FindFileByIDRow
+		var i Folder
 		if err := rows.Scan(
-			&i.FileID,
-			&i.FileName,
-			&i.Size,
-			&i.IsSecret,
+			&i.FolderID,
+			&i.Name,
+			&i.ParentID,
 			&i.OwnerID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.CreatedBy,
 			&i.UpdatedBy,
-			&i.PermissionID,
-			&i.FileID_2,
-			&i.Permission,
-			&i.UserID,
 		); err != nil {
 			return nil, err
 		}
@@ -216,8 +2199,48 @@ func (q *Queries) FindFileByID(ctx context.Context, arg FindFileByIDParams) ([]F
 	return items, nil
 }
 
+const findReservedSizeByUserID = `-- name: FindReservedSizeByUserID :one
+SELECT CAST(COALESCE(SUM(size), 0) AS INTEGER) FROM upload_reservations WHERE user_id = ? AND expires_at > ?
+`
+
+type FindReservedSizeByUserIDParams struct {
+	UserID    string
+	ExpiresAt int64
+}
+
+func (q *Queries) FindReservedSizeByUserID(ctx context.Context, arg FindReservedSizeByUserIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, findReservedSizeByUserID, arg.UserID, arg.ExpiresAt)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const findServerTotals = `-- name: FindServerTotals :one
+SELECT COUNT(DISTINCT owner_id) AS users, COUNT(*) AS files,
+CAST(COALESCE(SUM(size), 0) + (SELECT COALESCE(SUM(v.size), 0) FROM files_versions v) AS INTEGER) AS total_size
+FROM files
+`
+
+type FindServerTotalsRow struct {
+	Users     int64
+	Files     int64
+	TotalSize int64
+}
+
+func (q *Queries) FindServerTotals(ctx context.Context) (FindServerTotalsRow, error) {
+	row := q.db.QueryRowContext(ctx, findServerTotals)
+	var i FindServerTotalsRow
+	err := row.Scan(&i.Users, &i.Files, &i.TotalSize)
+	return i, err
+}
+
 const findUsageByUserID = `-- name: FindUsageByUserID :one
-SELECT SUM(f.size) as totalSize
+SELECT SUM(f.size) + COALESCE((
+    SELECT SUM(v.size)
+    FROM files_versions v
+    INNER JOIN files fv ON v.file_id = fv.file_id
+    WHERE fv.owner_id = f.owner_id
+), 0) as totalSize
 FROM files f
 WHERE f.owner_id = ?
 GROUP BY f.owner_id
@@ -230,41 +2253,409 @@ func (q *Queries) FindUsageByUserID(ctx context.Context, ownerID string) (sql.Nu
 	return totalsize, err
 }
 
+const incrementFileLinkDownloads = `-- name: IncrementFileLinkDownloads :execrows
+UPDATE files_links SET downloads = downloads + 1
+WHERE token = ?
+AND (max_downloads IS NULL OR downloads < max_downloads)
+`
+
+func (q *Queries) IncrementFileLinkDownloads(ctx context.Context, token string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, incrementFileLinkDownloads, token)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const releaseBlob = `-- name: ReleaseBlob :one
+UPDATE blobs SET ref_count = ref_count - 1 WHERE hash = ? RETURNING ref_count
+`
+
+func (q *Queries) ReleaseBlob(ctx context.Context, hash string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, releaseBlob, hash)
+	var ref_count int64
+	err := row.Scan(&ref_count)
+	return ref_count, err
+}
+
+const restoreFileByID = `-- name: RestoreFileByID :execrows
+UPDATE files SET
+deleted_at = NULL,
+deleted_by = NULL,
+parent_id = (SELECT fo.folder_id FROM folders fo WHERE fo.folder_id = files.parent_id),
+updated_at = ?1,
+updated_by = ?2
+WHERE file_id = ?3
+AND owner_id = ?4
+AND deleted_at IS NOT NULL
+`
+
+type RestoreFileByIDParams struct {
+	UpdatedAt sql.NullInt64
+	UpdatedBy sql.NullString
+	FileID    string
+	OwnerID   string
+}
+
+func (q *Queries) RestoreFileByID(ctx context.Context, arg RestoreFileByIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreFileByID,
+		arg.UpdatedAt,
+		arg.UpdatedBy,
+		arg.FileID,
+		arg.OwnerID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const trashFileByID = `-- name: TrashFileByID :exec
+UPDATE files SET
+deleted_at = ?1,
+deleted_by = ?2
+WHERE deleted_at IS NULL
+AND file_id IN (
+    SELECT f.file_id 
+    FROM files f
+    LEFT JOIN files_permissions fp ON f.file_id = fp.file_id AND fp.permission = 'EDITOR' 
+    WHERE f.file_id = ?3
+    AND (
+        f.owner_id = ?4 OR
+        fp.user_id = ?4 OR
+        fp.group_name IN (SELECT value FROM json_each(?5))
+    )
+)
+`
+
+type TrashFileByIDParams struct {
+	DeletedAt sql.NullInt64
+	DeletedBy sql.NullString
+	FileID    string
+	OwnerID   string
+	Groups    interface{}
+}
+
+func (q *Queries) TrashFileByID(ctx context.Context, arg TrashFileByIDParams) error {
+	_, err := q.db.ExecContext(ctx, trashFileByID,
+		arg.DeletedAt,
+		arg.DeletedBy,
+		arg.FileID,
+		arg.OwnerID,
+		arg.Groups,
+	)
+	return err
+}
+
+const updateAccessTokenLastUsed = `-- name: UpdateAccessTokenLastUsed :exec
+UPDATE access_tokens SET last_used_at = ? WHERE token_id = ?
+`
+
+type UpdateAccessTokenLastUsedParams struct {
+	LastUsedAt sql.NullInt64
+	TokenID    string
+}
+
+func (q *Queries) UpdateAccessTokenLastUsed(ctx context.Context, arg UpdateAccessTokenLastUsedParams) error {
+	_, err := q.db.ExecContext(ctx, updateAccessTokenLastUsed, arg.LastUsedAt, arg.TokenID)
+	return err
+}
+
+const updateBlobDeletionFailure = `-- name: UpdateBlobDeletionFailure :exec
+UPDATE blob_deletions SET attempts = attempts + 1, last_error = ? WHERE blob_id = ?
+`
+
+type UpdateBlobDeletionFailureParams struct {
+	LastError sql.NullString
+	BlobID    string
+}
+
+func (q *Queries) UpdateBlobDeletionFailure(ctx context.Context, arg UpdateBlobDeletionFailureParams) error {
+	_, err := q.db.ExecContext(ctx, updateBlobDeletionFailure, arg.LastError, arg.BlobID)
+	return err
+}
+
+const updateBlobRefID = `-- name: UpdateBlobRefID :exec
+UPDATE blobs_refs SET blob_id = ?1 WHERE blob_id = ?2
+`
+
+type UpdateBlobRefIDParams struct {
+	ToBlobID   string
+	FromBlobID string
+}
+
+func (q *Queries) UpdateBlobRefID(ctx context.Context, arg UpdateBlobRefIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateBlobRefID, arg.ToBlobID, arg.FromBlobID)
+	return err
+}
+
 const updateFileByID = `-- name: UpdateFileByID :exec
 UPDATE files SET 
-file_name = ?3,
-is_secret = ?4,
-updated_at = ?5,
-updated_by = ?6
+file_name = ?1,
+is_secret = ?2,
+updated_at = ?3,
+updated_by = ?4
 WHERE file_id IN (
     SELECT f.file_id
     FROM files f
     LEFT JOIN files_permissions fp ON f.file_id = fp.file_id AND fp.permission = 'EDITOR' 
-    WHERE f.file_id = ?1
+    WHERE f.file_id = ?5
     AND (
-        f.owner_id = ?2 OR
-        fp.user_id = ?2
+        f.owner_id = ?6 OR
+        fp.user_id = ?6 OR
+        fp.group_name IN (SELECT value FROM json_each(?7))
     )
 )
 `
 
 type UpdateFileByIDParams struct {
-	FileID    string
-	OwnerID   string
 	FileName  string
 	IsSecret  bool
 	UpdatedAt sql.NullInt64
 	UpdatedBy sql.NullString
+	FileID    string
+	OwnerID   string
+	Groups    interface{}
 }
 
 func (q *Queries) UpdateFileByID(ctx context.Context, arg UpdateFileByIDParams) error {
 	_, err := q.db.ExecContext(ctx, updateFileByID,
-		arg.FileID,
-		arg.OwnerID,
 		arg.FileName,
 		arg.IsSecret,
 		arg.UpdatedAt,
 		arg.UpdatedBy,
+		arg.FileID,
+		arg.OwnerID,
+		arg.Groups,
+	)
+	return err
+}
+
+const updateFileComment = `-- name: UpdateFileComment :exec
+UPDATE files_comments SET content = ?, updated_at = ? WHERE comment_id = ?
+`
+
+type UpdateFileCommentParams struct {
+	Content   string
+	UpdatedAt sql.NullInt64
+	CommentID string
+}
+
+func (q *Queries) UpdateFileComment(ctx context.Context, arg UpdateFileCommentParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileComment, arg.Content, arg.UpdatedAt, arg.CommentID)
+	return err
+}
+
+const updateFileContentByID = `-- name: UpdateFileContentByID :exec
+UPDATE files SET size = ?, checksum = ?, corrupted_at = NULL, updated_at = ?, updated_by = ? WHERE file_id = ?
+`
+
+type UpdateFileContentByIDParams struct {
+	Size      int64
+	Checksum  sql.NullString
+	UpdatedAt sql.NullInt64
+	UpdatedBy sql.NullString
+	FileID    string
+}
+
+func (q *Queries) UpdateFileContentByID(ctx context.Context, arg UpdateFileContentByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileContentByID,
+		arg.Size,
+		arg.Checksum,
+		arg.UpdatedAt,
+		arg.UpdatedBy,
+		arg.FileID,
+	)
+	return err
+}
+
+const updateFileCorruptedAtByID = `-- name: UpdateFileCorruptedAtByID :exec
+UPDATE files SET corrupted_at = ?1
+WHERE file_id = ?2
+AND COALESCE(checksum, '') = CAST(?3 AS TEXT)
+`
+
+type UpdateFileCorruptedAtByIDParams struct {
+	CorruptedAt sql.NullInt64
+	FileID      string
+	Checksum    string
+}
+
+func (q *Queries) UpdateFileCorruptedAtByID(ctx context.Context, arg UpdateFileCorruptedAtByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileCorruptedAtByID, arg.CorruptedAt, arg.FileID, arg.Checksum)
+	return err
+}
+
+const updateFileOwnerByID = `-- name: UpdateFileOwnerByID :exec
+UPDATE files SET owner_id = ?, parent_id = NULL, updated_at = ?, updated_by = ? WHERE file_id = ?
+`
+
+type UpdateFileOwnerByIDParams struct {
+	OwnerID   string
+	UpdatedAt sql.NullInt64
+	UpdatedBy sql.NullString
+	FileID    string
+}
+
+func (q *Queries) UpdateFileOwnerByID(ctx context.Context, arg UpdateFileOwnerByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileOwnerByID,
+		arg.OwnerID,
+		arg.UpdatedAt,
+		arg.UpdatedBy,
+		arg.FileID,
+	)
+	return err
+}
+
+const updateFileParentByID = `-- name: UpdateFileParentByID :execrows
+UPDATE files SET
+parent_id = ?1,
+updated_at = ?2,
+updated_by = ?3
+WHERE file_id IN (
+    SELECT f.file_id
+    FROM files f
+    LEFT JOIN files_permissions fp ON f.file_id = fp.file_id AND fp.permission = 'EDITOR'
+    WHERE f.file_id = ?4
+    AND (
+        f.owner_id = ?5 OR
+        fp.user_id = ?5 OR
+        fp.group_name IN (SELECT value FROM json_each(?6))
+    )
+)
+`
+
+type UpdateFileParentByIDParams struct {
+	ParentID  sql.NullString
+	UpdatedAt sql.NullInt64
+	UpdatedBy sql.NullString
+	FileID    string
+	OwnerID   string
+	Groups    interface{}
+}
+
+func (q *Queries) UpdateFileParentByID(ctx context.Context, arg UpdateFileParentByIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateFileParentByID,
+		arg.ParentID,
+		arg.UpdatedAt,
+		arg.UpdatedBy,
+		arg.FileID,
+		arg.OwnerID,
+		arg.Groups,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateFileSizeByID = `-- name: UpdateFileSizeByID :exec
+UPDATE files SET size = ? WHERE file_id = ?
+`
+
+type UpdateFileSizeByIDParams struct {
+	Size   int64
+	FileID string
+}
+
+func (q *Queries) UpdateFileSizeByID(ctx context.Context, arg UpdateFileSizeByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileSizeByID, arg.Size, arg.FileID)
+	return err
+}
+
+const updateFilesOwnerByOwnerID = `-- name: UpdateFilesOwnerByOwnerID :execrows
+UPDATE files SET
+owner_id = ?1,
+updated_at = ?2,
+updated_by = ?3
+WHERE owner_id = ?4
+`
+
+type UpdateFilesOwnerByOwnerIDParams struct {
+	NewOwnerID string
+	UpdatedAt  sql.NullInt64
+	UpdatedBy  sql.NullString
+	OwnerID    string
+}
+
+func (q *Queries) UpdateFilesOwnerByOwnerID(ctx context.Context, arg UpdateFilesOwnerByOwnerIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateFilesOwnerByOwnerID,
+		arg.NewOwnerID,
+		arg.UpdatedAt,
+		arg.UpdatedBy,
+		arg.OwnerID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateFileVersionSizeByID = `-- name: UpdateFileVersionSizeByID :exec
+UPDATE files_versions SET size = ? WHERE version_id = ?
+`
+
+type UpdateFileVersionSizeByIDParams struct {
+	Size      int64
+	VersionID string
+}
+
+func (q *Queries) UpdateFileVersionSizeByID(ctx context.Context, arg UpdateFileVersionSizeByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateFileVersionSizeByID, arg.Size, arg.VersionID)
+	return err
+}
+
+const updateFolderByID = `-- name: UpdateFolderByID :execrows
+UPDATE folders SET
+name = ?1,
+updated_at = ?2,
+updated_by = ?3
+WHERE folder_id = ?4 AND owner_id = ?5
+`
+
+type UpdateFolderByIDParams struct {
+	Name      string
+	UpdatedAt sql.NullInt64
+	UpdatedBy sql.NullString
+	FolderID  string
+	OwnerID   string
+}
+
+func (q *Queries) UpdateFolderByID(ctx context.Context, arg UpdateFolderByIDParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateFolderByID,
+		arg.Name,
+		arg.UpdatedAt,
+		arg.UpdatedBy,
+		arg.FolderID,
+		arg.OwnerID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateFoldersOwnerByOwnerID = `-- name: UpdateFoldersOwnerByOwnerID :exec
+UPDATE folders SET
+owner_id = ?1,
+updated_at = ?2,
+updated_by = ?3
+WHERE owner_id = ?4
+`
+
+type UpdateFoldersOwnerByOwnerIDParams struct {
+	NewOwnerID string
+	UpdatedAt  sql.NullInt64
+	UpdatedBy  sql.NullString
+	OwnerID    string
+}
+
+func (q *Queries) UpdateFoldersOwnerByOwnerID(ctx context.Context, arg UpdateFoldersOwnerByOwnerIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateFoldersOwnerByOwnerID,
+		arg.NewOwnerID,
+		arg.UpdatedAt,
+		arg.UpdatedBy,
+		arg.OwnerID,
 	)
 	return err
 }
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/mapper"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+)
+
+type AdminHandler interface {
+	ListUsage(w http.ResponseWriter, r *http.Request)
+	Totals(w http.ResponseWriter, r *http.Request)
+	ListUserFiles(w http.ResponseWriter, r *http.Request)
+	DeleteUserFile(w http.ResponseWriter, r *http.Request)
+	ListAudit(w http.ResponseWriter, r *http.Request)
+	StorageStatus(w http.ResponseWriter, r *http.Request)
+}
+
+type adminHandler struct {
+	adminFacade facade.AdminFacade
+}
+
+func NewAdminHandler(adminFacade facade.AdminFacade) AdminHandler {
+	return &adminHandler{adminFacade: adminFacade}
+}
+
+func (a *adminHandler) ListUsage(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	usagePage, err := a.adminFacade.FindAllUsage(traceId, page, size)
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapUsagePageResponse(page, size, usagePage, r.Host), traceId)
+}
+
+func (a *adminHandler) Totals(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	totals, err := a.adminFacade.FindTotals(traceId)
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapTotalsResponse(totals), traceId)
+}
+
+func (a *adminHandler) ListUserFiles(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+
+	filename := r.URL.Query().Get("filename")
+
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	userId := chi.URLParam(r, "userId")
+
+	filesPage, err := a.adminFacade.FindAllFiles(traceId, userId, page, size, filename)
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapAdminFilePageResponse(page, size, userId, filesPage, r.Host), traceId)
+}
+
+func (a *adminHandler) DeleteUserFile(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	userId := chi.URLParam(r, "userId")
+	fileId := chi.URLParam(r, "fileId")
+
+	err := a.adminFacade.DeleteFile(traceId, userId, fileId)
+
+	if err == repository.ErrFileDoesNotExists {
+		response.NotFound(w, traceId)
+		return
+	}
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *adminHandler) ListAudit(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	auditPage, err := a.adminFacade.FindAllAudit(traceId, page, size)
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapAuditPageResponse(page, size, auditPage, r.Host), traceId)
+}
+
+func (a *adminHandler) StorageStatus(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	response.Ok(w, a.adminFacade.FindStorageStatus(traceId), traceId)
+}
@@ -0,0 +1,165 @@
+package handler_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	chim "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAdmin(t *testing.T) {
+	createReq := func(method string, url string, params map[string]string) *http.Request {
+		rctx := chi.NewRouteContext()
+
+		for k, v := range params {
+			rctx.URLParams.Add(k, v)
+		}
+
+		req, _ := http.NewRequest(method, url, nil)
+		ctx := context.WithValue(req.Context(), chim.RequestIDKey, "trace-id")
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("should list users usage", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAdminFacade(mockCtrl)
+
+		af.EXPECT().FindAllUsage("trace-id", 0, 2).Return(&entity.UsagePage{
+			Count: 3,
+			Content: []*entity.Usage{
+				{UserId: "user1", Files: 3, Size: 2048},
+				{UserId: "user2", Files: 1, Size: 1024},
+			},
+		}, nil)
+
+		ctr := handler.NewAdminHandler(af)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ListUsage).ServeHTTP(rr, createReq("GET", "/admin/usage?size=2", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+
+		var res model.UsagePageResponse
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&res))
+		assert.Equal(t, "/file-service/v1/admin/usage?page=1&size=2", res.Next)
+		assert.Len(t, res.Content, 2)
+	})
+
+	t.Run("should return server totals", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAdminFacade(mockCtrl)
+
+		af.EXPECT().FindTotals("trace-id").Return(&entity.Totals{Users: 2, Files: 4, Size: 3072}, nil)
+
+		ctr := handler.NewAdminHandler(af)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Totals).ServeHTTP(rr, createReq("GET", "/admin/totals", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"users": 2, "files": 4, "size": 3072}`, rr.Body.String())
+	})
+
+	t.Run("should return storage status", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAdminFacade(mockCtrl)
+
+		af.EXPECT().FindStorageStatus("trace-id").Return(&entity.StorageStatus{
+			Mirrored: true,
+			Disks: []*entity.DiskStatus{
+				{Path: "/mnt/primary/storage", Healthy: true, Blobs: 3},
+				{Path: "/mnt/secondary/storage", Healthy: false, Error: "blob fileId is missing"},
+			},
+		})
+
+		ctr := handler.NewAdminHandler(af)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.StorageStatus).ServeHTTP(rr, createReq("GET", "/admin/storage", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{
+			"mirrored": true,
+			"repaired": 0,
+			"disks": [
+				{"path": "/mnt/primary/storage", "healthy": true, "blobs": 3},
+				{"path": "/mnt/secondary/storage", "healthy": false, "blobs": 0, "error": "blob fileId is missing"}
+			]
+		}`, rr.Body.String())
+	})
+
+	t.Run("should list user files", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAdminFacade(mockCtrl)
+
+		af.EXPECT().FindAllFiles("trace-id", "user1", 0, 0, "cv").Return(&entity.FilePage{
+			Count:   1,
+			Content: []*entity.File{createFileMetadataLookup(uuid.NewString())},
+		}, nil)
+
+		ctr := handler.NewAdminHandler(af)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ListUserFiles).ServeHTTP(rr, createReq("GET", "/admin/users/user1/files?filename=cv", map[string]string{"userId": "user1"}))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should delete user file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAdminFacade(mockCtrl)
+
+		random := uuid.NewString()
+		af.EXPECT().DeleteFile("trace-id", "user1", random).Return(nil)
+
+		ctr := handler.NewAdminHandler(af)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.DeleteUserFile).ServeHTTP(rr, createReq("DELETE", "/admin/users/user1/files/"+random, map[string]string{"userId": "user1", "fileId": random}))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("should return NOT FOUND when user does not own the file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAdminFacade(mockCtrl)
+
+		random := uuid.NewString()
+		af.EXPECT().DeleteFile("trace-id", "user1", random).Return(repository.ErrFileDoesNotExists)
+
+		ctr := handler.NewAdminHandler(af)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.DeleteUserFile).ServeHTTP(rr, createReq("DELETE", "/admin/users/user1/files/"+random, map[string]string{"userId": "user1", "fileId": random}))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return INTERNAL SERVER ERROR when audit listing fails", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAdminFacade(mockCtrl)
+
+		af.EXPECT().FindAllAudit("trace-id", 0, 0).Return(nil, errors.New("generic error"))
+
+		ctr := handler.NewAdminHandler(af)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ListAudit).ServeHTTP(rr, createReq("GET", "/admin/audit", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
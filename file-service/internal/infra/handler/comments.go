@@ -0,0 +1,125 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/validator"
+)
+
+type CommentsHandler interface {
+	ListComments(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+}
+
+type commentsHandler struct {
+	commentFacade facade.CommentFacade
+}
+
+func NewCommentsHandler(commentFacade facade.CommentFacade) CommentsHandler {
+	return &commentsHandler{commentFacade: commentFacade}
+}
+
+func (c *commentsHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	comments, err := c.commentFacade.FindAll(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"))
+
+	if err != nil {
+		handleCommentError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, comments, traceId)
+}
+
+func (c *commentsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return
+	}
+
+	if err := validator.ValidateCreateCommentRequest(&req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return
+	}
+
+	comment, err := c.commentFacade.Create(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"), req.ParentId, req.Content)
+
+	if err != nil {
+		handleCommentError(w, err, traceId)
+		return
+	}
+
+	response.Created(w, comment, traceId)
+}
+
+func (c *commentsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return
+	}
+
+	if err := validator.ValidateUpdateCommentRequest(&req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return
+	}
+
+	comment, err := c.commentFacade.Update(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"),
+		chi.URLParam(r, "commentId"), req.Content)
+
+	if err != nil {
+		handleCommentError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, comment, traceId)
+}
+
+func (c *commentsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	err := c.commentFacade.Delete(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"), chi.URLParam(r, "commentId"))
+
+	if err != nil {
+		handleCommentError(w, err, traceId)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleCommentError(w http.ResponseWriter, err error, traceId string) {
+	switch err {
+	case repository.ErrFileDoesNotExists, repository.ErrCommentDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotCommentAuthor:
+		response.Forbidden(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
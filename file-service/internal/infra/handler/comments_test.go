@@ -0,0 +1,153 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	apiHandler "github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestComments(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	createReq := func(method string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "fileId")
+		rctx.URLParams.Add("commentId", "commentId")
+
+		req, _ := http.NewRequest(method, "/files/fileId/comments", bytes.NewBufferString(body))
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("should list comments", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cf := mocks.NewMockCommentFacade(mockCtrl)
+
+		reply := &entity.Comment{CommentId: "replyId", ParentId: "commentId", Content: "Thanks!", Replies: []*entity.Comment{}}
+
+		cf.EXPECT().FindAll("test-trace-id", "userId", []string{}, "fileId").
+			Return([]*entity.Comment{{CommentId: "commentId", Content: "Nice one", Replies: []*entity.Comment{reply}}}, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewCommentsHandler(cf).ListComments).ServeHTTP(rr, createReq("GET", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"parentId":"commentId"`)
+	})
+
+	t.Run("should return not found when file is not visible to requester", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cf := mocks.NewMockCommentFacade(mockCtrl)
+
+		cf.EXPECT().FindAll("test-trace-id", "userId", []string{}, "fileId").Return(nil, repository.ErrFileDoesNotExists)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewCommentsHandler(cf).ListComments).ServeHTTP(rr, createReq("GET", ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should create reply", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cf := mocks.NewMockCommentFacade(mockCtrl)
+
+		cf.EXPECT().Create("test-trace-id", "userId", []string{}, "fileId", "commentId", "Thanks!").
+			Return(entity.NewComment("fileId", "commentId", "Thanks!", "userId"), nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewCommentsHandler(cf).Create).
+			ServeHTTP(rr, createReq("POST", `{"parentId":"commentId","content":"Thanks!"}`))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("should return not found when parent comment does not exists", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cf := mocks.NewMockCommentFacade(mockCtrl)
+
+		cf.EXPECT().Create("test-trace-id", "userId", []string{}, "fileId", "commentId", "Thanks!").
+			Return(nil, repository.ErrCommentDoesNotExists)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewCommentsHandler(cf).Create).
+			ServeHTTP(rr, createReq("POST", `{"parentId":"commentId","content":"Thanks!"}`))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return bad request when content is empty", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewCommentsHandler(nil).Create).ServeHTTP(rr, createReq("POST", `{"content":""}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should update comment", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cf := mocks.NewMockCommentFacade(mockCtrl)
+
+		cf.EXPECT().Update("test-trace-id", "userId", []string{}, "fileId", "commentId", "Edited").
+			Return(&entity.Comment{CommentId: "commentId", Content: "Edited"}, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewCommentsHandler(cf).Update).ServeHTTP(rr, createReq("PUT", `{"content":"Edited"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"content":"Edited"`)
+	})
+
+	t.Run("should return forbidden when requester is not the author", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cf := mocks.NewMockCommentFacade(mockCtrl)
+
+		cf.EXPECT().Update("test-trace-id", "userId", []string{}, "fileId", "commentId", "Edited").Return(nil, facade.ErrNotCommentAuthor)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewCommentsHandler(cf).Update).ServeHTTP(rr, createReq("PUT", `{"content":"Edited"}`))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should delete comment", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cf := mocks.NewMockCommentFacade(mockCtrl)
+
+		cf.EXPECT().Delete("test-trace-id", "userId", []string{}, "fileId", "commentId").Return(nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewCommentsHandler(cf).Delete).ServeHTTP(rr, createReq("DELETE", ""))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("should return internal server error when delete fails", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		cf := mocks.NewMockCommentFacade(mockCtrl)
+
+		cf.EXPECT().Delete("test-trace-id", "userId", []string{}, "fileId", "commentId").Return(errors.New("generic error"))
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewCommentsHandler(cf).Delete).ServeHTTP(rr, createReq("DELETE", ""))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
@@ -1,8 +1,13 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -11,12 +16,14 @@ import (
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
 	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
 )
 
 type DownloadHandler interface {
 	Download(w http.ResponseWriter, r *http.Request)
+	DownloadVersion(w http.ResponseWriter, r *http.Request)
 }
 
 type downloadHandler struct {
@@ -33,7 +40,7 @@ func (h *downloadHandler) Download(w http.ResponseWriter, r *http.Request) {
 	usr := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
 	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
 
-	fileRep, err := h.fileFacade.FindById(usr.Subject(), fileId)
+	fileRep, err := h.fileFacade.FindById(usr.Subject(), m.UserGroups(usr), fileId)
 
 	if err == repository.ErrFileDoesNotExists {
 		response.NotFound(w, traceId)
@@ -54,9 +61,72 @@ func (h *downloadHandler) Download(w http.ResponseWriter, r *http.Request) {
 
 	defer file.Close()
 
+	serveFile(w, r, fileRep, file)
+}
+
+func (h *downloadHandler) DownloadVersion(w http.ResponseWriter, r *http.Request) {
+	fileId := chi.URLParam(r, "fileId")
+	usr := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	number, err := strconv.ParseInt(chi.URLParam(r, "version"), 10, 64)
+
+	if err != nil {
+		response.NotFound(w, traceId)
+		return
+	}
+
+	fileRep, err := h.fileFacade.FindById(usr.Subject(), m.UserGroups(usr), fileId)
+
+	if err == repository.ErrFileDoesNotExists {
+		response.NotFound(w, traceId)
+		return
+	}
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	version, err := h.fileFacade.FindVersion(traceId, usr.Subject(), m.UserGroups(usr), fileId, number)
+
+	if err == repository.ErrVersionDoesNotExists {
+		response.NotFound(w, traceId)
+		return
+	}
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	// versions are stored as blobs of their own, named after the version ID
+	file, err := h.downloadUseCase.Execute(r.Context(), version.VersionId)
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	defer file.Close()
+
+	versionRep := *fileRep
+	versionRep.Size = version.Size
+	versionRep.Checksum = version.Checksum
+
+	serveFile(w, r, &versionRep, file)
+}
+
+func serveFile(w http.ResponseWriter, r *http.Request, fileRep *entity.File, content io.ReadSeeker) {
 	w.Header().Set("Content-Type", "application/octet-stream")
 	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", fileRep.Filename))
 	w.Header().Set("Content-Length", fmt.Sprintf("%d", fileRep.Size))
 
-	http.ServeContent(w, r, fileRep.Filename, time.Now(), file)
+	// files uploaded before checksums were recorded have none
+	if checksum, err := hex.DecodeString(fileRep.Checksum); err == nil && len(checksum) == sha256.Size {
+		w.Header().Set("Digest", "sha-256="+base64.StdEncoding.EncodeToString(checksum))
+		w.Header().Set("ETag", fmt.Sprintf("\"%s\"", fileRep.Checksum))
+	}
+
+	http.ServeContent(w, r, fileRep.Filename, time.Now(), content)
 }
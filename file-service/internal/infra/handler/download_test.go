@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -41,10 +42,11 @@ func TestDownload(t *testing.T) {
 
 		ff := mocks.NewMockFileFacade(mockCtrl)
 
-		ff.EXPECT().FindById(gomock.Any(), gomock.Any()).Return(&entity.File{
+		ff.EXPECT().FindById(gomock.Any(), gomock.Any(), gomock.Any()).Return(&entity.File{
 			FileId:    "4e2bc94b-a6b6-4c44-9512-79b5eb654524",
 			Filename:  testFilename,
 			Size:      1024,
+			Checksum:  "ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73",
 			UpdatedAt: &[]time.Time{time.Now()}[0],
 			CreatedBy: uuid.NewString(),
 			UpdatedBy: &[]string{uuid.NewString()}[0],
@@ -62,6 +64,8 @@ func TestDownload(t *testing.T) {
 		assert.Equal(t, http.StatusOK, rr.Code)
 		assert.Equal(t, "application/octet-stream", rr.Header().Get("Content-Type"))
 		assert.Equal(t, fmt.Sprintf("attachment; filename=\"%s\"", testFilename), rr.Header().Get("Content-Disposition"))
+		assert.Equal(t, "sha-256=7XACtDnprIRfIjV9giusFERzD722AW0+yUMil7nsn3M=", rr.Header().Get("Digest"))
+		assert.Equal(t, `"ed7002b439e9ac845f22357d822bac1444730fbdb6016d3ec9432297b9ec9f73"`, rr.Header().Get("ETag"))
 	})
 
 	t.Run("should return NOT FOUND when no file are found in database with given id", func(t *testing.T) {
@@ -70,7 +74,7 @@ func TestDownload(t *testing.T) {
 
 		ff := mocks.NewMockFileFacade(mockCtrl)
 
-		ff.EXPECT().FindById(gomock.Any(), gomock.Any()).Return(nil, repository.ErrFileDoesNotExists)
+		ff.EXPECT().FindById(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repository.ErrFileDoesNotExists)
 
 		ctr := handler.NewDownloadHandler(downloadUseCase, ff)
 
@@ -91,7 +95,7 @@ func TestDownload(t *testing.T) {
 
 		ff := mocks.NewMockFileFacade(mockCtrl)
 
-		ff.EXPECT().FindById(gomock.Any(), gomock.Any()).Return(nil, errors.New("generic error"))
+		ff.EXPECT().FindById(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("generic error"))
 
 		ctr := handler.NewDownloadHandler(downloadUseCase, ff)
 
@@ -110,7 +114,7 @@ type downloadFileUseCaseMock struct {
 	shouldReturnErr bool
 }
 
-func (d *downloadFileUseCaseMock) Execute(ctx context.Context, fileId string) (file *os.File, err error) {
+func (d *downloadFileUseCaseMock) Execute(ctx context.Context, fileId string) (file io.ReadSeekCloser, err error) {
 	if d.shouldReturnErr {
 		return nil, errors.New("generic error")
 	}
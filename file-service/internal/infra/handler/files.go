@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
@@ -20,20 +22,29 @@ import (
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/validator"
 )
 
+const metadataQueryPrefix = "meta."
+
 type FilesHandler interface {
 	ListFiles(w http.ResponseWriter, r *http.Request)
+	ListSharedFiles(w http.ResponseWriter, r *http.Request)
 	FindById(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
+	Move(w http.ResponseWriter, r *http.Request)
+	Copy(w http.ResponseWriter, r *http.Request)
+	UpdateMetadata(w http.ResponseWriter, r *http.Request)
+	Star(w http.ResponseWriter, r *http.Request)
+	Unstar(w http.ResponseWriter, r *http.Request)
 }
 
 type filesHandler struct {
 	fileFacade    facade.FileFacade
 	updateUseCase usecase.UpdateFileUseCase
+	copyUseCase   usecase.CopyFileUseCase
 }
 
-func NewFilesHandler(fileFacade facade.FileFacade, updateUseCase usecase.UpdateFileUseCase) FilesHandler {
-	return &filesHandler{fileFacade: fileFacade, updateUseCase: updateUseCase}
+func NewFilesHandler(fileFacade facade.FileFacade, updateUseCase usecase.UpdateFileUseCase, copyUseCase usecase.CopyFileUseCase) FilesHandler {
+	return &filesHandler{fileFacade: fileFacade, updateUseCase: updateUseCase, copyUseCase: copyUseCase}
 }
 
 func (f *filesHandler) ListFiles(w http.ResponseWriter, r *http.Request) {
@@ -42,13 +53,18 @@ func (f *filesHandler) ListFiles(w http.ResponseWriter, r *http.Request) {
 
 	filename := r.URL.Query().Get("filename")
 	secretQuery := r.URL.Query().Get("secret")
+	starredQuery := r.URL.Query().Get("starred")
+	folderId := r.URL.Query().Get("folderId")
+	tag := r.URL.Query().Get("tag")
+	metadata := metadataFilter(r.URL.Query())
 
 	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
 	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
 
 	secret, _ := strconv.ParseBool(secretQuery)
+	starred, _ := strconv.ParseBool(starredQuery)
 
-	filesPage, err := f.fileFacade.FindAll(traceId, user.Subject(), page, size, filename, secret)
+	filesPage, err := f.fileFacade.FindAll(traceId, user.Subject(), m.UserGroups(user), page, size, filename, secret, folderId, tag, metadata, starred)
 
 	if err != nil {
 		response.InternalServerError(w, traceId)
@@ -58,20 +74,39 @@ func (f *filesHandler) ListFiles(w http.ResponseWriter, r *http.Request) {
 	response.Ok(w, mapper.MapFilePageResponse(page, size, filesPage, r.Host), traceId)
 }
 
+func (f *filesHandler) ListSharedFiles(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	filesPage, err := f.fileFacade.FindAllShared(traceId, user.Subject(), m.UserGroups(user), page, size)
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapSharedFilePageResponse(page, size, filesPage, r.Host), traceId)
+}
+
 func (f *filesHandler) FindById(w http.ResponseWriter, r *http.Request) {
 	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
 	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
 
 	fileId := chi.URLParam(r, "id")
 
-	entity, err := f.fileFacade.FindById(user.Subject(), fileId)
+	entity, err := f.fileFacade.FindById(user.Subject(), m.UserGroups(user), fileId)
 
-	if err == repository.ErrFileDoesNotExists {
+	switch err {
+	case nil:
+		response.Ok(w, entity, traceId)
+	case repository.ErrFileDoesNotExists:
 		response.NotFound(w, traceId)
-		return
+	default:
+		response.InternalServerError(w, traceId)
 	}
-
-	response.Ok(w, entity, traceId)
 }
 
 func (f *filesHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -100,31 +135,163 @@ func (f *filesHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	fileMetadata, err := f.updateUseCase.Execute(r.Context(), file)
 
-	if err == repository.ErrFileDoesNotExists {
+	switch err {
+	case nil:
+		response.Ok(w, fileMetadata, traceId)
+	case repository.ErrFileDoesNotExists:
 		response.NotFound(w, traceId)
+	case repository.ErrFileLocked:
+		response.Locked(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
+
+func (f *filesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	fileId := chi.URLParam(r, "id")
+
+	slog.Info(fileId)
+
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	err := f.fileFacade.DeleteById(traceId, user.Subject(), m.UserGroups(user), fileId)
+
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusNoContent)
+	case repository.ErrFileDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotFileEditor:
+		response.Forbidden(w, traceId)
+	case repository.ErrFileLocked:
+		response.Locked(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
+
+func (f *filesHandler) Move(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.MoveFileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
 		return
 	}
 
-	if err != nil {
+	file, err := f.fileFacade.Move(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"), req.FolderId)
+
+	switch err {
+	case nil:
+		response.Ok(w, file, traceId)
+	case repository.ErrFileDoesNotExists, repository.ErrFolderDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotFileEditor:
+		response.Forbidden(w, traceId)
+	case repository.ErrFileLocked:
+		response.Locked(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
+
+func (f *filesHandler) Copy(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	var req model.CopyFileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return
+	}
+
+	file, err := f.copyUseCase.Execute(r.Context(), chi.URLParam(r, "id"), req.FolderId, req.Filename)
+
+	switch err {
+	case nil:
+		response.Created(w, file, traceId)
+	case repository.ErrFileDoesNotExists, repository.ErrFolderDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotFileEditor:
+		response.Forbidden(w, traceId)
+	case usecase.ErrNotAvailableSpace:
+		response.BadRequest(w, model.ErrorResponse{Message: err.Error()}, traceId)
+	default:
 		response.InternalServerError(w, traceId)
+	}
+}
+
+func (f *filesHandler) UpdateMetadata(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.UpdateMetadataRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
 		return
 	}
 
-	response.Ok(w, fileMetadata, traceId)
+	if err := validator.ValidateUpdateMetadataRequest(req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return
+	}
+
+	file, err := f.fileFacade.UpdateMetadata(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"), req)
+
+	switch err {
+	case nil:
+		response.Ok(w, file, traceId)
+	case repository.ErrFileDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotFileEditor:
+		response.Forbidden(w, traceId)
+	case repository.ErrFileLocked:
+		response.Locked(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
 }
 
-func (f *filesHandler) Delete(w http.ResponseWriter, r *http.Request) {
-	fileId := chi.URLParam(r, "id")
+func (f *filesHandler) Star(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
 
-	slog.Info(fileId)
+	err := f.fileFacade.Star(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"))
+
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusNoContent)
+	case repository.ErrFileDoesNotExists:
+		response.NotFound(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
 
+func (f *filesHandler) Unstar(w http.ResponseWriter, r *http.Request) {
 	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
 	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
 
-	if err := f.fileFacade.DeleteById(traceId, user.Subject(), fileId); err != nil {
+	if err := f.fileFacade.Unstar(traceId, user.Subject(), chi.URLParam(r, "id")); err != nil {
 		response.InternalServerError(w, traceId)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// metadataFilter collects the meta.<key>=<value> query parameters
+func metadataFilter(query url.Values) map[string]string {
+	metadata := map[string]string{}
+
+	for param, values := range query {
+		if key, found := strings.CutPrefix(param, metadataQueryPrefix); found && key != "" {
+			metadata[key] = values[0]
+		}
+	}
+
+	return metadata
+}
@@ -15,8 +15,10 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/google/uuid"
 	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
 	apiHandler "github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
 	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
@@ -33,12 +35,12 @@ func TestGetAllFilesSuccess(t *testing.T) {
 
 	ff := mocks.NewMockFileFacade(mockCtrl)
 
-	ff.EXPECT().FindAll(gomock.Any(), gomock.Any(), 0, 0, "", false).Return(&entity.FilePage{
+	ff.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), 0, 0, "", false, "", "", map[string]string{}, false).Return(&entity.FilePage{
 		Content: []*entity.File{},
 		Count:   0,
 	}, nil)
 
-	ctr := apiHandler.NewFilesHandler(ff, nil)
+	ctr := apiHandler.NewFilesHandler(ff, nil, nil)
 
 	req, _ := http.NewRequest("GET", "/files", nil)
 	req.Header.Set("Content-Type", "application/json")
@@ -63,12 +65,12 @@ func TestGetAllFilesPaginatedSuccess(t *testing.T) {
 
 	ff := mocks.NewMockFileFacade(mockCtrl)
 
-	ff.EXPECT().FindAll(gomock.Any(), gomock.Any(), 0, 3, "", false).Return(&entity.FilePage{
+	ff.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), 0, 3, "", false, "", "", map[string]string{}, false).Return(&entity.FilePage{
 		Content: []*entity.File{},
 		Count:   0,
 	}, nil)
 
-	ctr := apiHandler.NewFilesHandler(ff, nil)
+	ctr := apiHandler.NewFilesHandler(ff, nil, nil)
 
 	page := 0
 	size := 3
@@ -87,6 +89,63 @@ func TestGetAllFilesPaginatedSuccess(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rr.Code)
 }
 
+func TestGetAllFilesByTagSuccess(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	mockCtrl := gomock.NewController(t)
+
+	ff := mocks.NewMockFileFacade(mockCtrl)
+
+	ff.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), 0, 0, "", false, "", "holidays", map[string]string{}, false).Return(&entity.FilePage{
+		Content: []*entity.File{},
+		Count:   0,
+	}, nil)
+
+	ctr := apiHandler.NewFilesHandler(ff, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/files?tag=holidays", nil)
+	ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+	ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(ctr.ListFiles).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestGetAllFilesByMetadataSuccess(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	mockCtrl := gomock.NewController(t)
+
+	ff := mocks.NewMockFileFacade(mockCtrl)
+
+	ff.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), 0, 0, "", false, "", "",
+		map[string]string{"camera": "X100", "project": "home"}, false).Return(&entity.FilePage{
+		Content: []*entity.File{},
+		Count:   0,
+	}, nil)
+
+	ctr := apiHandler.NewFilesHandler(ff, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/files?meta.camera=X100&meta.project=home&meta.=ignored", nil)
+	ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+	ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(ctr.ListFiles).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
 func TestGetAllFilesPaginatedInternalServerError(t *testing.T) {
 	token := jwt.New()
 	err := token.Set("sub", "userId")
@@ -96,9 +155,9 @@ func TestGetAllFilesPaginatedInternalServerError(t *testing.T) {
 
 	ff := mocks.NewMockFileFacade(mockCtrl)
 
-	ff.EXPECT().FindAll(gomock.Any(), gomock.Any(), 0, 3, "", false).Return(nil, errors.New("generic error"))
+	ff.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), 0, 3, "", false, "", "", map[string]string{}, false).Return(nil, errors.New("generic error"))
 
-	ctr := apiHandler.NewFilesHandler(ff, nil)
+	ctr := apiHandler.NewFilesHandler(ff, nil, nil)
 
 	page := 0
 	size := 3
@@ -117,6 +176,63 @@ func TestGetAllFilesPaginatedInternalServerError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 }
 
+func TestGetAllSharedFilesSuccess(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	mockCtrl := gomock.NewController(t)
+
+	ff := mocks.NewMockFileFacade(mockCtrl)
+
+	ff.EXPECT().FindAllShared(gomock.Any(), "userId", []string{}, 0, 3).Return(&entity.FilePage{
+		Content: []*entity.File{},
+		Count:   0,
+	}, nil)
+
+	ctr := apiHandler.NewFilesHandler(ff, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/files/shared?page=0&size=3", nil)
+	req.Header.Set("Content-Type", "application/json")
+	ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+	ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+
+	handler := http.HandlerFunc(ctr.ListSharedFiles)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestGetAllSharedFilesInternalServerError(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	mockCtrl := gomock.NewController(t)
+
+	ff := mocks.NewMockFileFacade(mockCtrl)
+
+	ff.EXPECT().FindAllShared(gomock.Any(), "userId", []string{}, 0, 0).Return(nil, errors.New("generic error"))
+
+	ctr := apiHandler.NewFilesHandler(ff, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/files/shared", nil)
+	req.Header.Set("Content-Type", "application/json")
+	ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+	ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+
+	handler := http.HandlerFunc(ctr.ListSharedFiles)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
+
 func TestDeleteFileSuccess(t *testing.T) {
 	token := jwt.New()
 	err := token.Set("sub", "userId")
@@ -128,9 +244,9 @@ func TestDeleteFileSuccess(t *testing.T) {
 
 	ff := mocks.NewMockFileFacade(mockCtrl)
 
-	ff.EXPECT().DeleteById(gomock.Any(), gomock.Any(), random).Return(nil)
+	ff.EXPECT().DeleteById(gomock.Any(), gomock.Any(), gomock.Any(), random).Return(nil)
 
-	ctr := apiHandler.NewFilesHandler(ff, nil)
+	ctr := apiHandler.NewFilesHandler(ff, nil, nil)
 
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("id", random)
@@ -161,9 +277,9 @@ func TestDeleteFileInternalServerError(t *testing.T) {
 
 	ff := mocks.NewMockFileFacade(mockCtrl)
 
-	ff.EXPECT().DeleteById("test-trace-id", "userId", random).Return(errors.New("generic error"))
+	ff.EXPECT().DeleteById("test-trace-id", "userId", []string{}, random).Return(errors.New("generic error"))
 
-	ctr := apiHandler.NewFilesHandler(ff, nil)
+	ctr := apiHandler.NewFilesHandler(ff, nil, nil)
 
 	rctx := chi.NewRouteContext()
 	rctx.URLParams.Add("id", random)
@@ -183,9 +299,36 @@ func TestDeleteFileInternalServerError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 }
 
+func TestDeleteFileLocked(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	mockCtrl := gomock.NewController(t)
+
+	ff := mocks.NewMockFileFacade(mockCtrl)
+
+	ff.EXPECT().DeleteById("test-trace-id", "userId", []string{}, "fileId").Return(repository.ErrFileLocked)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "fileId")
+
+	req, _ := http.NewRequest("DELETE", "/files/fileId", nil)
+	ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+	ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+	ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(apiHandler.NewFilesHandler(ff, nil, nil).Delete).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusLocked, rr.Code)
+}
+
 func TestUpdateFileSuccess(t *testing.T) {
 	uc := &updateUseCaseMock{}
-	ctr := apiHandler.NewFilesHandler(nil, uc)
+	ctr := apiHandler.NewFilesHandler(nil, uc, nil)
 
 	random := uuid.NewString()
 	reqBody := []byte(`{
@@ -225,7 +368,7 @@ func TestUpdateFileSuccess(t *testing.T) {
 
 func TestUpdateFileNotFound(t *testing.T) {
 	uc := &updateUseCaseMock{shouldThrowNotFound: true}
-	ctr := apiHandler.NewFilesHandler(nil, uc)
+	ctr := apiHandler.NewFilesHandler(nil, uc, nil)
 
 	random := uuid.NewString()
 	reqBody := []byte(`{
@@ -249,7 +392,7 @@ func TestUpdateFileNotFound(t *testing.T) {
 
 func TestUpdateFileInternalServerError(t *testing.T) {
 	uc := &updateUseCaseMock{shouldThrowError: true}
-	ctr := apiHandler.NewFilesHandler(nil, uc)
+	ctr := apiHandler.NewFilesHandler(nil, uc, nil)
 
 	random := uuid.NewString()
 	reqBody := []byte(`{
@@ -271,9 +414,26 @@ func TestUpdateFileInternalServerError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, rr.Code)
 }
 
+func TestUpdateFileLocked(t *testing.T) {
+	uc := &updateUseCaseMock{shouldThrowLocked: true}
+	ctr := apiHandler.NewFilesHandler(nil, uc, nil)
+
+	req, _ := http.NewRequest("PUT", "/files/"+uuid.NewString(), bytes.NewBufferString(`{"filename": "budget.xlsx"}`))
+	req.Header.Set("Content-Type", "application/json")
+	ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(ctr.Update).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusLocked, rr.Code)
+}
+
 type updateUseCaseMock struct {
 	shouldThrowError    bool
 	shouldThrowNotFound bool
+	shouldThrowLocked   bool
 }
 
 func (c *updateUseCaseMock) Execute(ctx context.Context, file *entity.File) (fileMetadata *entity.File, err error) {
@@ -285,6 +445,10 @@ func (c *updateUseCaseMock) Execute(ctx context.Context, file *entity.File) (fil
 		return nil, repository.ErrFileDoesNotExists
 	}
 
+	if c.shouldThrowLocked {
+		return nil, repository.ErrFileLocked
+	}
+
 	return createFileMetadataLookup(file.FileId), nil
 }
 
@@ -307,3 +471,301 @@ func createFileMetadataLookup(id string) *entity.File {
 		UpdatedBy: &[]string{uuid.NewString()}[0],
 	}
 }
+
+func TestMoveFile(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	createReq := func(fileId string, body []byte) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", fileId)
+
+		req, _ := http.NewRequest("POST", "/files/"+fileId+"/move", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("should move file into folder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		file := createFileMetadataLookup(random)
+		file.ParentId = "folderId"
+		ff.EXPECT().Move("test-trace-id", "userId", []string{}, random, "folderId").Return(file, nil)
+
+		ctr := apiHandler.NewFilesHandler(ff, nil, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Move).ServeHTTP(rr, createReq(random, []byte(`{"folderId": "folderId"}`)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return forbidden when requester cannot edit file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().Move("test-trace-id", "userId", []string{}, random, "").Return(nil, facade.ErrNotFileEditor)
+
+		ctr := apiHandler.NewFilesHandler(ff, nil, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Move).ServeHTTP(rr, createReq(random, []byte(`{}`)))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return locked when file is locked by another user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().Move("test-trace-id", "userId", []string{}, random, "").Return(nil, repository.ErrFileLocked)
+
+		ctr := apiHandler.NewFilesHandler(ff, nil, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Move).ServeHTTP(rr, createReq(random, []byte(`{}`)))
+
+		assert.Equal(t, http.StatusLocked, rr.Code)
+	})
+
+	t.Run("should return not found when folder does not exists", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().Move("test-trace-id", "userId", []string{}, random, "folderId").Return(nil, repository.ErrFolderDoesNotExists)
+
+		ctr := apiHandler.NewFilesHandler(ff, nil, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Move).ServeHTTP(rr, createReq(random, []byte(`{"folderId": "folderId"}`)))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
+func TestCopyFile(t *testing.T) {
+	createReq := func(fileId string, body []byte) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", fileId)
+
+		req, _ := http.NewRequest("POST", "/files/"+fileId+"/copy", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("should copy file", func(t *testing.T) {
+		uc := &copyUseCaseMock{}
+		ctr := apiHandler.NewFilesHandler(nil, nil, uc)
+
+		random := uuid.NewString()
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Copy).ServeHTTP(rr, createReq(random, []byte(`{"folderId": "folderId", "filename": "copy.pdf"}`)))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, random, uc.fileId)
+		assert.Equal(t, "folderId", uc.folderId)
+		assert.Equal(t, "copy.pdf", uc.filename)
+	})
+
+	t.Run("should return bad request when there is no space available", func(t *testing.T) {
+		ctr := apiHandler.NewFilesHandler(nil, nil, &copyUseCaseMock{err: usecase.ErrNotAvailableSpace})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Copy).ServeHTTP(rr, createReq(uuid.NewString(), []byte(`{}`)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return forbidden when requester cannot edit file", func(t *testing.T) {
+		ctr := apiHandler.NewFilesHandler(nil, nil, &copyUseCaseMock{err: facade.ErrNotFileEditor})
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Copy).ServeHTTP(rr, createReq(uuid.NewString(), []byte(`{}`)))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+type copyUseCaseMock struct {
+	err      error
+	fileId   string
+	folderId string
+	filename string
+}
+
+func (c *copyUseCaseMock) Execute(ctx context.Context, fileId string, folderId string, filename string) (*entity.File, error) {
+	c.fileId = fileId
+	c.folderId = folderId
+	c.filename = filename
+
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return entity.NewFile(filename, 7, false, "userId"), nil
+}
+
+func TestUpdateMetadata(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	createReq := func(body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "fileId")
+
+		req, _ := http.NewRequest("PUT", "/files/fileId/metadata", bytes.NewBufferString(body))
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		metadata := map[string]string{"camera": "X100"}
+
+		ff.EXPECT().UpdateMetadata("test-trace-id", "userId", []string{}, "fileId", metadata).
+			Return(&entity.File{FileId: "fileId", Metadata: metadata}, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewFilesHandler(ff, nil, nil).UpdateMetadata).ServeHTTP(rr, createReq(`{"camera":"X100"}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"metadata":{"camera":"X100"}`)
+	})
+
+	t.Run("should return bad request when key is empty", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewFilesHandler(nil, nil, nil).UpdateMetadata).ServeHTTP(rr, createReq(`{"":"X100"}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return unprocessable entity when values are not strings", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewFilesHandler(nil, nil, nil).UpdateMetadata).ServeHTTP(rr, createReq(`{"pages":3}`))
+
+		assert.Equal(t, http.StatusUnprocessableEntity, rr.Code)
+	})
+
+	t.Run("should return forbidden when requester cannot edit file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().UpdateMetadata("test-trace-id", "userId", []string{}, "fileId", map[string]string{}).Return(nil, facade.ErrNotFileEditor)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewFilesHandler(ff, nil, nil).UpdateMetadata).ServeHTTP(rr, createReq(`{}`))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
+
+func TestGetAllStarredFilesSuccess(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	mockCtrl := gomock.NewController(t)
+
+	ff := mocks.NewMockFileFacade(mockCtrl)
+
+	ff.EXPECT().FindAll(gomock.Any(), gomock.Any(), gomock.Any(), 0, 0, "", false, "", "", map[string]string{}, true).Return(&entity.FilePage{
+		Content: []*entity.File{{FileId: "fileId", Starred: true}},
+		Count:   1,
+	}, nil)
+
+	ctr := apiHandler.NewFilesHandler(ff, nil, nil)
+
+	req, _ := http.NewRequest("GET", "/files?starred=true", nil)
+	ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+	ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+	req = req.WithContext(ctx)
+
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(ctr.ListFiles).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"starred":true`)
+}
+
+func TestStarFile(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	createReq := func(method string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "fileId")
+
+		req, _ := http.NewRequest(method, "/files/fileId/star", nil)
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().Star("test-trace-id", "userId", []string{}, "fileId").Return(nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewFilesHandler(ff, nil, nil).Star).ServeHTTP(rr, createReq("PUT"))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("should return not found when file is not visible to requester", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().Star("test-trace-id", "userId", []string{}, "fileId").Return(repository.ErrFileDoesNotExists)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewFilesHandler(ff, nil, nil).Star).ServeHTTP(rr, createReq("PUT"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should unstar file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().Unstar("test-trace-id", "userId", "fileId").Return(nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewFilesHandler(ff, nil, nil).Unstar).ServeHTTP(rr, createReq("DELETE"))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("should return internal server error when unstar fails", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().Unstar("test-trace-id", "userId", "fileId").Return(errors.New("generic error"))
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewFilesHandler(ff, nil, nil).Unstar).ServeHTTP(rr, createReq("DELETE"))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/mapper"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/validator"
+)
+
+type FoldersHandler interface {
+	ListRoot(w http.ResponseWriter, r *http.Request)
+	ListChildren(w http.ResponseWriter, r *http.Request)
+	FindById(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Rename(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+}
+
+type foldersHandler struct {
+	folderFacade facade.FolderFacade
+}
+
+func NewFoldersHandler(folderFacade facade.FolderFacade) FoldersHandler {
+	return &foldersHandler{folderFacade: folderFacade}
+}
+
+func (f *foldersHandler) ListRoot(w http.ResponseWriter, r *http.Request) {
+	f.listChildren(w, r, "")
+}
+
+func (f *foldersHandler) ListChildren(w http.ResponseWriter, r *http.Request) {
+	f.listChildren(w, r, chi.URLParam(r, "id"))
+}
+
+func (f *foldersHandler) listChildren(w http.ResponseWriter, r *http.Request, folderId string) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	folders, err := f.folderFacade.FindChildren(traceId, user.Subject(), folderId)
+
+	if err != nil {
+		handleFolderError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapFoldersResponse(folders), traceId)
+}
+
+func (f *foldersHandler) FindById(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	folder, err := f.folderFacade.FindById(traceId, user.Subject(), chi.URLParam(r, "id"))
+
+	if err != nil {
+		handleFolderError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapFolderResponse(folder), traceId)
+}
+
+func (f *foldersHandler) Create(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.CreateFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return
+	}
+
+	if err := validator.ValidateCreateFolderRequest(&req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return
+	}
+
+	folder, err := f.folderFacade.Create(traceId, user.Subject(), req.Name, req.ParentId)
+
+	if err != nil {
+		handleFolderError(w, err, traceId)
+		return
+	}
+
+	response.Created(w, mapper.MapFolderResponse(folder), traceId)
+}
+
+func (f *foldersHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.UpdateFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return
+	}
+
+	if err := validator.ValidateUpdateFolderRequest(&req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return
+	}
+
+	folder, err := f.folderFacade.Rename(traceId, user.Subject(), chi.URLParam(r, "id"), req.Name)
+
+	if err != nil {
+		handleFolderError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapFolderResponse(folder), traceId)
+}
+
+func (f *foldersHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	if err := f.folderFacade.Delete(traceId, user.Subject(), chi.URLParam(r, "id")); err != nil {
+		handleFolderError(w, err, traceId)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleFolderError(w http.ResponseWriter, err error, traceId string) {
+	switch err {
+	case repository.ErrFolderDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrFolderNotEmpty:
+		response.BadRequest(w, model.ErrorResponse{Message: err.Error()}, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
@@ -0,0 +1,157 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	apiHandler "github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFolders(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	createReq := func(method string, folderId string, body []byte) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", folderId)
+
+		req, _ := http.NewRequest(method, "/folders", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("should list root folders", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFolderFacade(mockCtrl)
+
+		ff.EXPECT().FindChildren("test-trace-id", "userId", "").Return([]*entity.Folder{entity.NewFolder("photos", "", "userId")}, nil)
+
+		ctr := apiHandler.NewFoldersHandler(ff)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ListRoot).ServeHTTP(rr, createReq("GET", "", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return not found when listing children of unknown folder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFolderFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().FindChildren("test-trace-id", "userId", random).Return(nil, repository.ErrFolderDoesNotExists)
+
+		ctr := apiHandler.NewFoldersHandler(ff)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ListChildren).ServeHTTP(rr, createReq("GET", random, nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should find folder with its path", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFolderFacade(mockCtrl)
+
+		folder := entity.NewFolder("2024", uuid.NewString(), "userId")
+		folder.Path = []*entity.Breadcrumb{{FolderId: folder.ParentId, Name: "photos"}, {FolderId: folder.FolderId, Name: "2024"}}
+
+		ff.EXPECT().FindById("test-trace-id", "userId", folder.FolderId).Return(folder, nil)
+
+		ctr := apiHandler.NewFoldersHandler(ff)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.FindById).ServeHTTP(rr, createReq("GET", folder.FolderId, nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"name":"photos"`)
+	})
+
+	t.Run("should create folder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFolderFacade(mockCtrl)
+
+		parentId := uuid.NewString()
+		ff.EXPECT().Create("test-trace-id", "userId", "2024", parentId).Return(entity.NewFolder("2024", parentId, "userId"), nil)
+
+		ctr := apiHandler.NewFoldersHandler(ff)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"name": "2024", "parentId": "` + parentId + `"}`)
+		http.HandlerFunc(ctr.Create).ServeHTTP(rr, createReq("POST", "", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("should return bad request when folder name is empty", func(t *testing.T) {
+		ctr := apiHandler.NewFoldersHandler(nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Create).ServeHTTP(rr, createReq("POST", "", []byte(`{"name": " "}`)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should rename folder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFolderFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().Rename("test-trace-id", "userId", random, "2025").Return(entity.NewFolder("2025", "", "userId"), nil)
+
+		ctr := apiHandler.NewFoldersHandler(ff)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Rename).ServeHTTP(rr, createReq("PUT", random, []byte(`{"name": "2025"}`)))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should delete folder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFolderFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().Delete("test-trace-id", "userId", random).Return(nil)
+
+		ctr := apiHandler.NewFoldersHandler(ff)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Delete).ServeHTTP(rr, createReq("DELETE", random, nil))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("should return bad request when deleting folder that is not empty", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFolderFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().Delete("test-trace-id", "userId", random).Return(facade.ErrFolderNotEmpty)
+
+		ctr := apiHandler.NewFoldersHandler(ff)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Delete).ServeHTTP(rr, createReq("DELETE", random, nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
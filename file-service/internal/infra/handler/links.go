@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/mapper"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/validator"
+)
+
+type LinksHandler interface {
+	ListLinks(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+}
+
+type linksHandler struct {
+	linkFacade facade.LinkFacade
+}
+
+func NewLinksHandler(linkFacade facade.LinkFacade) LinksHandler {
+	return &linksHandler{linkFacade: linkFacade}
+}
+
+func (l *linksHandler) ListLinks(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	fileId := chi.URLParam(r, "id")
+
+	links, err := l.linkFacade.FindAll(traceId, user.Subject(), fileId)
+
+	if err != nil {
+		handleSharingError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapLinksResponse(links, r.Host), traceId)
+}
+
+func (l *linksHandler) Create(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.CreateLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return
+	}
+
+	if err := validator.ValidateCreateLinkRequest(&req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return
+	}
+
+	fileId := chi.URLParam(r, "id")
+
+	link, err := l.linkFacade.Create(traceId, user.Subject(), fileId, req.Password, req.ExpiresAt, req.MaxDownloads)
+
+	if err != nil {
+		handleSharingError(w, err, traceId)
+		return
+	}
+
+	response.Created(w, mapper.MapLinkResponse(link, r.Host), traceId)
+}
+
+func (l *linksHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	fileId := chi.URLParam(r, "id")
+	token := chi.URLParam(r, "token")
+
+	if err := l.linkFacade.Delete(traceId, user.Subject(), fileId, token); err != nil {
+		handleSharingError(w, err, traceId)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
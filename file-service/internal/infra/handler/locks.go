@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/validator"
+)
+
+type LocksHandler interface {
+	Lock(w http.ResponseWriter, r *http.Request)
+	Unlock(w http.ResponseWriter, r *http.Request)
+}
+
+type locksHandler struct {
+	config     *config.Config
+	fileFacade facade.FileFacade
+}
+
+func NewLocksHandler(config *config.Config, fileFacade facade.FileFacade) LocksHandler {
+	return &locksHandler{config: config, fileFacade: fileFacade}
+}
+
+func (l *locksHandler) Lock(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.LockFileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return
+	}
+
+	if err := validator.ValidateLockFileRequest(&req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return
+	}
+
+	lock, err := l.fileFacade.Lock(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"), l.lockDuration(req.Minutes))
+
+	switch err {
+	case nil:
+		response.Ok(w, lock, traceId)
+	case repository.ErrFileDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotFileEditor:
+		response.Forbidden(w, traceId)
+	case repository.ErrFileLocked:
+		response.Locked(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
+
+func (l *locksHandler) Unlock(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	err := l.fileFacade.Unlock(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"))
+
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusNoContent)
+	case repository.ErrFileDoesNotExists, repository.ErrLockDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotLockHolder:
+		response.Forbidden(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
+
+// lockDuration falls back to locks.default-minutes when none is requested and caps it at locks.max-minutes
+func (l *locksHandler) lockDuration(minutes int) time.Duration {
+	if minutes == 0 {
+		minutes = l.config.Locks.DefaultMinutes
+	}
+
+	if l.config.Locks.MaxMinutes > 0 && minutes > l.config.Locks.MaxMinutes {
+		minutes = l.config.Locks.MaxMinutes
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
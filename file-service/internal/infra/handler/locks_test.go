@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	apiHandler "github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestLocks(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	cfg := &config.Config{}
+	cfg.Locks.DefaultMinutes = 30
+	cfg.Locks.MaxMinutes = 60
+
+	createReq := func(method string, body string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "fileId")
+
+		req, _ := http.NewRequest(method, "/files/fileId/lock", bytes.NewBufferString(body))
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("should lock file for the default duration", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().Lock("test-trace-id", "userId", []string{}, "fileId", 30*time.Minute).
+			Return(entity.NewLock("fileId", "userId", 30*time.Minute), nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewLocksHandler(cfg, ff).Lock).ServeHTTP(rr, createReq("PUT", `{}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"lockedBy":"userId"`)
+	})
+
+	t.Run("should cap lock duration", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().Lock("test-trace-id", "userId", []string{}, "fileId", time.Hour).
+			Return(entity.NewLock("fileId", "userId", time.Hour), nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewLocksHandler(cfg, ff).Lock).ServeHTTP(rr, createReq("PUT", `{"minutes":600}`))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return bad request when minutes is negative", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewLocksHandler(cfg, nil).Lock).ServeHTTP(rr, createReq("PUT", `{"minutes":-1}`))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return locked when another user holds the lock", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().Lock("test-trace-id", "userId", []string{}, "fileId", 30*time.Minute).Return(nil, repository.ErrFileLocked)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewLocksHandler(cfg, ff).Lock).ServeHTTP(rr, createReq("PUT", `{}`))
+
+		assert.Equal(t, http.StatusLocked, rr.Code)
+	})
+
+	t.Run("should unlock file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().Unlock("test-trace-id", "userId", []string{}, "fileId").Return(nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewLocksHandler(cfg, ff).Unlock).ServeHTTP(rr, createReq("DELETE", ""))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("should return not found when file is not locked", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().Unlock("test-trace-id", "userId", []string{}, "fileId").Return(repository.ErrLockDoesNotExists)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewLocksHandler(cfg, ff).Unlock).ServeHTTP(rr, createReq("DELETE", ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return forbidden when requester neither holds the lock nor owns the file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().Unlock("test-trace-id", "userId", []string{}, "fileId").Return(facade.ErrNotLockHolder)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewLocksHandler(cfg, ff).Unlock).ServeHTTP(rr, createReq("DELETE", ""))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/validator"
+)
+
+type OwnershipHandler interface {
+	Transfer(w http.ResponseWriter, r *http.Request)
+	TransferAll(w http.ResponseWriter, r *http.Request)
+}
+
+type ownershipHandler struct {
+	transferUseCase    usecase.TransferOwnershipUseCase
+	transferAllUseCase usecase.TransferAllOwnershipUseCase
+}
+
+func NewOwnershipHandler(transferUseCase usecase.TransferOwnershipUseCase, transferAllUseCase usecase.TransferAllOwnershipUseCase) OwnershipHandler {
+	return &ownershipHandler{transferUseCase: transferUseCase, transferAllUseCase: transferAllUseCase}
+}
+
+func (o *ownershipHandler) Transfer(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	req, ok := decodeTransferOwnershipRequest(w, r, traceId)
+
+	if !ok {
+		return
+	}
+
+	fileId := chi.URLParam(r, "id")
+
+	fileMetadata, err := o.transferUseCase.Execute(r.Context(), fileId, req.UserId, req.KeepAsEditor)
+
+	if err != nil {
+		handleTransferError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, fileMetadata, traceId)
+}
+
+func (o *ownershipHandler) TransferAll(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	req, ok := decodeTransferOwnershipRequest(w, r, traceId)
+
+	if !ok {
+		return
+	}
+
+	ownerId := chi.URLParam(r, "userId")
+
+	transferred, err := o.transferAllUseCase.Execute(r.Context(), ownerId, req.UserId, req.KeepAsEditor)
+
+	if err != nil {
+		handleTransferError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, &model.TransferOwnershipResponse{Transferred: transferred}, traceId)
+}
+
+func decodeTransferOwnershipRequest(w http.ResponseWriter, r *http.Request, traceId string) (*model.TransferOwnershipRequest, bool) {
+	var req model.TransferOwnershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return nil, false
+	}
+
+	if err := validator.ValidateTransferOwnershipRequest(&req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return nil, false
+	}
+
+	return &req, true
+}
+
+func handleTransferError(w http.ResponseWriter, err error, traceId string) {
+	switch err {
+	case repository.ErrFileDoesNotExists:
+		response.NotFound(w, traceId)
+	case usecase.ErrNotFileOwner:
+		response.Forbidden(w, traceId)
+	case usecase.ErrAlreadyOwner, usecase.ErrRecipientNotAvailableSpace:
+		response.BadRequest(w, model.ErrorResponse{Message: err.Error()}, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
@@ -0,0 +1,136 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferOwnership(t *testing.T) {
+	createReq := func(fileId string, body []byte) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", fileId)
+
+		req, _ := http.NewRequest("PUT", "/files/"+fileId+"/owner", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		ctr := handler.NewOwnershipHandler(&transferUseCaseMock{}, nil)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"userId": "recipientId", "keepAsEditor": true}`)
+		http.HandlerFunc(ctr.Transfer).ServeHTTP(rr, createReq(uuid.NewString(), body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return bad request when recipient is missing", func(t *testing.T) {
+		ctr := handler.NewOwnershipHandler(&transferUseCaseMock{}, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Transfer).ServeHTTP(rr, createReq(uuid.NewString(), []byte(`{}`)))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return forbidden when requester is not the owner", func(t *testing.T) {
+		ctr := handler.NewOwnershipHandler(&transferUseCaseMock{err: usecase.ErrNotFileOwner}, nil)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"userId": "recipientId"}`)
+		http.HandlerFunc(ctr.Transfer).ServeHTTP(rr, createReq(uuid.NewString(), body))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return not found when file does not exists", func(t *testing.T) {
+		ctr := handler.NewOwnershipHandler(&transferUseCaseMock{err: repository.ErrFileDoesNotExists}, nil)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"userId": "recipientId"}`)
+		http.HandlerFunc(ctr.Transfer).ServeHTTP(rr, createReq(uuid.NewString(), body))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return bad request when recipient has no space available", func(t *testing.T) {
+		ctr := handler.NewOwnershipHandler(&transferUseCaseMock{err: usecase.ErrRecipientNotAvailableSpace}, nil)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"userId": "recipientId"}`)
+		http.HandlerFunc(ctr.Transfer).ServeHTTP(rr, createReq(uuid.NewString(), body))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestTransferAllOwnership(t *testing.T) {
+	createReq := func(userId string, body []byte) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("userId", userId)
+
+		req, _ := http.NewRequest("POST", "/admin/users/"+userId+"/transfer", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		ctr := handler.NewOwnershipHandler(nil, &transferAllUseCaseMock{transferred: 3})
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"userId": "recipientId"}`)
+		http.HandlerFunc(ctr.TransferAll).ServeHTTP(rr, createReq("ownerId", body))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.JSONEq(t, `{"transferred": 3}`, rr.Body.String())
+	})
+
+	t.Run("should return internal server error when transfer fails", func(t *testing.T) {
+		ctr := handler.NewOwnershipHandler(nil, &transferAllUseCaseMock{err: errors.New("generic error")})
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"userId": "recipientId"}`)
+		http.HandlerFunc(ctr.TransferAll).ServeHTTP(rr, createReq("ownerId", body))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+type transferUseCaseMock struct {
+	err error
+}
+
+func (c *transferUseCaseMock) Execute(ctx context.Context, fileId string, recipientId string, keepAsEditor bool) (*entity.File, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+
+	return &entity.File{FileId: fileId, Owner: recipientId}, nil
+}
+
+type transferAllUseCaseMock struct {
+	transferred int64
+	err         error
+}
+
+func (c *transferAllUseCaseMock) Execute(ctx context.Context, ownerId string, recipientId string, keepAsEditor bool) (int64, error) {
+	return c.transferred, c.err
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/mapper"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/validator"
+)
+
+type PermissionsHandler interface {
+	ListPermissions(w http.ResponseWriter, r *http.Request)
+	Grant(w http.ResponseWriter, r *http.Request)
+	Revoke(w http.ResponseWriter, r *http.Request)
+	RevokeGroup(w http.ResponseWriter, r *http.Request)
+}
+
+type permissionsHandler struct {
+	fileFacade facade.FileFacade
+}
+
+func NewPermissionsHandler(fileFacade facade.FileFacade) PermissionsHandler {
+	return &permissionsHandler{fileFacade: fileFacade}
+}
+
+func (p *permissionsHandler) ListPermissions(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	fileId := chi.URLParam(r, "id")
+
+	file, err := p.fileFacade.FindPermissions(traceId, user.Subject(), fileId)
+
+	if err != nil {
+		handleSharingError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapFilePermissionsResponse(file), traceId)
+}
+
+func (p *permissionsHandler) Grant(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.CreatePermissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return
+	}
+
+	if err := validator.ValidateCreatePermissionRequest(&req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return
+	}
+
+	fileId := chi.URLParam(r, "id")
+
+	var file *entity.File
+	var err error
+
+	if req.Group != "" {
+		file, err = p.fileFacade.GrantGroupPermission(traceId, user.Subject(), fileId, req.Group, req.Permission)
+	} else {
+		file, err = p.fileFacade.GrantPermission(traceId, user.Subject(), fileId, req.UserId, req.Permission)
+	}
+
+	if err != nil {
+		handleSharingError(w, err, traceId)
+		return
+	}
+
+	response.Created(w, mapper.MapFilePermissionsResponse(file), traceId)
+}
+
+func (p *permissionsHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	fileId := chi.URLParam(r, "id")
+	userId := chi.URLParam(r, "userId")
+
+	if err := p.fileFacade.RevokePermission(traceId, user.Subject(), fileId, userId); err != nil {
+		handleSharingError(w, err, traceId)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (p *permissionsHandler) RevokeGroup(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	fileId := chi.URLParam(r, "id")
+
+	// group names usually carry the identity provider path (e.g. /family),
+	// so clients are expected to send them url encoded
+	group, err := url.PathUnescape(chi.URLParam(r, "group"))
+
+	if err != nil {
+		response.BadRequest(w, model.ErrorResponse{Message: err.Error()}, traceId)
+		return
+	}
+
+	if err := p.fileFacade.RevokeGroupPermission(traceId, user.Subject(), fileId, group); err != nil {
+		handleSharingError(w, err, traceId)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleSharingError(w http.ResponseWriter, err error, traceId string) {
+	switch err {
+	case repository.ErrFileDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotFileOwner:
+		response.Forbidden(w, traceId)
+	case facade.ErrSecretFileCannotBeShared, facade.ErrCannotGrantOwner:
+		response.BadRequest(w, model.ErrorResponse{Message: err.Error()}, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
@@ -0,0 +1,192 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	apiHandler "github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPermissions(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	createReq := func(method string, fileId string, userId string, body []byte) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", fileId)
+		rctx.URLParams.Add("userId", userId)
+
+		req, _ := http.NewRequest(method, "/files/"+fileId+"/permissions", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("should list file permissions", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().FindPermissions("test-trace-id", "userId", random).Return(createFileMetadataLookup(random), nil)
+
+		ctr := apiHandler.NewPermissionsHandler(ff)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ListPermissions).ServeHTTP(rr, createReq("GET", random, "", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return not found when listing permissions of unknown file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().FindPermissions("test-trace-id", "userId", random).Return(nil, repository.ErrFileDoesNotExists)
+
+		ctr := apiHandler.NewPermissionsHandler(ff)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ListPermissions).ServeHTTP(rr, createReq("GET", random, "", nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return forbidden when a viewer lists permissions", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().FindPermissions("test-trace-id", "userId", random).Return(nil, facade.ErrNotFileOwner)
+
+		ctr := apiHandler.NewPermissionsHandler(ff)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ListPermissions).ServeHTTP(rr, createReq("GET", random, "", nil))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should grant permission", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().GrantPermission("test-trace-id", "userId", random, "otherUser", "VIEWER").Return(createFileMetadataLookup(random), nil)
+
+		ctr := apiHandler.NewPermissionsHandler(ff)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"userId": "otherUser", "permission": "VIEWER"}`)
+		http.HandlerFunc(ctr.Grant).ServeHTTP(rr, createReq("POST", random, "", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("should return bad request when permission is invalid", func(t *testing.T) {
+		ctr := apiHandler.NewPermissionsHandler(nil)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"userId": "otherUser", "permission": "OWNER"}`)
+		http.HandlerFunc(ctr.Grant).ServeHTTP(rr, createReq("POST", uuid.NewString(), "", body))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return bad request when file is secret", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().GrantPermission("test-trace-id", "userId", random, "otherUser", "EDITOR").Return(nil, facade.ErrSecretFileCannotBeShared)
+
+		ctr := apiHandler.NewPermissionsHandler(ff)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"userId": "otherUser", "permission": "EDITOR"}`)
+		http.HandlerFunc(ctr.Grant).ServeHTTP(rr, createReq("POST", random, "", body))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return forbidden when requester is not the owner", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().GrantPermission("test-trace-id", "userId", random, "otherUser", "EDITOR").Return(nil, facade.ErrNotFileOwner)
+
+		ctr := apiHandler.NewPermissionsHandler(ff)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"userId": "otherUser", "permission": "EDITOR"}`)
+		http.HandlerFunc(ctr.Grant).ServeHTTP(rr, createReq("POST", random, "", body))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should revoke permission", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().RevokePermission("test-trace-id", "userId", random, "otherUser").Return(nil)
+
+		ctr := apiHandler.NewPermissionsHandler(ff)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Revoke).ServeHTTP(rr, createReq("DELETE", random, "otherUser", nil))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("should grant permission to group", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().GrantGroupPermission("test-trace-id", "userId", random, "/family", "VIEWER").Return(createFileMetadataLookup(random), nil)
+
+		ctr := apiHandler.NewPermissionsHandler(ff)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"group": "/family", "permission": "VIEWER"}`)
+		http.HandlerFunc(ctr.Grant).ServeHTTP(rr, createReq("POST", random, "", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+	})
+
+	t.Run("should revoke group permission with url encoded group name", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().RevokeGroupPermission("test-trace-id", "userId", random, "/family").Return(nil)
+
+		ctr := apiHandler.NewPermissionsHandler(ff)
+
+		req := createReq("DELETE", random, "", nil)
+		chi.RouteContext(req.Context()).URLParams.Add("group", "%2Ffamily")
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.RevokeGroup).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+)
+
+const linkPasswordRealm = `Basic realm="raspstore", charset="UTF-8"`
+
+type PublicHandler interface {
+	Download(w http.ResponseWriter, r *http.Request)
+}
+
+type publicHandler struct {
+	downloadUseCase usecase.DownloadFileUseCase
+	linkFacade      facade.LinkFacade
+}
+
+func NewPublicHandler(downloadUseCase usecase.DownloadFileUseCase, linkFacade facade.LinkFacade) PublicHandler {
+	return &publicHandler{downloadUseCase: downloadUseCase, linkFacade: linkFacade}
+}
+
+func (h *publicHandler) Download(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	// password protected links are answered with a basic auth challenge,
+	// so browsers prompt for the password without any extra UI
+	_, password, _ := r.BasicAuth()
+
+	fileRep, err := h.linkFacade.Redeem(traceId, token, password)
+
+	if err == repository.ErrLinkDoesNotExists {
+		response.NotFound(w, traceId)
+		return
+	}
+
+	if err == facade.ErrLinkUnavailable {
+		response.Gone(w, traceId)
+		return
+	}
+
+	if err == facade.ErrInvalidLinkPassword {
+		w.Header().Set("WWW-Authenticate", linkPasswordRealm)
+		response.Unauthorized(w)
+		return
+	}
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	file, err := h.downloadUseCase.Execute(r.Context(), fileRep.FileId)
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	defer file.Close()
+
+	serveFile(w, r, fileRep, file)
+}
@@ -0,0 +1,123 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	chim "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestPublicDownload(t *testing.T) {
+	createReq := func() (req *http.Request) {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("token", "link-token")
+
+		req, _ = http.NewRequest("GET", "/file-service/v1/public/link-token", nil)
+		ctx := context.WithValue(req.Context(), chim.RequestIDKey, "trace-id")
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		lf := mocks.NewMockLinkFacade(mockCtrl)
+
+		lf.EXPECT().Redeem("trace-id", "link-token", "").Return(&entity.File{
+			FileId:   "4e2bc94b-a6b6-4c44-9512-79b5eb654524",
+			Filename: testFilename,
+			Size:     12,
+		}, nil)
+
+		ctr := handler.NewPublicHandler(&downloadFileUseCaseMock{}, lf)
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(ctr.Download).ServeHTTP(rr, createReq())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/octet-stream", rr.Header().Get("Content-Type"))
+	})
+
+	t.Run("should send password from basic auth", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		lf := mocks.NewMockLinkFacade(mockCtrl)
+
+		lf.EXPECT().Redeem("trace-id", "link-token", "secret-password").Return(&entity.File{
+			FileId:   "4e2bc94b-a6b6-4c44-9512-79b5eb654524",
+			Filename: testFilename,
+			Size:     12,
+		}, nil)
+
+		ctr := handler.NewPublicHandler(&downloadFileUseCaseMock{}, lf)
+
+		req := createReq()
+		req.SetBasicAuth("", "secret-password")
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(ctr.Download).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return UNAUTHORIZED with challenge when password does not match", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		lf := mocks.NewMockLinkFacade(mockCtrl)
+
+		lf.EXPECT().Redeem("trace-id", "link-token", "").Return(nil, facade.ErrInvalidLinkPassword)
+
+		ctr := handler.NewPublicHandler(&downloadFileUseCaseMock{}, lf)
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(ctr.Download).ServeHTTP(rr, createReq())
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+		assert.NotEmpty(t, rr.Header().Get("WWW-Authenticate"))
+	})
+
+	t.Run("should return GONE when link is expired or exhausted", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		lf := mocks.NewMockLinkFacade(mockCtrl)
+
+		lf.EXPECT().Redeem("trace-id", "link-token", "").Return(nil, facade.ErrLinkUnavailable)
+
+		ctr := handler.NewPublicHandler(&downloadFileUseCaseMock{}, lf)
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(ctr.Download).ServeHTTP(rr, createReq())
+
+		assert.Equal(t, http.StatusGone, rr.Code)
+	})
+
+	t.Run("should return NOT FOUND when link does not exists", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+
+		lf := mocks.NewMockLinkFacade(mockCtrl)
+
+		lf.EXPECT().Redeem("trace-id", "link-token", "").Return(nil, repository.ErrLinkDoesNotExists)
+
+		ctr := handler.NewPublicHandler(&downloadFileUseCaseMock{}, lf)
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(ctr.Download).ServeHTTP(rr, createReq())
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
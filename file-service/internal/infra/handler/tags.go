@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/validator"
+)
+
+type TagsHandler interface {
+	ListTags(w http.ResponseWriter, r *http.Request)
+	Add(w http.ResponseWriter, r *http.Request)
+	Remove(w http.ResponseWriter, r *http.Request)
+}
+
+type tagsHandler struct {
+	fileFacade facade.FileFacade
+}
+
+func NewTagsHandler(fileFacade facade.FileFacade) TagsHandler {
+	return &tagsHandler{fileFacade: fileFacade}
+}
+
+func (t *tagsHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	tags, err := t.fileFacade.FindAllTags(traceId, user.Subject(), m.UserGroups(user))
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	response.Ok(w, tags, traceId)
+}
+
+func (t *tagsHandler) Add(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.AddTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return
+	}
+
+	if err := validator.ValidateAddTagRequest(&req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return
+	}
+
+	file, err := t.fileFacade.AddTag(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"), req.Name)
+
+	switch err {
+	case nil:
+		response.Ok(w, file, traceId)
+	case repository.ErrFileDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotFileEditor:
+		response.Forbidden(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
+
+func (t *tagsHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	tag, err := url.PathUnescape(chi.URLParam(r, "tag"))
+
+	if err != nil {
+		response.BadRequest(w, model.ErrorResponse{Message: err.Error()}, traceId)
+		return
+	}
+
+	err = t.fileFacade.RemoveTag(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"), tag)
+
+	switch err {
+	case nil:
+		w.WriteHeader(http.StatusNoContent)
+	case repository.ErrFileDoesNotExists, repository.ErrTagDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotFileEditor:
+		response.Forbidden(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
@@ -0,0 +1,122 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	apiHandler "github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTags(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	createReq := func(method string, body string, tag string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "fileId")
+		rctx.URLParams.Add("tag", tag)
+
+		req, _ := http.NewRequest(method, "/files/fileId/tags", bytes.NewBufferString(body))
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("should list tags", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().FindAllTags("test-trace-id", "userId", []string{}).Return([]*entity.Tag{{Name: "holidays", Files: 3}}, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTagsHandler(ff).ListTags).ServeHTTP(rr, createReq("GET", "", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"files":3`)
+	})
+
+	t.Run("should add tag", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().AddTag("test-trace-id", "userId", []string{}, "fileId", "holidays").
+			Return(&entity.File{FileId: "fileId", Tags: []string{"holidays"}}, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTagsHandler(ff).Add).ServeHTTP(rr, createReq("POST", `{"name":"holidays"}`, ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"tags":["holidays"]`)
+	})
+
+	t.Run("should return bad request when tag name is empty", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTagsHandler(nil).Add).ServeHTTP(rr, createReq("POST", `{"name":""}`, ""))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should return forbidden when requester cannot edit file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().AddTag("test-trace-id", "userId", []string{}, "fileId", "holidays").Return(nil, facade.ErrNotFileEditor)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTagsHandler(ff).Add).ServeHTTP(rr, createReq("POST", `{"name":"holidays"}`, ""))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should remove tag", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().RemoveTag("test-trace-id", "userId", []string{}, "fileId", "summer holidays").Return(nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTagsHandler(ff).Remove).ServeHTTP(rr, createReq("DELETE", "", "summer%20holidays"))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("should return not found when tag is not attached", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().RemoveTag("test-trace-id", "userId", []string{}, "fileId", "holidays").Return(repository.ErrTagDoesNotExists)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTagsHandler(ff).Remove).ServeHTTP(rr, createReq("DELETE", "", "holidays"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return internal server error when listing fails", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().FindAllTags("test-trace-id", "userId", []string{}).Return(nil, errors.New("generic error"))
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTagsHandler(ff).ListTags).ServeHTTP(rr, createReq("GET", "", ""))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
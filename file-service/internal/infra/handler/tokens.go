@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/mapper"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/validator"
+)
+
+type TokensHandler interface {
+	ListTokens(w http.ResponseWriter, r *http.Request)
+	Create(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+}
+
+type tokensHandler struct {
+	accessTokenFacade facade.AccessTokenFacade
+}
+
+func NewTokensHandler(accessTokenFacade facade.AccessTokenFacade) TokensHandler {
+	return &tokensHandler{accessTokenFacade: accessTokenFacade}
+}
+
+func (t *tokensHandler) ListTokens(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	tokens, err := t.accessTokenFacade.FindAll(traceId, user.Subject())
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapAccessTokensResponse(tokens), traceId)
+}
+
+func (t *tokensHandler) Create(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	var req model.CreateAccessTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.UnprocessableEntity(w, traceId)
+		return
+	}
+
+	if err := validator.ValidateCreateAccessTokenRequest(&req); err != nil {
+		response.BadRequest(w, model.ErrorResponse{
+			Message: err.Error(),
+		}, traceId)
+		return
+	}
+
+	token, err := t.accessTokenFacade.Create(traceId, user.Subject(), m.UserGroups(user), req.Name, req.Scope, req.ExpiresAt)
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	response.Created(w, mapper.MapAccessTokenResponse(token), traceId)
+}
+
+func (t *tokensHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	tokenId := chi.URLParam(r, "id")
+
+	err := t.accessTokenFacade.Delete(traceId, user.Subject(), tokenId)
+
+	if err == repository.ErrAccessTokenDoesNotExists {
+		response.NotFound(w, traceId)
+		return
+	}
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
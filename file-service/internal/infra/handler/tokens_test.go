@@ -0,0 +1,113 @@
+package handler_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	apiHandler "github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTokens(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	createReq := func(method string, tokenId string, body []byte) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", tokenId)
+
+		req, _ := http.NewRequest(method, "/tokens", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("should list access tokens", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAccessTokenFacade(mockCtrl)
+
+		af.EXPECT().FindAll("test-trace-id", "userId").Return([]*entity.AccessToken{{TokenId: uuid.NewString()}}, nil)
+
+		ctr := apiHandler.NewTokensHandler(af)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ListTokens).ServeHTTP(rr, createReq("GET", "", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should create access token and return it only once", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAccessTokenFacade(mockCtrl)
+
+		created, err := entity.NewAccessToken("userId", "backup-script", entity.ScopeReadOnly, []string{}, nil)
+		assert.NoError(t, err)
+
+		af.EXPECT().Create("test-trace-id", "userId", []string{}, "backup-script", "READ_ONLY", nil).Return(created, nil)
+
+		ctr := apiHandler.NewTokensHandler(af)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"name": "backup-script", "scope": "READ_ONLY"}`)
+		http.HandlerFunc(ctr.Create).ServeHTTP(rr, createReq("POST", "", body))
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Contains(t, rr.Body.String(), created.Token)
+		assert.NotContains(t, rr.Body.String(), created.TokenHash)
+	})
+
+	t.Run("should return bad request when scope is invalid", func(t *testing.T) {
+		ctr := apiHandler.NewTokensHandler(nil)
+
+		rr := httptest.NewRecorder()
+		body := []byte(`{"name": "backup-script", "scope": "ADMIN"}`)
+		http.HandlerFunc(ctr.Create).ServeHTTP(rr, createReq("POST", "", body))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should revoke access token", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAccessTokenFacade(mockCtrl)
+
+		random := uuid.NewString()
+		af.EXPECT().Delete("test-trace-id", "userId", random).Return(nil)
+
+		ctr := apiHandler.NewTokensHandler(af)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Delete).ServeHTTP(rr, createReq("DELETE", random, nil))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("should return not found when revoking unknown access token", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		af := mocks.NewMockAccessTokenFacade(mockCtrl)
+
+		random := uuid.NewString()
+		af.EXPECT().Delete("test-trace-id", "userId", random).Return(repository.ErrAccessTokenDoesNotExists)
+
+		ctr := apiHandler.NewTokensHandler(af)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.Delete).ServeHTTP(rr, createReq("DELETE", random, nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
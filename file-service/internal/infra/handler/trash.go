@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/mapper"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+)
+
+type TrashHandler interface {
+	ListTrash(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
+}
+
+type trashHandler struct {
+	fileFacade facade.FileFacade
+}
+
+func NewTrashHandler(fileFacade facade.FileFacade) TrashHandler {
+	return &trashHandler{fileFacade: fileFacade}
+}
+
+func (t *trashHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	filesPage, err := t.fileFacade.FindAllTrashed(traceId, user.Subject(), page, size)
+
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapTrashFilePageResponse(page, size, filesPage, r.Host), traceId)
+}
+
+func (t *trashHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	file, err := t.fileFacade.Restore(traceId, user.Subject(), chi.URLParam(r, "id"))
+
+	switch err {
+	case nil:
+		response.Ok(w, file, traceId)
+	case repository.ErrFileDoesNotExists:
+		response.NotFound(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
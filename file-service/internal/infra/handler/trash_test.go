@@ -0,0 +1,108 @@
+package handler_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	apiHandler "github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestListTrash(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	t.Run("happy path", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		file := createFileMetadataLookup(uuid.NewString())
+		deletedAt := time.Now()
+		file.DeletedAt = &deletedAt
+
+		ff.EXPECT().FindAllTrashed("test-trace-id", "userId", 0, 10).Return(&entity.FilePage{Content: []*entity.File{file}, Count: 1}, nil)
+
+		req, _ := http.NewRequest("GET", "/trash?size=10", nil)
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTrashHandler(ff).ListTrash).ServeHTTP(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "deletedAt")
+	})
+
+	t.Run("should return internal server error when facade fails", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().FindAllTrashed("test-trace-id", "userId", 0, 0).Return(nil, errors.New("generic error"))
+
+		req, _ := http.NewRequest("GET", "/trash", nil)
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTrashHandler(ff).ListTrash).ServeHTTP(rr, req.WithContext(ctx))
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
+func TestRestoreFile(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	createReq := func(fileId string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", fileId)
+
+		req, _ := http.NewRequest("POST", "/trash/"+fileId+"/restore", nil)
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().Restore("test-trace-id", "userId", random).Return(createFileMetadataLookup(random), nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTrashHandler(ff).Restore).ServeHTTP(rr, createReq(random))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("should return not found when file is not in trash", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		random := uuid.NewString()
+		ff.EXPECT().Restore("test-trace-id", "userId", random).Return(nil, repository.ErrFileDoesNotExists)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewTrashHandler(ff).Restore).ServeHTTP(rr, createReq(random))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -1,76 +1,219 @@
 package handler
 
 import (
+	"io"
 	"log/slog"
+	"mime/multipart"
 	"net/http"
-	"os"
+	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
-	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
 	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
 )
 
 type UploadHandler interface {
 	Upload(w http.ResponseWriter, r *http.Request)
+	ReplaceContent(w http.ResponseWriter, r *http.Request)
 }
 
 type uploadHandler struct {
-	config            *config.Config
-	uploadUseCase     usecase.UploadFileUseCase
-	createFileUseCase usecase.CreateFileUseCase
+	uploadUseCase usecase.UploadFileUseCase
+	fileFacade    facade.FileFacade
+	folderFacade  facade.FolderFacade
 }
 
-func NewUploadHandler(config *config.Config, uploadUseCase usecase.UploadFileUseCase, createFileUseCase usecase.CreateFileUseCase) UploadHandler {
-	return &uploadHandler{config: config, uploadUseCase: uploadUseCase, createFileUseCase: createFileUseCase}
+func NewUploadHandler(uploadUseCase usecase.UploadFileUseCase, fileFacade facade.FileFacade, folderFacade facade.FolderFacade) UploadHandler {
+	return &uploadHandler{
+		uploadUseCase: uploadUseCase,
+		fileFacade:    fileFacade,
+		folderFacade:  folderFacade,
+	}
 }
 
+// the longest value accepted for the form fields sent before the file part
+const maxUploadFieldSize = 1024
+
+// Upload streams the file part straight into storage. The fields it depends on, folderId and the size
+// to reserve, must be sent before the file part, which is the last one read
 func (h *uploadHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	usr := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
 	traceId := r.Context().Value(middleware.RequestIDKey).(string)
-	if err := r.ParseMultipartForm(32 << 20); err != nil {
-		slog.Error("Could not allocate MultipartForm parser", "traceId", traceId, "error", err)
-		response.UnprocessableEntity(w, traceId)
-		return
-	}
 
-	file, header, err := r.FormFile("file")
+	reader, err := r.MultipartReader()
 
 	if err != nil {
-		slog.Error("Could not open Multipart Form file", "traceId", traceId, "error", err)
+		slog.Error("Could not read multipart form", "traceId", traceId, "error", err)
 		response.UnprocessableEntity(w, traceId)
 		return
 	}
 
+	fields := make(map[string]string)
+
+	var file *multipart.Part
+
+	for file == nil {
+		part, err := reader.NextPart()
+
+		if err != nil {
+			slog.Error("Could not find file part in multipart form", "traceId", traceId, "error", err)
+			response.UnprocessableEntity(w, traceId)
+			return
+		}
+
+		if part.FormName() == "file" && part.FileName() != "" {
+			file = part
+			continue
+		}
+
+		// other parts are skipped without being stored
+		if part.FormName() != "size" && part.FormName() != "folderId" {
+			continue
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, maxUploadFieldSize+1))
+
+		if err != nil || len(value) > maxUploadFieldSize {
+			slog.Error("Could not read multipart form field", "traceId", traceId, "field", part.FormName(), "error", err)
+			response.UnprocessableEntity(w, traceId)
+			return
+		}
+
+		fields[part.FormName()] = string(value)
+	}
+
 	defer file.Close()
 
-	fm := entity.NewFile(header.Filename, header.Size, false, usr.Subject())
+	// the declared size is what gets reserved from the owner quota before streaming
+	if fields["size"] == "" {
+		response.LengthRequired(w, traceId)
+		return
+	}
 
-	if err := h.uploadUseCase.Execute(r.Context(), fm, file); err != nil {
+	size, err := strconv.ParseInt(fields["size"], 10, 64)
+
+	if err != nil || size < 0 {
+		response.BadRequest(w, model.ErrorResponse{Message: "size must be the size of the file in bytes"}, traceId)
+		return
+	}
+
+	folderId := fields["folderId"]
+
+	if folderId != "" {
+		_, err := h.folderFacade.FindById(traceId, usr.Subject(), folderId)
+
+		if err == repository.ErrFolderDoesNotExists {
+			response.NotFound(w, traceId)
+			return
+		}
+
+		if err != nil {
+			response.InternalServerError(w, traceId)
+			return
+		}
+	}
+
+	existing, err := h.fileFacade.FindByName(usr.Subject(), folderId, file.FileName())
+
+	if err != nil && err != repository.ErrFileDoesNotExists {
 		response.InternalServerError(w, traceId)
 		return
 	}
 
-	if err := h.createFileUseCase.Execute(fm); err != nil {
-		h.handleCreateUseCaseError(w, fm, traceId)
+	// upload only tokens are meant to add files, and replacing content could push every version out
+	if existing != nil && m.UserScope(usr) == entity.ScopeUploadOnly {
+		slog.Info("Refusing to replace existing file with upload only token", "traceId", traceId, "fileId", existing.FileId)
+		response.Forbidden(w, traceId)
+		return
+	}
+
+	fm := entity.NewFile(file.FileName(), size, false, usr.Subject())
+	fm.ParentId = folderId
+
+	// re-uploading a file to the same folder replaces its content and keeps the previous one as a version
+	uploaded, err := h.uploadUseCase.Execute(r.Context(), fm, file, existing)
+
+	if err != nil {
+		h.handleUploadError(w, err, traceId)
+		return
+	}
+
+	res := &model.UploadSuccessResponse{
+		FileId:   uploaded.FileId,
+		Filename: uploaded.Filename,
+		OwnerId:  uploaded.Owner,
+	}
+
+	if existing != nil {
+		response.Ok(w, res, traceId)
 		return
 	}
 
-	response.Created(w, &model.UploadSuccessResponse{
-		FileId:   fm.FileId,
-		Filename: fm.Filename,
-		OwnerId:  fm.Owner,
-	}, traceId)
+	response.Created(w, res, traceId)
 }
 
-func (h *uploadHandler) handleCreateUseCaseError(w http.ResponseWriter, file *entity.File, traceId string) {
-	if err := os.Remove(h.config.Storage.Path + "/storage/" + file.FileId); err != nil {
-		slog.Error("Could not remove file from fs", "fileId", file.FileId)
+func (h *uploadHandler) ReplaceContent(w http.ResponseWriter, r *http.Request) {
+	usr := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+	traceId := r.Context().Value(middleware.RequestIDKey).(string)
+
+	fileId := chi.URLParam(r, "id")
+
+	// the declared size is what gets reserved from the owner quota before streaming
+	if r.ContentLength < 0 {
+		response.LengthRequired(w, traceId)
+		return
+	}
+
+	// checked before streaming so a request that will be refused does not write the whole body to disk
+	existing, err := h.fileFacade.FindById(usr.Subject(), m.UserGroups(usr), fileId)
+
+	if err == repository.ErrFileDoesNotExists {
+		response.NotFound(w, traceId)
+		return
 	}
 
-	response.InternalServerError(w, traceId)
+	if err != nil {
+		response.InternalServerError(w, traceId)
+		return
+	}
+
+	if !existing.CanEdit(usr.Subject(), m.UserGroups(usr)) {
+		response.Forbidden(w, traceId)
+		return
+	}
+
+	defer r.Body.Close()
+
+	fm := entity.NewFile(existing.Filename, r.ContentLength, existing.Secret, usr.Subject())
+
+	file, err := h.uploadUseCase.Execute(r.Context(), fm, r.Body, existing)
+
+	if err != nil {
+		h.handleUploadError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, file, traceId)
+}
+
+func (h *uploadHandler) handleUploadError(w http.ResponseWriter, err error, traceId string) {
+	switch err {
+	case repository.ErrFileDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotFileEditor:
+		response.Forbidden(w, traceId)
+	case repository.ErrFileLocked:
+		response.Locked(w, traceId)
+	case usecase.ErrNotAvailableSpace, usecase.ErrUploadSizeMismatch:
+		response.BadRequest(w, model.ErrorResponse{Message: err.Error()}, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
 }
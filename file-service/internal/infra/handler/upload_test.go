@@ -12,24 +12,23 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
-	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
 	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 )
 
 const testFilename = "test.txt"
 const defaultUserId = "e9e28c79-a5e8-4545-bd32-e536e690bd4a"
 
 func TestUpload(t *testing.T) {
-	config := &config.Config{Storage: struct {
-		Path  string
-		Limit string
-	}{Path: "./"}}
-
 	token := jwt.New()
 	err := token.Set("sub", defaultUserId)
 	assert.NoError(t, err)
@@ -45,8 +44,7 @@ func TestUpload(t *testing.T) {
 
 	t.Run("happy path", func(t *testing.T) {
 		uploadUseCase := &uploadFileUseCaseMock{}
-		cFileUseCase := &createUseCaseMock{}
-		ctr := handler.NewUploadHandler(config, uploadUseCase, cFileUseCase)
+		ctr := handler.NewUploadHandler(uploadUseCase, newFileFacadeMock(t), nil)
 
 		tempFile, err := createTempFile()
 		assert.NoError(t, err)
@@ -57,6 +55,8 @@ func TestUpload(t *testing.T) {
 
 		body := &bytes.Buffer{}
 		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("size", "12"))
+
 		part, err := writer.CreateFormFile("file", filepath.Base(tempFile))
 		assert.NoError(t, err)
 
@@ -74,12 +74,39 @@ func TestUpload(t *testing.T) {
 		handler.ServeHTTP(rr, req)
 
 		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, int64(12), uploadUseCase.file.Size)
+		assert.Equal(t, "test content", uploadUseCase.content)
+	})
+
+	t.Run("should return length required when size is not sent before the file", func(t *testing.T) {
+		uploadUseCase := &uploadFileUseCaseMock{}
+		ctr := handler.NewUploadHandler(uploadUseCase, newFileFacadeMock(t), nil)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, err := writer.CreateFormFile("file", testFilename)
+		assert.NoError(t, err)
+
+		_, err = part.Write([]byte("test content"))
+		assert.NoError(t, err)
+
+		// fields after the file part are never read, the content would have to be buffered to get to them
+		assert.NoError(t, writer.WriteField("size", "12"))
+		assert.NoError(t, writer.Close())
+
+		req := createReq(body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(ctr.Upload).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusLengthRequired, rr.Code)
+		assert.Nil(t, uploadUseCase.file)
 	})
 
 	t.Run("should return bad request when form without file", func(t *testing.T) {
 		uploadUseCase := &uploadFileUseCaseMock{}
-		cFileUseCase := &createUseCaseMock{}
-		ctr := handler.NewUploadHandler(config, uploadUseCase, cFileUseCase)
+		ctr := handler.NewUploadHandler(uploadUseCase, newFileFacadeMock(t), nil)
 
 		body := &bytes.Buffer{}
 		writer := multipart.NewWriter(body)
@@ -99,8 +126,7 @@ func TestUpload(t *testing.T) {
 
 	t.Run("should return bad request when form without file", func(t *testing.T) {
 		uploadUseCase := &uploadFileUseCaseMock{}
-		cFileUseCase := &createUseCaseMock{}
-		ctr := handler.NewUploadHandler(config, uploadUseCase, cFileUseCase)
+		ctr := handler.NewUploadHandler(uploadUseCase, newFileFacadeMock(t), nil)
 
 		body := &bytes.Buffer{}
 		writer := multipart.NewWriter(body)
@@ -119,9 +145,8 @@ func TestUpload(t *testing.T) {
 	})
 
 	t.Run("should return internal server error when upload use case returns error", func(t *testing.T) {
-		uploadUseCase := &uploadFileUseCaseMock{shouldReturnError: true}
-		cFileUseCase := &createUseCaseMock{}
-		ctr := handler.NewUploadHandler(config, uploadUseCase, cFileUseCase)
+		uploadUseCase := &uploadFileUseCaseMock{err: errors.New("generic error")}
+		ctr := handler.NewUploadHandler(uploadUseCase, newFileFacadeMock(t), nil)
 
 		tempFile, err := createTempFile()
 		assert.NoError(t, err)
@@ -132,6 +157,8 @@ func TestUpload(t *testing.T) {
 
 		body := &bytes.Buffer{}
 		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("size", "12"))
+
 		part, err := writer.CreateFormFile("file", filepath.Base(tempFile))
 		assert.NoError(t, err)
 
@@ -151,10 +178,9 @@ func TestUpload(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rr.Code)
 	})
 
-	t.Run("should return internal server error when create use case returns error", func(t *testing.T) {
-		uploadUseCase := &uploadFileUseCaseMock{}
-		cFileUseCase := &createUseCaseMock{shouldReturnErr: true}
-		ctr := handler.NewUploadHandler(config, uploadUseCase, cFileUseCase)
+	t.Run("should return bad request when owner has not enough space", func(t *testing.T) {
+		uploadUseCase := &uploadFileUseCaseMock{err: usecase.ErrNotAvailableSpace}
+		ctr := handler.NewUploadHandler(uploadUseCase, newFileFacadeMock(t), nil)
 
 		tempFile, err := createTempFile()
 		assert.NoError(t, err)
@@ -165,6 +191,8 @@ func TestUpload(t *testing.T) {
 
 		body := &bytes.Buffer{}
 		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("size", "12"))
+
 		part, err := writer.CreateFormFile("file", filepath.Base(tempFile))
 		assert.NoError(t, err)
 
@@ -181,10 +209,237 @@ func TestUpload(t *testing.T) {
 		handler := http.HandlerFunc(ctr.Upload)
 		handler.ServeHTTP(rr, req)
 
-		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("should upload file into folder", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFolderFacade(mockCtrl)
+		ff.EXPECT().FindById(defaultUserId, defaultUserId, "folderId").Return(&entity.Folder{FolderId: "folderId"}, nil)
+
+		uploadUseCase := &uploadFileUseCaseMock{}
+		ctr := handler.NewUploadHandler(uploadUseCase, newFileFacadeMock(t), ff)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("size", "12"))
+		assert.NoError(t, writer.WriteField("folderId", "folderId"))
+
+		part, err := writer.CreateFormFile("file", testFilename)
+		assert.NoError(t, err)
+
+		_, err = part.Write([]byte("test content"))
+		assert.NoError(t, err)
+
+		assert.NoError(t, writer.Close())
+
+		req := createReq(body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(ctr.Upload).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, "folderId", uploadUseCase.file.ParentId)
+		assert.Equal(t, int64(len("test content")), uploadUseCase.file.Size)
+	})
+
+	t.Run("should return not found when folder does not exists", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFolderFacade(mockCtrl)
+		ff.EXPECT().FindById(defaultUserId, defaultUserId, "folderId").Return(nil, repository.ErrFolderDoesNotExists)
+
+		ctr := handler.NewUploadHandler(&uploadFileUseCaseMock{}, nil, ff)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("size", "12"))
+		assert.NoError(t, writer.WriteField("folderId", "folderId"))
+
+		part, err := writer.CreateFormFile("file", testFilename)
+		assert.NoError(t, err)
+
+		_, err = part.Write([]byte("test content"))
+		assert.NoError(t, err)
+
+		assert.NoError(t, writer.Close())
+
+		req := createReq(body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(ctr.Upload).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should create new version when file already exists", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fileFacade := mocks.NewMockFileFacade(mockCtrl)
+		fileFacade.EXPECT().FindByName(defaultUserId, "", testFilename).Return(&entity.File{FileId: "existingId"}, nil)
+
+		uploadUseCase := &uploadFileUseCaseMock{}
+		ctr := handler.NewUploadHandler(uploadUseCase, fileFacade, nil)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("size", "12"))
+
+		part, err := writer.CreateFormFile("file", testFilename)
+		assert.NoError(t, err)
+
+		_, err = part.Write([]byte("test content"))
+		assert.NoError(t, err)
+
+		assert.NoError(t, writer.Close())
+
+		req := createReq(body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(ctr.Upload).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "existingId", uploadUseCase.replaced.FileId)
+	})
+
+	t.Run("should return forbidden when upload only token would replace existing file", func(t *testing.T) {
+		scoped := jwt.New()
+		assert.NoError(t, scoped.Set("sub", defaultUserId))
+		assert.NoError(t, scoped.Set("raspstore_scope", entity.ScopeUploadOnly))
+
+		mockCtrl := gomock.NewController(t)
+		fileFacade := mocks.NewMockFileFacade(mockCtrl)
+		fileFacade.EXPECT().FindByName(defaultUserId, "", testFilename).Return(&entity.File{FileId: "existingId"}, nil)
+
+		uploadUseCase := &uploadFileUseCaseMock{}
+		ctr := handler.NewUploadHandler(uploadUseCase, fileFacade, nil)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("size", "12"))
+
+		part, err := writer.CreateFormFile("file", testFilename)
+		assert.NoError(t, err)
+
+		_, err = part.Write([]byte("test content"))
+		assert.NoError(t, err)
+
+		assert.NoError(t, writer.Close())
+
+		req := createReq(body)
+		req = req.WithContext(context.WithValue(req.Context(), m.UserClaimsCtxKey, scoped))
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(ctr.Upload).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+		assert.Nil(t, uploadUseCase.replaced)
 	})
 }
 
+func TestReplaceContent(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", defaultUserId)
+	assert.NoError(t, err)
+
+	createReq := func() *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", "fileId")
+
+		req, err := http.NewRequest("PUT", "/file-service/v1/files/fileId/content", bytes.NewBufferString("new content"))
+		assert.NoError(t, err)
+		ctx := context.WithValue(req.Context(), m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chiMiddleware.RequestIDKey, defaultUserId)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fileFacade := mocks.NewMockFileFacade(mockCtrl)
+		fileFacade.EXPECT().FindById(defaultUserId, []string{}, "fileId").Return(&entity.File{
+			FileId:  "fileId",
+			Owner:   "otherUser",
+			Editors: []string{defaultUserId},
+		}, nil)
+
+		uploadUseCase := &uploadFileUseCaseMock{}
+		ctr := handler.NewUploadHandler(uploadUseCase, fileFacade, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ReplaceContent).ServeHTTP(rr, createReq())
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "fileId", uploadUseCase.replaced.FileId)
+		assert.Equal(t, int64(len("new content")), uploadUseCase.file.Size)
+	})
+
+	t.Run("should return length required when body size is not declared", func(t *testing.T) {
+		uploadUseCase := &uploadFileUseCaseMock{}
+		ctr := handler.NewUploadHandler(uploadUseCase, nil, nil)
+
+		req := createReq()
+		req.ContentLength = -1
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ReplaceContent).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusLengthRequired, rr.Code)
+		assert.Nil(t, uploadUseCase.file)
+	})
+
+	t.Run("should return forbidden when requester is a viewer", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fileFacade := mocks.NewMockFileFacade(mockCtrl)
+		fileFacade.EXPECT().FindById(defaultUserId, []string{}, "fileId").Return(&entity.File{
+			FileId:  "fileId",
+			Owner:   "otherUser",
+			Viewers: []string{defaultUserId},
+		}, nil)
+
+		ctr := handler.NewUploadHandler(&uploadFileUseCaseMock{}, fileFacade, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ReplaceContent).ServeHTTP(rr, createReq())
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return not found when file does not exists", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fileFacade := mocks.NewMockFileFacade(mockCtrl)
+		fileFacade.EXPECT().FindById(defaultUserId, []string{}, "fileId").Return(nil, repository.ErrFileDoesNotExists)
+
+		ctr := handler.NewUploadHandler(&uploadFileUseCaseMock{}, fileFacade, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ReplaceContent).ServeHTTP(rr, createReq())
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return bad request when owner has not enough space", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		fileFacade := mocks.NewMockFileFacade(mockCtrl)
+		fileFacade.EXPECT().FindById(defaultUserId, []string{}, "fileId").Return(&entity.File{FileId: "fileId", Owner: defaultUserId}, nil)
+
+		ctr := handler.NewUploadHandler(&uploadFileUseCaseMock{err: usecase.ErrNotAvailableSpace}, fileFacade, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(ctr.ReplaceContent).ServeHTTP(rr, createReq())
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func newFileFacadeMock(t *testing.T) *mocks.MockFileFacade {
+	fileFacade := mocks.NewMockFileFacade(gomock.NewController(t))
+	fileFacade.EXPECT().FindByName(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, repository.ErrFileDoesNotExists).AnyTimes()
+	return fileFacade
+}
+
 func createTempFile() (string, error) {
 	tempDir := os.TempDir()
 	tempFile := filepath.Join(tempDir, testFilename)
@@ -193,25 +448,31 @@ func createTempFile() (string, error) {
 }
 
 type uploadFileUseCaseMock struct {
-	shouldReturnError bool
+	err      error
+	file     *entity.File
+	content  string
+	replaced *entity.File
 }
 
-func (u *uploadFileUseCaseMock) Execute(ctx context.Context, file *entity.File, src io.Reader) (err error) {
-	if u.shouldReturnError {
-		return errors.New("generic error")
+func (u *uploadFileUseCaseMock) Execute(ctx context.Context, file *entity.File, src io.Reader, replaced *entity.File) (*entity.File, error) {
+	u.file = file
+	u.replaced = replaced
+
+	content, err := io.ReadAll(src)
+
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
-}
+	u.content = string(content)
 
-type createUseCaseMock struct {
-	shouldReturnErr bool
-}
+	if u.err != nil {
+		return nil, u.err
+	}
 
-func (f *createUseCaseMock) Execute(file *entity.File) (err error) {
-	if f.shouldReturnErr {
-		return errors.New("generic error")
+	if replaced != nil {
+		return replaced, nil
 	}
 
-	return nil
+	return file, nil
 }
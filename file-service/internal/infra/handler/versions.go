@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/mapper"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+)
+
+type VersionsHandler interface {
+	ListVersions(w http.ResponseWriter, r *http.Request)
+	Restore(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+}
+
+type versionsHandler struct {
+	fileFacade            facade.FileFacade
+	restoreVersionUseCase usecase.RestoreVersionUseCase
+	deleteVersionUseCase  usecase.DeleteVersionUseCase
+}
+
+func NewVersionsHandler(fileFacade facade.FileFacade, restoreVersionUseCase usecase.RestoreVersionUseCase,
+	deleteVersionUseCase usecase.DeleteVersionUseCase) VersionsHandler {
+	return &versionsHandler{fileFacade: fileFacade, restoreVersionUseCase: restoreVersionUseCase, deleteVersionUseCase: deleteVersionUseCase}
+}
+
+func (v *versionsHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+	user := r.Context().Value(m.UserClaimsCtxKey).(jwt.Token)
+
+	versions, err := v.fileFacade.FindVersions(traceId, user.Subject(), m.UserGroups(user), chi.URLParam(r, "id"))
+
+	if err != nil {
+		handleVersionError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, mapper.MapFileVersionsResponse(versions), traceId)
+}
+
+func (v *versionsHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	version, err := strconv.ParseInt(chi.URLParam(r, "version"), 10, 64)
+
+	if err != nil {
+		response.NotFound(w, traceId)
+		return
+	}
+
+	file, err := v.restoreVersionUseCase.Execute(r.Context(), chi.URLParam(r, "id"), version)
+
+	if err != nil {
+		handleVersionError(w, err, traceId)
+		return
+	}
+
+	response.Ok(w, file, traceId)
+}
+
+func (v *versionsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+	version, err := strconv.ParseInt(chi.URLParam(r, "version"), 10, 64)
+
+	if err != nil {
+		response.NotFound(w, traceId)
+		return
+	}
+
+	if err := v.deleteVersionUseCase.Execute(r.Context(), chi.URLParam(r, "id"), version); err != nil {
+		handleVersionError(w, err, traceId)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func handleVersionError(w http.ResponseWriter, err error, traceId string) {
+	switch err {
+	case repository.ErrFileDoesNotExists, repository.ErrVersionDoesNotExists:
+		response.NotFound(w, traceId)
+	case facade.ErrNotFileEditor:
+		response.Forbidden(w, traceId)
+	case repository.ErrFileLocked:
+		response.Locked(w, traceId)
+	default:
+		response.InternalServerError(w, traceId)
+	}
+}
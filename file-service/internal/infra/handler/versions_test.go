@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	apiHandler "github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
+	m "github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestVersions(t *testing.T) {
+	token := jwt.New()
+	err := token.Set("sub", "userId")
+	assert.NoError(t, err)
+
+	createReq := func(method string, fileId string, version string) *http.Request {
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", fileId)
+		rctx.URLParams.Add("version", version)
+
+		req, _ := http.NewRequest(method, "/files/"+fileId+"/versions/"+version, nil)
+		ctx := context.WithValue(req.Context(), middleware.RequestIDKey, "test-trace-id")
+		ctx = context.WithValue(ctx, m.UserClaimsCtxKey, token)
+		ctx = context.WithValue(ctx, chi.RouteCtxKey, rctx)
+		return req.WithContext(ctx)
+	}
+
+	t.Run("should list versions", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().FindVersions("test-trace-id", "userId", []string{}, "fileId").Return([]*entity.FileVersion{
+			{VersionId: "versionId", FileId: "fileId", Version: 1, Size: 7, CreatedAt: time.Now(), CreatedBy: "userId"},
+		}, nil)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewVersionsHandler(ff, nil, nil).ListVersions).ServeHTTP(rr, createReq("GET", "fileId", ""))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), `"version":1`)
+		assert.NotContains(t, rr.Body.String(), "versionId")
+	})
+
+	t.Run("should return not found when listing versions of unknown file", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ff := mocks.NewMockFileFacade(mockCtrl)
+
+		ff.EXPECT().FindVersions("test-trace-id", "userId", []string{}, "fileId").Return(nil, repository.ErrFileDoesNotExists)
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewVersionsHandler(ff, nil, nil).ListVersions).ServeHTTP(rr, createReq("GET", "fileId", ""))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should restore version", func(t *testing.T) {
+		restore := &restoreVersionUseCaseMock{}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewVersionsHandler(nil, restore, nil).Restore).ServeHTTP(rr, createReq("POST", "fileId", "3"))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, int64(3), restore.version)
+	})
+
+	t.Run("should return not found when version is not a number", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewVersionsHandler(nil, &restoreVersionUseCaseMock{}, nil).Restore).ServeHTTP(rr, createReq("POST", "fileId", "latest"))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("should return forbidden when deleting version of file requester cannot edit", func(t *testing.T) {
+		del := &deleteVersionUseCaseMock{err: facade.ErrNotFileEditor}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewVersionsHandler(nil, nil, del).Delete).ServeHTTP(rr, createReq("DELETE", "fileId", "1"))
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("should return locked when deleting version of file locked by another user", func(t *testing.T) {
+		del := &deleteVersionUseCaseMock{err: repository.ErrFileLocked}
+
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewVersionsHandler(nil, nil, del).Delete).ServeHTTP(rr, createReq("DELETE", "fileId", "1"))
+
+		assert.Equal(t, http.StatusLocked, rr.Code)
+	})
+
+	t.Run("should delete version", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		http.HandlerFunc(apiHandler.NewVersionsHandler(nil, nil, &deleteVersionUseCaseMock{}).Delete).ServeHTTP(rr, createReq("DELETE", "fileId", "1"))
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+}
+
+type restoreVersionUseCaseMock struct {
+	version int64
+}
+
+func (r *restoreVersionUseCaseMock) Execute(ctx context.Context, fileId string, version int64) (*entity.File, error) {
+	r.version = version
+	return &entity.File{FileId: fileId}, nil
+}
+
+type deleteVersionUseCaseMock struct {
+	err error
+}
+
+func (d *deleteVersionUseCaseMock) Execute(ctx context.Context, fileId string, version int64) error {
+	return d.err
+}
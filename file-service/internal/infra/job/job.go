@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+)
+
+// Schedule runs task right away and then on every interval until ctx is done.
+// Each run gets its own trace id, so use cases log the same way they do when serving requests
+func Schedule(ctx context.Context, name string, interval time.Duration, task func(ctx context.Context) error) {
+	if interval <= 0 {
+		slog.Warn("Job disabled because its interval is not positive", "job", name)
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		traceId := uuid.NewString()
+
+		if err := task(context.WithValue(ctx, chiMiddleware.RequestIDKey, traceId)); err != nil {
+			slog.Error("Job run failed", "job", name, "traceId", traceId, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -7,8 +7,34 @@ import (
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
 )
 
+const (
+	filesRoute       = "/file-service/v1/files"
+	sharedFilesRoute = "/file-service/v1/files/shared"
+	trashRoute       = "/file-service/v1/trash"
+	publicRoute      = "/file-service/v1/public"
+	adminUsageRoute  = "/file-service/v1/admin/usage"
+	adminFilesRoute  = "/file-service/v1/admin/users/%s/files"
+	adminAuditRoute  = "/file-service/v1/admin/audit"
+)
+
 func MapFilePageResponse(page int, size int, filesPage *entity.FilePage, host string) *model.FilePageResponse {
-	nextUrl := buildNextUrl(filesPage, host, page, size)
+	return mapFilePageResponse(page, size, filesPage, host, filesRoute)
+}
+
+func MapSharedFilePageResponse(page int, size int, filesPage *entity.FilePage, host string) *model.FilePageResponse {
+	return mapFilePageResponse(page, size, filesPage, host, sharedFilesRoute)
+}
+
+func MapTrashFilePageResponse(page int, size int, filesPage *entity.FilePage, host string) *model.FilePageResponse {
+	return mapFilePageResponse(page, size, filesPage, host, trashRoute)
+}
+
+func MapAdminFilePageResponse(page int, size int, userId string, filesPage *entity.FilePage, host string) *model.FilePageResponse {
+	return mapFilePageResponse(page, size, filesPage, host, fmt.Sprintf(adminFilesRoute, userId))
+}
+
+func mapFilePageResponse(page int, size int, filesPage *entity.FilePage, host string, route string) *model.FilePageResponse {
+	nextUrl := buildNextUrl(len(filesPage.Content), host, route, page, size)
 
 	return &model.FilePageResponse{
 		Page:          page,
@@ -31,20 +57,185 @@ func mapFilePageContents(entities []*entity.File) []*model.FileContent {
 
 func mapFilePageContentParser(entity *entity.File) *model.FileContent {
 	return &model.FileContent{
-		FileId:    entity.FileId,
-		Filename:  entity.Filename,
-		Size:      entity.Size,
-		Owner:     entity.Owner,
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
-		CreatedBy: entity.CreatedBy,
-		UpdatedBy: entity.UpdatedBy,
+		FileId:     entity.FileId,
+		Filename:   entity.Filename,
+		Size:       entity.Size,
+		Owner:      entity.Owner,
+		ParentId:   entity.ParentId,
+		CreatedAt:  entity.CreatedAt,
+		UpdatedAt:  entity.UpdatedAt,
+		CreatedBy:  entity.CreatedBy,
+		UpdatedBy:  entity.UpdatedBy,
+		Permission: entity.Permission,
+		SharedBy:   sharedBy(entity),
+		Starred:    entity.Starred,
+		DeletedAt:  entity.DeletedAt,
+		DeletedBy:  entity.DeletedBy,
+	}
+}
+
+func sharedBy(entity *entity.File) string {
+	if entity.Permission == "" {
+		return ""
+	}
+
+	return entity.Owner
+}
+
+func MapFilePermissionsResponse(file *entity.File) *model.FilePermissionsResponse {
+	return &model.FilePermissionsResponse{
+		FileId:       file.FileId,
+		Owner:        file.Owner,
+		Editors:      file.Editors,
+		Viewers:      file.Viewers,
+		EditorGroups: file.EditorGroups,
+		ViewerGroups: file.ViewerGroups,
+	}
+}
+
+func MapLinksResponse(links []*entity.Link, host string) []*model.LinkResponse {
+	res := make([]*model.LinkResponse, len(links))
+
+	for i, l := range links {
+		res[i] = MapLinkResponse(l, host)
+	}
+
+	return res
+}
+
+func MapLinkResponse(link *entity.Link, host string) *model.LinkResponse {
+	return &model.LinkResponse{
+		Token:        link.Token,
+		FileId:       link.FileId,
+		Url:          fmt.Sprintf("%s%s/%s", host, publicRoute, link.Token),
+		Protected:    link.Protected(),
+		ExpiresAt:    link.ExpiresAt,
+		MaxDownloads: link.MaxDownloads,
+		Downloads:    link.Downloads,
+		CreatedAt:    link.CreatedAt,
+	}
+}
+
+func MapFoldersResponse(folders []*entity.Folder) []*model.FolderResponse {
+	res := make([]*model.FolderResponse, len(folders))
+
+	for i, f := range folders {
+		res[i] = MapFolderResponse(f)
+	}
+
+	return res
+}
+
+func MapFolderResponse(folder *entity.Folder) *model.FolderResponse {
+	var path []*model.BreadcrumbResponse
+
+	for _, b := range folder.Path {
+		path = append(path, &model.BreadcrumbResponse{FolderId: b.FolderId, Name: b.Name})
+	}
+
+	return &model.FolderResponse{
+		FolderId:  folder.FolderId,
+		Name:      folder.Name,
+		ParentId:  folder.ParentId,
+		Path:      path,
+		CreatedAt: folder.CreatedAt,
+		UpdatedAt: folder.UpdatedAt,
+	}
+}
+
+func MapFileVersionsResponse(versions []*entity.FileVersion) []*model.FileVersionResponse {
+	res := make([]*model.FileVersionResponse, len(versions))
+
+	for i, v := range versions {
+		res[i] = &model.FileVersionResponse{
+			Version:   v.Version,
+			Size:      v.Size,
+			CreatedAt: v.CreatedAt,
+			CreatedBy: v.CreatedBy,
+		}
+	}
+
+	return res
+}
+
+func MapAccessTokensResponse(tokens []*entity.AccessToken) []*model.AccessTokenResponse {
+	res := make([]*model.AccessTokenResponse, len(tokens))
+
+	for i, t := range tokens {
+		res[i] = MapAccessTokenResponse(t)
+	}
+
+	return res
+}
+
+func MapAccessTokenResponse(token *entity.AccessToken) *model.AccessTokenResponse {
+	return &model.AccessTokenResponse{
+		TokenId:    token.TokenId,
+		Name:       token.Name,
+		Scope:      token.Scope,
+		Token:      token.Token,
+		ExpiresAt:  token.ExpiresAt,
+		CreatedAt:  token.CreatedAt,
+		LastUsedAt: token.LastUsedAt,
+	}
+}
+
+func MapUsagePageResponse(page int, size int, usagePage *entity.UsagePage, host string) *model.UsagePageResponse {
+	content := make([]*model.UsageContent, len(usagePage.Content))
+
+	for i, u := range usagePage.Content {
+		content[i] = &model.UsageContent{
+			UserId: u.UserId,
+			Files:  u.Files,
+			Size:   u.Size,
+		}
+	}
+
+	return &model.UsagePageResponse{
+		Page:          page,
+		Size:          size,
+		TotalElements: usagePage.Count,
+		Next:          buildNextUrl(len(usagePage.Content), host, adminUsageRoute, page, size),
+		Content:       content,
+	}
+}
+
+func MapTotalsResponse(totals *entity.Totals) *model.TotalsResponse {
+	return &model.TotalsResponse{
+		Users: totals.Users,
+		Files: totals.Files,
+		Size:  totals.Size,
+	}
+}
+
+func MapAuditPageResponse(page int, size int, auditPage *entity.AuditPage, host string) *model.AuditPageResponse {
+	content := make([]*model.AuditContent, len(auditPage.Content))
+
+	for i, a := range auditPage.Content {
+		content[i] = &model.AuditContent{
+			AuditId:      a.AuditId,
+			UserId:       a.UserId,
+			Action:       a.Action,
+			TargetUserId: a.TargetUserId,
+			TargetFileId: a.TargetFileId,
+			Status:       a.Status,
+			TraceId:      a.TraceId,
+			CreatedAt:    a.CreatedAt,
+		}
+	}
+
+	return &model.AuditPageResponse{
+		Page:          page,
+		Size:          size,
+		TotalElements: auditPage.Count,
+		Next:          buildNextUrl(len(auditPage.Content), host, adminAuditRoute, page, size),
+		Content:       content,
 	}
 }
 
-func buildNextUrl(filesPage *entity.FilePage, host string, page int, size int) (nextUrl string) {
-	if len(filesPage.Content) == size {
-		nextUrl = fmt.Sprintf("%s/file-service/v1/files?page=%d&size=%d", host, page+1, size)
+func buildNextUrl(length int, host string, route string, page int, size int) (nextUrl string) {
+	if length == size {
+		nextUrl = fmt.Sprintf("%s%s?page=%d&size=%d", host, route, page+1, size)
 	}
 
 	return
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+)
+
+// AdminMiddleware only lets through users holding the configured admin role.
+// It must be mounted after JWTMiddleware, since it reads the parsed token
+func AdminMiddleware(config *config.Config) func(h http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+			user := r.Context().Value(UserClaimsCtxKey).(jwt.Token)
+
+			if !IsAdmin(config, user) {
+				slog.Info("User is not an administrator", "traceId", traceId, "userId", user.Subject())
+				response.Forbidden(w, traceId)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	}
+}
+
+// IsAdmin looks for the admin role in the configured claim. Nested claims,
+// like keycloak's realm_access.roles, are reached using dots
+func IsAdmin(config *config.Config, token jwt.Token) bool {
+	if config.Auth.AdminClaim == "" || config.Auth.AdminRole == "" {
+		return false
+	}
+
+	path := strings.Split(config.Auth.AdminClaim, ".")
+
+	claim, ok := token.Get(path[0])
+
+	for _, key := range path[1:] {
+		if !ok {
+			return false
+		}
+
+		var obj map[string]interface{}
+
+		if obj, ok = claim.(map[string]interface{}); !ok {
+			return false
+		}
+
+		claim, ok = obj[key]
+	}
+
+	if !ok {
+		return false
+	}
+
+	switch value := claim.(type) {
+	case string:
+		return value == config.Auth.AdminRole
+	case []interface{}:
+		for _, role := range value {
+			if role == config.Auth.AdminRole {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,49 @@
+package middleware_test
+
+import (
+	"testing"
+
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsAdmin(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Auth.AdminClaim = "realm_access.roles"
+	cfg.Auth.AdminRole = "raspstore-admin"
+
+	t.Run("should find role in nested claim", func(t *testing.T) {
+		token := jwt.New()
+		assert.NoError(t, token.Set("realm_access", map[string]interface{}{
+			"roles": []interface{}{"default-roles", "raspstore-admin"},
+		}))
+
+		assert.True(t, middleware.IsAdmin(cfg, token))
+	})
+
+	t.Run("should not find role when user does not have it", func(t *testing.T) {
+		token := jwt.New()
+		assert.NoError(t, token.Set("realm_access", map[string]interface{}{
+			"roles": []interface{}{"default-roles"},
+		}))
+
+		assert.False(t, middleware.IsAdmin(cfg, token))
+	})
+
+	t.Run("should not find role when claim is missing", func(t *testing.T) {
+		assert.False(t, middleware.IsAdmin(cfg, jwt.New()))
+	})
+
+	t.Run("should find role in string claim", func(t *testing.T) {
+		cfg := &config.Config{}
+		cfg.Auth.AdminClaim = "role"
+		cfg.Auth.AdminRole = "admin"
+
+		token := jwt.New()
+		assert.NoError(t, token.Set("role", "admin"))
+
+		assert.True(t, middleware.IsAdmin(cfg, token))
+	})
+}
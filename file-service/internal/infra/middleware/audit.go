@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+)
+
+// AuditMiddleware records every request that reaches the routes it is mounted on,
+// including the ones refused by AdminMiddleware. It must be mounted after JWTMiddleware
+func AuditMiddleware(auditRepository repository.AuditRepository) func(h http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+			user := r.Context().Value(UserClaimsCtxKey).(jwt.Token)
+
+			ww := chiMiddleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			h.ServeHTTP(ww, r)
+
+			status := ww.Status()
+
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			// the route pattern and url params are only complete after routing,
+			// so the entry is built once the handler is done
+			action := r.Method + " " + chi.RouteContext(r.Context()).RoutePattern()
+
+			entry := entity.NewAuditEntry(user.Subject(), action, chi.URLParam(r, "userId"), chi.URLParam(r, "fileId"), status, traceId)
+
+			if err := auditRepository.Save(entry); err != nil {
+				slog.Error("Could not record admin action", "traceId", traceId, "action", action, "error", err)
+			}
+		})
+	}
+}
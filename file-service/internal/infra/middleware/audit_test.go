@@ -0,0 +1,89 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository/mocks"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAuditMiddleware(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Auth.AdminClaim = "roles"
+	cfg.Auth.AdminRole = "admin"
+
+	createRouter := func(ar *mocks.MockAuditRepository, token jwt.Token) *chi.Mux {
+		router := chi.NewRouter()
+
+		router.Use(func(h http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithValue(r.Context(), chiMiddleware.RequestIDKey, "trace-id")
+				ctx = context.WithValue(ctx, middleware.UserClaimsCtxKey, token)
+				h.ServeHTTP(w, r.WithContext(ctx))
+			})
+		})
+		router.Use(middleware.AuditMiddleware(ar))
+		router.Use(middleware.AdminMiddleware(cfg))
+
+		router.Delete("/admin/users/{userId}/files/{fileId}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		return router
+	}
+
+	t.Run("should record admin action", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ar := mocks.NewMockAuditRepository(mockCtrl)
+
+		token := jwt.New()
+		assert.NoError(t, token.Set("sub", "adminId"))
+		assert.NoError(t, token.Set("roles", []interface{}{"admin"}))
+
+		ar.EXPECT().Save(gomock.Any()).DoAndReturn(func(entry *entity.AuditEntry) error {
+			assert.Equal(t, "adminId", entry.UserId)
+			assert.Equal(t, "DELETE /admin/users/{userId}/files/{fileId}", entry.Action)
+			assert.Equal(t, "user1", entry.TargetUserId)
+			assert.Equal(t, "file1", entry.TargetFileId)
+			assert.Equal(t, http.StatusNoContent, entry.Status)
+			assert.Equal(t, "trace-id", entry.TraceId)
+			return nil
+		})
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/admin/users/user1/files/file1", nil)
+		createRouter(ar, token).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+
+	t.Run("should record refused action from non admin user", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		ar := mocks.NewMockAuditRepository(mockCtrl)
+
+		token := jwt.New()
+		assert.NoError(t, token.Set("sub", "userId"))
+
+		ar.EXPECT().Save(gomock.Any()).DoAndReturn(func(entry *entity.AuditEntry) error {
+			assert.Equal(t, "userId", entry.UserId)
+			assert.Equal(t, http.StatusForbidden, entry.Status)
+			return nil
+		})
+
+		rr := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/admin/users/user1/files/file1", nil)
+		createRouter(ar, token).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+}
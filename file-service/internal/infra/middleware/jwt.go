@@ -21,6 +21,7 @@ const UserClaimsCtxKey userClaimsKeyType = 101
 const (
 	tokenPrefix         = "Bearer"
 	authorizationHeader = "Authorization"
+	groupsClaim         = "groups"
 )
 
 var (
@@ -38,6 +39,12 @@ func JWTMiddleware(config *config.Config) func(h http.Handler) http.Handler {
 
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the user may already be authenticated by a personal access token
+			if _, ok := r.Context().Value(UserClaimsCtxKey).(jwt.Token); ok {
+				h.ServeHTTP(w, r)
+				return
+			}
+
 			tkn, err := verifyJwt(r, ar, config.Auth.PublicKeyUrl)
 
 			if err != nil {
@@ -53,6 +60,32 @@ func JWTMiddleware(config *config.Config) func(h http.Handler) http.Handler {
 	}
 }
 
+// UserGroups returns the groups the token owner belongs to, as sent by
+// the identity provider in the groups claim
+func UserGroups(token jwt.Token) []string {
+	claim, ok := token.Get(groupsClaim)
+
+	if !ok {
+		return []string{}
+	}
+
+	values, ok := claim.([]interface{})
+
+	if !ok {
+		return []string{}
+	}
+
+	groups := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if group, ok := value.(string); ok {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
+
 func verifyJwt(r *http.Request, ar *jwk.AutoRefresh, jwkUri string) (jwt.Token, error) {
 	token, err := getTokenHeader(r)
 
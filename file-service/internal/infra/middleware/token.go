@@ -0,0 +1,145 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/lestrrat-go/jwx/jwt"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/response"
+)
+
+// not the standard scope claim, which identity provider tokens carry with their own OAuth scopes
+const scopeClaim = "raspstore_scope"
+
+// AccessTokenMiddleware authenticates requests carrying a personal access token.
+// The token owner is put in the context as a jwt.Token, so handlers do not need to
+// know how the user authenticated. Any other bearer token is left to JWTMiddleware
+func AccessTokenMiddleware(accessTokenFacade facade.AccessTokenFacade) func(h http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			raw, err := getTokenHeader(r)
+
+			if err != nil || !entity.IsAccessToken(raw) {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+
+			accessToken, err := accessTokenFacade.Authenticate(traceId, raw)
+
+			if err != nil {
+				response.Unauthorized(w)
+				return
+			}
+
+			tkn, err := accessTokenClaims(accessToken)
+
+			if err != nil {
+				slog.Error("Could not build access token claims", "traceId", traceId, "error", err)
+				response.InternalServerError(w, traceId)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UserClaimsCtxKey, tkn)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ScopeMiddleware restricts what scoped access tokens can do. Tokens issued by the identity
+// provider never carry the access token scope claim, so they are never restricted
+func ScopeMiddleware(allowedScopes ...string) func(h http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := r.Context().Value(UserClaimsCtxKey).(jwt.Token)
+
+			if scopeAllows(UserScope(user), r.Method, allowedScopes) {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+			slog.Info("Access token scope does not allow request", "traceId", traceId, "userId", user.Subject())
+			response.Forbidden(w, traceId)
+		})
+	}
+}
+
+// IdentityProviderMiddleware refuses requests authenticated by an access token, whatever its scope.
+// It guards managing access tokens, so a leaked token cannot be used to issue new ones that outlive it
+func IdentityProviderMiddleware() func(h http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := r.Context().Value(UserClaimsCtxKey).(jwt.Token)
+
+			if _, ok := user.Get(scopeClaim); !ok {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			traceId := r.Context().Value(chiMiddleware.RequestIDKey).(string)
+			slog.Info("Refusing request authenticated by an access token", "traceId", traceId, "userId", user.Subject())
+			response.Forbidden(w, traceId)
+		})
+	}
+}
+
+func scopeAllows(scope string, method string, allowedScopes []string) bool {
+	if scope == "" || scope == entity.ScopeFull {
+		return true
+	}
+
+	if scope == entity.ScopeReadOnly && (method == http.MethodGet || method == http.MethodHead) {
+		return true
+	}
+
+	for _, allowed := range allowedScopes {
+		if scope == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UserScope returns the scope of the access token the request was authenticated with,
+// which is empty for identity provider tokens
+func UserScope(token jwt.Token) string {
+	claim, ok := token.Get(scopeClaim)
+
+	if !ok {
+		return ""
+	}
+
+	scope, _ := claim.(string)
+	return scope
+}
+
+func accessTokenClaims(accessToken *entity.AccessToken) (jwt.Token, error) {
+	tkn := jwt.New()
+
+	if err := tkn.Set(jwt.SubjectKey, accessToken.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := tkn.Set(scopeClaim, accessToken.Scope); err != nil {
+		return nil, err
+	}
+
+	groups := make([]interface{}, len(accessToken.Groups))
+
+	for i, group := range accessToken.Groups {
+		groups[i] = group
+	}
+
+	if err := tkn.Set(groupsClaim, groups); err != nil {
+		return nil, err
+	}
+
+	return tkn, nil
+}
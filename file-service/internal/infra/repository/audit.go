@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type auditRepository struct {
+	ctx     context.Context
+	queries *gen.Queries
+}
+
+var _ repository.AuditRepository = (*auditRepository)(nil)
+
+func NewAuditRepository(ctx context.Context, db *sql.DB) *auditRepository {
+	return &auditRepository{queries: gen.New(db), ctx: ctx}
+}
+
+func (r *auditRepository) Save(entry *entity.AuditEntry) error {
+	return r.queries.CreateAdminAudit(r.ctx, gen.CreateAdminAuditParams{
+		AuditID:      entry.AuditId,
+		UserID:       entry.UserId,
+		Action:       entry.Action,
+		TargetUserID: sql.NullString{String: entry.TargetUserId, Valid: entry.TargetUserId != ""},
+		TargetFileID: sql.NullString{String: entry.TargetFileId, Valid: entry.TargetFileId != ""},
+		Status:       int64(entry.Status),
+		TraceID:      entry.TraceId,
+		CreatedAt:    entry.CreatedAt.UnixMilli(),
+	})
+}
+
+func (r *auditRepository) FindAll(page int, size int) (*entity.AuditPage, error) {
+	rows, err := r.queries.FindAllAdminAudits(r.ctx, gen.FindAllAdminAuditsParams{
+		Limit:  int64(size),
+		Offset: int64(page) * int64(size),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount := 0
+
+	if len(rows) != 0 {
+		totalCount = int(rows[0].Totalcount)
+	}
+
+	auditPage := &entity.AuditPage{Count: totalCount, Content: make([]*entity.AuditEntry, len(rows))}
+
+	for i, row := range rows {
+		auditPage.Content[i] = &entity.AuditEntry{
+			AuditId:      row.AuditID,
+			UserId:       row.UserID,
+			Action:       row.Action,
+			TargetUserId: row.TargetUserID.String,
+			TargetFileId: row.TargetFileID.String,
+			Status:       int(row.Status),
+			TraceId:      row.TraceID,
+			CreatedAt:    time.UnixMilli(row.CreatedAt),
+		}
+	}
+
+	return auditPage, nil
+}
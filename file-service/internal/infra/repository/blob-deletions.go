@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type blobDeletionsRepository struct {
+	ctx     context.Context
+	queries *gen.Queries
+}
+
+var _ repository.BlobDeletionsRepository = (*blobDeletionsRepository)(nil)
+
+func NewBlobDeletionsRepository(ctx context.Context, db *sql.DB) *blobDeletionsRepository {
+	return &blobDeletionsRepository{queries: gen.New(db), ctx: ctx}
+}
+
+// Save ignores the blobs already queued, keeping their attempts
+func (r *blobDeletionsRepository) Save(blobIds ...string) error {
+	createdAt := time.Now().UnixMilli()
+
+	for _, blobId := range blobIds {
+		if err := r.queries.CreateBlobDeletion(r.ctx, gen.CreateBlobDeletionParams{BlobID: blobId, CreatedAt: createdAt}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *blobDeletionsRepository) FindAll() ([]*entity.BlobDeletion, error) {
+	rows, err := r.queries.FindAllBlobDeletions(r.ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	deletions := make([]*entity.BlobDeletion, len(rows))
+
+	for i, row := range rows {
+		deletions[i] = &entity.BlobDeletion{
+			BlobId:    row.BlobID,
+			Attempts:  row.Attempts,
+			LastError: row.LastError.String,
+			CreatedAt: time.UnixMilli(row.CreatedAt),
+		}
+	}
+
+	return deletions, nil
+}
+
+func (r *blobDeletionsRepository) Fail(blobId string, reason string) error {
+	return r.queries.UpdateBlobDeletionFailure(r.ctx, gen.UpdateBlobDeletionFailureParams{
+		LastError: sql.NullString{String: reason, Valid: true},
+		BlobID:    blobId,
+	})
+}
+
+func (r *blobDeletionsRepository) Delete(blobId string) error {
+	return r.queries.DeleteBlobDeletion(r.ctx, blobId)
+}
@@ -0,0 +1,165 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type blobsRepository struct {
+	ctx     context.Context
+	db      *sql.DB
+	queries *gen.Queries
+}
+
+var _ repository.BlobsRepository = (*blobsRepository)(nil)
+
+func NewBlobsRepository(ctx context.Context, db *sql.DB) *blobsRepository {
+	return &blobsRepository{queries: gen.New(db), db: db, ctx: ctx}
+}
+
+func (r *blobsRepository) Acquire(blobId string, hash string, size int64) (refCount int64, orphan string, err error) {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return 0, "", err
+	}
+
+	defer tx.Rollback()
+
+	nq := r.queries.WithTx(tx)
+
+	if orphan, err = r.release(nq, blobId); err != nil && err != repository.ErrBlobRefDoesNotExists {
+		return 0, "", err
+	}
+
+	now := time.Now().UnixMilli()
+
+	refCount, err = nq.AcquireBlob(r.ctx, gen.AcquireBlobParams{Hash: hash, Size: size, CreatedAt: now})
+
+	if err != nil {
+		return 0, "", err
+	}
+
+	if err := nq.CreateBlobRef(r.ctx, gen.CreateBlobRefParams{BlobID: blobId, Hash: hash, CreatedAt: now}); err != nil {
+		return 0, "", err
+	}
+
+	// storing the same content again under blobId brings it back to life
+	if orphan == hash {
+		orphan = ""
+	}
+
+	return refCount, orphan, tx.Commit()
+}
+
+func (r *blobsRepository) Release(blobId string) (orphan string, err error) {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return "", err
+	}
+
+	defer tx.Rollback()
+
+	if orphan, err = r.release(r.queries.WithTx(tx), blobId); err != nil {
+		return "", err
+	}
+
+	return orphan, tx.Commit()
+}
+
+func (r *blobsRepository) Rename(fromId string, toId string) (orphan string, err error) {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return "", err
+	}
+
+	defer tx.Rollback()
+
+	nq := r.queries.WithTx(tx)
+
+	if _, err := nq.FindBlobRef(r.ctx, fromId); err == sql.ErrNoRows {
+		return "", repository.ErrBlobRefDoesNotExists
+	} else if err != nil {
+		return "", err
+	}
+
+	if orphan, err = r.release(nq, toId); err != nil && err != repository.ErrBlobRefDoesNotExists {
+		return "", err
+	}
+
+	if err := nq.UpdateBlobRefID(r.ctx, gen.UpdateBlobRefIDParams{ToBlobID: toId, FromBlobID: fromId}); err != nil {
+		return "", err
+	}
+
+	return orphan, tx.Commit()
+}
+
+func (r *blobsRepository) FindByBlobId(blobId string) (*entity.BlobRef, error) {
+	row, err := r.queries.FindBlobRef(r.ctx, blobId)
+
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrBlobRefDoesNotExists
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.BlobRef{BlobId: row.BlobID, Hash: row.Hash, Size: row.Size, CreatedAt: time.UnixMilli(row.CreatedAt)}, nil
+}
+
+func (r *blobsRepository) FindAll() ([]*entity.BlobRef, error) {
+	rows, err := r.queries.FindAllBlobRefs(r.ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]*entity.BlobRef, 0, len(rows))
+
+	for _, row := range rows {
+		refs = append(refs, &entity.BlobRef{BlobId: row.BlobID, Hash: row.Hash, Size: row.Size, CreatedAt: time.UnixMilli(row.CreatedAt)})
+	}
+
+	return refs, nil
+}
+
+// release drops the reference of blobId, and the content row along with it when that was its last reference
+func (r *blobsRepository) release(nq *gen.Queries, blobId string) (orphan string, err error) {
+	ref, err := nq.FindBlobRef(r.ctx, blobId)
+
+	if err == sql.ErrNoRows {
+		return "", repository.ErrBlobRefDoesNotExists
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	if err := nq.DeleteBlobRef(r.ctx, blobId); err != nil {
+		return "", err
+	}
+
+	refCount, err := nq.ReleaseBlob(r.ctx, ref.Hash)
+
+	if err != nil {
+		return "", err
+	}
+
+	if refCount > 0 {
+		return "", nil
+	}
+
+	if err := nq.DeleteUnreferencedBlob(r.ctx, ref.Hash); err != nil {
+		return "", err
+	}
+
+	return ref.Hash, nil
+}
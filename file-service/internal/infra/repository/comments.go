@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type commentsRepository struct {
+	ctx     context.Context
+	queries *gen.Queries
+}
+
+var _ repository.CommentsRepository = (*commentsRepository)(nil)
+
+func NewCommentsRepository(ctx context.Context, db *sql.DB) *commentsRepository {
+	return &commentsRepository{queries: gen.New(db), ctx: ctx}
+}
+
+func (r *commentsRepository) Save(comment *entity.Comment) error {
+	return r.queries.CreateFileComment(r.ctx, gen.CreateFileCommentParams{
+		CommentID: comment.CommentId,
+		FileID:    comment.FileId,
+		ParentID:  sql.NullString{String: comment.ParentId, Valid: comment.ParentId != ""},
+		Content:   comment.Content,
+		CreatedAt: comment.CreatedAt.UnixMilli(),
+		CreatedBy: comment.CreatedBy,
+	})
+}
+
+func (r *commentsRepository) FindById(fileId string, commentId string) (*entity.Comment, error) {
+	row, err := r.queries.FindFileComment(r.ctx, gen.FindFileCommentParams{FileID: fileId, CommentID: commentId})
+
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrCommentDoesNotExists
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mapComment(row), nil
+}
+
+// FindAllByFileId lists the comments of the file from the oldest to the newest, replies included
+func (r *commentsRepository) FindAllByFileId(fileId string) ([]*entity.Comment, error) {
+	rows, err := r.queries.FindFileCommentsByFileID(r.ctx, fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	comments := make([]*entity.Comment, len(rows))
+
+	for i, row := range rows {
+		comments[i] = mapComment(row)
+	}
+
+	return comments, nil
+}
+
+func (r *commentsRepository) Update(comment *entity.Comment) error {
+	ts := time.Now()
+	comment.UpdatedAt = &ts
+
+	return r.queries.UpdateFileComment(r.ctx, gen.UpdateFileCommentParams{
+		Content:   comment.Content,
+		UpdatedAt: sql.NullInt64{Int64: ts.UnixMilli(), Valid: true},
+		CommentID: comment.CommentId,
+	})
+}
+
+// Delete removes the comment along with every reply under it
+func (r *commentsRepository) Delete(commentId string) error {
+	return r.queries.DeleteFileCommentThread(r.ctx, commentId)
+}
+
+func mapComment(row gen.FilesComment) *entity.Comment {
+	comment := &entity.Comment{
+		CommentId: row.CommentID,
+		FileId:    row.FileID,
+		ParentId:  row.ParentID.String,
+		Content:   row.Content,
+		CreatedAt: time.UnixMilli(row.CreatedAt),
+		CreatedBy: row.CreatedBy,
+		Replies:   []*entity.Comment{},
+	}
+
+	if row.UpdatedAt.Valid {
+		updatedAt := time.UnixMilli(row.UpdatedAt.Int64)
+		comment.UpdatedAt = &updatedAt
+	}
+
+	return comment
+}
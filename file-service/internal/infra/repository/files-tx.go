@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
@@ -33,10 +34,14 @@ func (t *txFilesRepository) Commit(tx *sql.Tx) error {
 	return tx.Commit()
 }
 
-func (t *txFilesRepository) FindById(tx *sql.Tx, userId string, fileId string) (*entity.File, error) {
+func (t *txFilesRepository) Rollback(tx *sql.Tx) error {
+	return tx.Rollback()
+}
+
+func (t *txFilesRepository) FindById(tx *sql.Tx, userId string, groups []string, fileId string) (*entity.File, error) {
 	nq := t.queries.WithTx(tx)
 
-	rows, err := nq.FindFileByID(t.ctx, gen.FindFileByIDParams{FileID: fileId, OwnerID: userId})
+	rows, err := nq.FindFileByID(t.ctx, gen.FindFileByIDParams{FileID: fileId, OwnerID: userId, Groups: groupsParam(groups)})
 
 	if err != nil {
 		return nil, err
@@ -46,44 +51,46 @@ func (t *txFilesRepository) FindById(tx *sql.Tx, userId string, fileId string) (
 		return nil, repository.ErrFileDoesNotExists
 	}
 
-	ref := rows[0]
-
-	updatedAt := time.UnixMilli(ref.UpdatedAt.Int64)
-
-	file := &entity.File{
-		FileId:    ref.FileID,
-		Filename:  ref.FileName,
-		Size:      ref.Size,
-		Secret:    ref.IsSecret,
-		Owner:     ref.OwnerID,
-		CreatedAt: time.UnixMilli(ref.CreatedAt),
-		UpdatedAt: &updatedAt,
-		CreatedBy: ref.CreatedBy,
-		UpdatedBy: &ref.UpdatedBy.String,
-		Viewers:   []string{},
-		Editors:   []string{},
-	}
+	return mapFileRows(rows), nil
+}
 
-	for _, row := range rows {
-		if !row.Permission.Valid {
-			continue
-		}
+func (t *txFilesRepository) FindUsageByUserId(tx *sql.Tx, userId string) (int64, error) {
+	nq := t.queries.WithTx(tx)
 
-		permission := row.Permission.String
+	row, err := nq.FindUsageByUserID(t.ctx, userId)
 
-		if permission == "VIEWER" {
-			file.Viewers = append(file.Viewers, row.UserID.String)
-		}
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
 
-		if permission == "EDITOR" {
-			file.Editors = append(file.Editors, row.UserID.String)
-		}
+	if err != nil {
+		return 0, err
 	}
 
-	return file, nil
+	return int64(row.Float64), nil
 }
 
-func (t *txFilesRepository) Update(tx *sql.Tx, userId string, file *entity.File) error {
+func (t *txFilesRepository) Save(tx *sql.Tx, file *entity.File) error {
+	nq := t.queries.WithTx(tx)
+
+	file.CreatedAt = time.Now()
+	ts := time.Now()
+	file.UpdatedAt = &ts
+
+	return nq.CreateFile(t.ctx, gen.CreateFileParams{
+		FileName:  file.Filename,
+		Size:      file.Size,
+		IsSecret:  file.Secret,
+		OwnerID:   file.Owner,
+		FileID:    file.FileId,
+		CreatedAt: file.CreatedAt.UnixMilli(),
+		CreatedBy: file.Owner,
+		ParentID:  sql.NullString{String: file.ParentId, Valid: file.ParentId != ""},
+		Checksum:  sql.NullString{String: file.Checksum, Valid: file.Checksum != ""},
+	})
+}
+
+func (t *txFilesRepository) Update(tx *sql.Tx, userId string, groups []string, file *entity.File) error {
 	nq := t.queries.WithTx(tx)
 
 	ts := time.Now()
@@ -93,6 +100,7 @@ func (t *txFilesRepository) Update(tx *sql.Tx, userId string, file *entity.File)
 	return nq.UpdateFileByID(t.ctx, gen.UpdateFileByIDParams{
 		FileID:    file.FileId,
 		OwnerID:   userId,
+		Groups:    groupsParam(groups),
 		FileName:  file.Filename,
 		IsSecret:  file.Secret,
 		UpdatedAt: sql.NullInt64{Int64: file.UpdatedAt.UnixMilli(), Valid: true},
@@ -100,8 +108,146 @@ func (t *txFilesRepository) Update(tx *sql.Tx, userId string, file *entity.File)
 	})
 }
 
+func (t *txFilesRepository) UpdateContent(tx *sql.Tx, userId string, file *entity.File) error {
+	nq := t.queries.WithTx(tx)
+
+	ts := time.Now()
+	file.UpdatedAt = &ts
+	file.UpdatedBy = &userId
+	// new content was just hashed, so it cannot be corrupted
+	file.CorruptedAt = nil
+
+	return nq.UpdateFileContentByID(t.ctx, gen.UpdateFileContentByIDParams{
+		FileID:    file.FileId,
+		Size:      file.Size,
+		Checksum:  sql.NullString{String: file.Checksum, Valid: file.Checksum != ""},
+		UpdatedAt: sql.NullInt64{Int64: ts.UnixMilli(), Valid: true},
+		UpdatedBy: sql.NullString{String: userId, Valid: true},
+	})
+}
+
+// SaveVersion numbers the version after the latest one of the file, unless it is numbered
+// already, which is the case of a version being put back where it was
+func (t *txFilesRepository) SaveVersion(tx *sql.Tx, version *entity.FileVersion) error {
+	nq := t.queries.WithTx(tx)
+
+	checksum := sql.NullString{String: version.Checksum, Valid: version.Checksum != ""}
+
+	if version.Version > 0 {
+		return nq.CreateNumberedFileVersion(t.ctx, gen.CreateNumberedFileVersionParams{
+			VersionID: version.VersionId,
+			FileID:    version.FileId,
+			Version:   version.Version,
+			Size:      version.Size,
+			CreatedAt: version.CreatedAt.UnixMilli(),
+			CreatedBy: version.CreatedBy,
+			Checksum:  checksum,
+		})
+	}
+
+	number, err := nq.CreateFileVersion(t.ctx, gen.CreateFileVersionParams{
+		VersionID: version.VersionId,
+		FileID:    version.FileId,
+		Size:      version.Size,
+		CreatedAt: version.CreatedAt.UnixMilli(),
+		CreatedBy: version.CreatedBy,
+		Checksum:  checksum,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	version.Version = number
+	return nil
+}
+
+func (t *txFilesRepository) DeleteVersion(tx *sql.Tx, versionId string) error {
+	nq := t.queries.WithTx(tx)
+
+	return nq.DeleteFileVersionByID(t.ctx, versionId)
+}
+
 func (t *txFilesRepository) DeleteFilePermissionByFileId(tx *sql.Tx, fileId string) error {
 	nq := t.queries.WithTx(tx)
 
 	return nq.DeleteFilePermissionByFileID(t.ctx, fileId)
 }
+
+func (t *txFilesRepository) DeleteFileLinksByFileId(tx *sql.Tx, fileId string) error {
+	nq := t.queries.WithTx(tx)
+
+	return nq.DeleteFileLinksByFileID(t.ctx, fileId)
+}
+
+func (t *txFilesRepository) UpdateOwner(tx *sql.Tx, requesterId string, fileId string, ownerId string) error {
+	nq := t.queries.WithTx(tx)
+
+	return nq.UpdateFileOwnerByID(t.ctx, gen.UpdateFileOwnerByIDParams{
+		FileID:    fileId,
+		OwnerID:   ownerId,
+		UpdatedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		UpdatedBy: sql.NullString{String: requesterId, Valid: true},
+	})
+}
+
+// UpdateOwnerByOwnerId also hands the folders over, so the files keep their place in the tree
+func (t *txFilesRepository) UpdateOwnerByOwnerId(tx *sql.Tx, requesterId string, ownerId string, newOwnerId string) (int64, error) {
+	nq := t.queries.WithTx(tx)
+
+	updatedAt := sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true}
+	updatedBy := sql.NullString{String: requesterId, Valid: true}
+
+	err := nq.UpdateFoldersOwnerByOwnerID(t.ctx, gen.UpdateFoldersOwnerByOwnerIDParams{
+		OwnerID:    ownerId,
+		NewOwnerID: newOwnerId,
+		UpdatedAt:  updatedAt,
+		UpdatedBy:  updatedBy,
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return nq.UpdateFilesOwnerByOwnerID(t.ctx, gen.UpdateFilesOwnerByOwnerIDParams{
+		OwnerID:    ownerId,
+		NewOwnerID: newOwnerId,
+		UpdatedAt:  updatedAt,
+		UpdatedBy:  updatedBy,
+	})
+}
+
+func (t *txFilesRepository) SavePermission(tx *sql.Tx, fileId string, userId string, permission string) error {
+	nq := t.queries.WithTx(tx)
+
+	return nq.CreateFilePermission(t.ctx, gen.CreateFilePermissionParams{
+		PermissionID: uuid.NewString(),
+		FileID:       fileId,
+		Permission:   permission,
+		UserID:       sql.NullString{String: userId, Valid: true},
+	})
+}
+
+func (t *txFilesRepository) DeletePermission(tx *sql.Tx, fileId string, userId string) error {
+	nq := t.queries.WithTx(tx)
+
+	return nq.DeleteFilePermissionByFileIDAndUserID(t.ctx, gen.DeleteFilePermissionByFileIDAndUserIDParams{
+		FileID: fileId,
+		UserID: sql.NullString{String: userId, Valid: true},
+	})
+}
+
+func (t *txFilesRepository) DeletePermissionsByOwnerId(tx *sql.Tx, ownerId string, userId string) error {
+	nq := t.queries.WithTx(tx)
+
+	return nq.DeleteFilePermissionsByOwnerIDAndUserID(t.ctx, gen.DeleteFilePermissionsByOwnerIDAndUserIDParams{
+		OwnerID: ownerId,
+		UserID:  sql.NullString{String: userId, Valid: true},
+	})
+}
+
+func (t *txFilesRepository) SaveOwnerEditorPermissions(tx *sql.Tx, ownerId string) error {
+	nq := t.queries.WithTx(tx)
+
+	return nq.CreateOwnerEditorPermissions(t.ctx, ownerId)
+}
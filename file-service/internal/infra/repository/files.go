@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"log/slog"
 	"time"
 
@@ -14,17 +15,22 @@ import (
 
 type filesRepository struct {
 	ctx     context.Context
+	db      *sql.DB
 	queries *gen.Queries
 }
 
 var _ repository.FilesRepository = (*filesRepository)(nil)
 
 func NewFilesRepository(ctx context.Context, db *sql.DB) *filesRepository {
-	return &filesRepository{queries: gen.New(db), ctx: ctx}
+	return &filesRepository{queries: gen.New(db), db: db, ctx: ctx}
 }
 
 func (r *filesRepository) Save(file *entity.File) error {
-	file.FileId = uuid.NewString()
+	// the ID is usually set by entity.NewFile, since the blob is stored under it before the row exists
+	if file.FileId == "" {
+		file.FileId = uuid.NewString()
+	}
+
 	file.CreatedAt = time.Now()
 	ts := time.Now()
 	file.UpdatedAt = &ts
@@ -37,6 +43,8 @@ func (r *filesRepository) Save(file *entity.File) error {
 		FileID:    file.FileId,
 		CreatedAt: file.CreatedAt.UnixMilli(),
 		CreatedBy: file.Owner,
+		ParentID:  sql.NullString{String: file.ParentId, Valid: file.ParentId != ""},
+		Checksum:  sql.NullString{String: file.Checksum, Valid: file.Checksum != ""},
 	})
 
 	if err != nil {
@@ -47,8 +55,8 @@ func (r *filesRepository) Save(file *entity.File) error {
 	return nil
 }
 
-func (r *filesRepository) FindById(id string, userId string) (*entity.File, error) {
-	rows, err := r.queries.FindFileByID(r.ctx, gen.FindFileByIDParams{FileID: id, OwnerID: userId})
+func (r *filesRepository) FindById(userId string, groups []string, fileId string) (*entity.File, error) {
+	rows, err := r.queries.FindFileByID(r.ctx, gen.FindFileByIDParams{FileID: fileId, OwnerID: userId, Groups: groupsParam(groups)})
 
 	if err != nil {
 		return nil, err
@@ -58,48 +66,113 @@ func (r *filesRepository) FindById(id string, userId string) (*entity.File, erro
 		return nil, repository.ErrFileDoesNotExists
 	}
 
-	ref := rows[0]
+	return mapFileRows(rows), nil
+}
 
-	updatedAt := time.UnixMilli(ref.UpdatedAt.Int64)
+func (r *filesRepository) FindByName(ownerId string, folderId string, filename string) (*entity.File, error) {
+	row, err := r.queries.FindFileByName(r.ctx, gen.FindFileByNameParams{OwnerID: ownerId, FileName: filename, ParentID: folderId})
 
-	file := &entity.File{
-		FileId:    ref.FileID,
-		Filename:  ref.FileName,
-		Size:      ref.Size,
-		Secret:    ref.IsSecret,
-		Owner:     ref.OwnerID,
-		CreatedAt: time.UnixMilli(ref.CreatedAt),
-		UpdatedAt: &updatedAt,
-		CreatedBy: ref.CreatedBy,
-		UpdatedBy: &ref.UpdatedBy.String,
-		Viewers:   []string{},
-		Editors:   []string{},
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrFileDoesNotExists
 	}
 
-	for _, row := range rows {
-		if !row.Permission.Valid {
-			continue
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		permission := row.Permission.String
+	return mapFile(row), nil
+}
 
-		if permission == "VIEWER" {
-			file.Viewers = append(file.Viewers, row.UserID.String)
-		}
+func (r *filesRepository) Trash(userId string, groups []string, fileId string) error {
+	return r.queries.TrashFileByID(r.ctx, gen.TrashFileByIDParams{
+		FileID:    fileId,
+		OwnerID:   userId,
+		Groups:    groupsParam(groups),
+		DeletedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		DeletedBy: sql.NullString{String: userId, Valid: true},
+	})
+}
 
-		if permission == "EDITOR" {
-			file.Editors = append(file.Editors, row.UserID.String)
-		}
+// Restore brings the file back to its folder, or to the top level when the folder was deleted meanwhile
+func (r *filesRepository) Restore(ownerId string, fileId string) error {
+	affected, err := r.queries.RestoreFileByID(r.ctx, gen.RestoreFileByIDParams{
+		FileID:    fileId,
+		OwnerID:   ownerId,
+		UpdatedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+		UpdatedBy: sql.NullString{String: ownerId, Valid: true},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrFileDoesNotExists
 	}
 
-	return file, nil
+	return nil
 }
 
-func (r *filesRepository) Delete(userId string, fileId string) error {
-	return r.queries.DeleteFileByID(r.ctx, gen.DeleteFileByIDParams{FileID: fileId, OwnerID: userId})
+// Purge permanently removes the file row along with its permissions, links, versions and everything users attached to it.
+// The blobs of the file and its versions are queued for deletion in the same transaction, so they are
+// removed from storage even when that fails right after the commit
+func (r *filesRepository) Purge(fileId string) error {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	nq := r.queries.WithTx(tx)
+
+	deletion := gen.CreateFileBlobDeletionsParams{CreatedAt: time.Now().UnixMilli(), FileID: fileId}
+
+	if err := nq.CreateFileBlobDeletions(r.ctx, deletion); err != nil {
+		return err
+	}
+
+	if err := nq.DeleteFilePermissionByFileID(r.ctx, fileId); err != nil {
+		return err
+	}
+
+	if err := nq.DeleteFileLinksByFileID(r.ctx, fileId); err != nil {
+		return err
+	}
+
+	if err := nq.DeleteFileVersionsByFileID(r.ctx, fileId); err != nil {
+		return err
+	}
+
+	if err := nq.DeleteFileTagsByFileID(r.ctx, fileId); err != nil {
+		return err
+	}
+
+	if err := nq.DeleteFileMetadataByFileID(r.ctx, fileId); err != nil {
+		return err
+	}
+
+	if err := nq.DeleteFileStarsByFileID(r.ctx, fileId); err != nil {
+		return err
+	}
+
+	if err := nq.DeleteFileCommentsByFileID(r.ctx, fileId); err != nil {
+		return err
+	}
+
+	if err := nq.DeleteFileLock(r.ctx, fileId); err != nil {
+		return err
+	}
+
+	if err := nq.DeleteFileByID(r.ctx, fileId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
 }
 
-func (r *filesRepository) Update(userId string, file *entity.File) error {
+func (r *filesRepository) Update(userId string, groups []string, file *entity.File) error {
 	ts := time.Now()
 	file.UpdatedAt = &ts
 	file.UpdatedBy = &userId
@@ -107,6 +180,7 @@ func (r *filesRepository) Update(userId string, file *entity.File) error {
 	return r.queries.UpdateFileByID(r.ctx, gen.UpdateFileByIDParams{
 		FileID:    file.FileId,
 		OwnerID:   userId,
+		Groups:    groupsParam(groups),
 		FileName:  file.Filename,
 		IsSecret:  file.Secret,
 		UpdatedAt: sql.NullInt64{Int64: file.UpdatedAt.UnixMilli(), Valid: true},
@@ -114,11 +188,133 @@ func (r *filesRepository) Update(userId string, file *entity.File) error {
 	})
 }
 
-func (r *filesRepository) FindAll(userId string, page int, size int, filename string, secret bool) (filesPage *entity.FilePage, err error) {
+func (r *filesRepository) UpdateParent(userId string, groups []string, file *entity.File) (bool, error) {
+	ts := time.Now()
+	file.UpdatedAt = &ts
+	file.UpdatedBy = &userId
+
+	affected, err := r.queries.UpdateFileParentByID(r.ctx, gen.UpdateFileParentByIDParams{
+		FileID:    file.FileId,
+		OwnerID:   userId,
+		Groups:    groupsParam(groups),
+		ParentID:  sql.NullString{String: file.ParentId, Valid: file.ParentId != ""},
+		UpdatedAt: sql.NullInt64{Int64: ts.UnixMilli(), Valid: true},
+		UpdatedBy: sql.NullString{String: userId, Valid: true},
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *filesRepository) FindAll(userId string, groups []string, page int, size int, filename string, secret bool, folderId string, tag string,
+	metadata map[string]string, starred bool) (filesPage *entity.FilePage, err error) {
 	rows, err := r.queries.FindAllFiles(r.ctx, gen.FindAllFilesParams{
 		OwnerID:  userId,
+		Groups:   groupsParam(groups),
 		FileName: "%" + filename + "%",
 		IsSecret: secret,
+		FolderID: folderId,
+		Tag:      tag,
+		Metadata: metadataParam(metadata),
+		Starred:  starred,
+		Limit:    int64(size),
+		Offset:   int64(page) * int64(size),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount := 0
+
+	if len(rows) != 0 {
+		totalCount = int(rows[0].Totalcount)
+	}
+
+	filePage := &entity.FilePage{Count: totalCount, Content: make([]*entity.File, len(rows))}
+
+	for i, row := range rows {
+		filePage.Content[i] = &entity.File{
+			FileId:    row.FileID,
+			Filename:  row.FileName,
+			Size:      row.Size,
+			Secret:    row.IsSecret,
+			Owner:     row.OwnerID,
+			ParentId:  row.ParentID.String,
+			Starred:   row.Starred,
+			CreatedAt: time.UnixMilli(row.CreatedAt),
+			CreatedBy: row.CreatedBy,
+		}
+
+		if row.UpdatedAt.Valid {
+			updatedAt := time.UnixMilli(row.UpdatedAt.Int64)
+			filePage.Content[i].UpdatedAt = &updatedAt
+		}
+
+		if row.UpdatedBy.Valid {
+			updatedBy := row.UpdatedBy.String
+			filePage.Content[i].UpdatedBy = &updatedBy
+		}
+	}
+
+	return filePage, nil
+}
+
+func (r *filesRepository) FindAllShared(userId string, groups []string, page int, size int) (filesPage *entity.FilePage, err error) {
+	rows, err := r.queries.FindAllSharedFiles(r.ctx, gen.FindAllSharedFilesParams{
+		UserID: userId,
+		Groups: groupsParam(groups),
+		Limit:  int64(size),
+		Offset: int64(page) * int64(size),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount := 0
+
+	if len(rows) != 0 {
+		totalCount = int(rows[0].Totalcount)
+	}
+
+	filePage := &entity.FilePage{Count: totalCount, Content: make([]*entity.File, len(rows))}
+
+	for i, row := range rows {
+		filePage.Content[i] = &entity.File{
+			FileId:     row.FileID,
+			Filename:   row.FileName,
+			Size:       row.Size,
+			Secret:     row.IsSecret,
+			Owner:      row.OwnerID,
+			ParentId:   row.ParentID.String,
+			Permission: row.Permission,
+			Starred:    row.Starred,
+			CreatedAt:  time.UnixMilli(row.CreatedAt),
+			CreatedBy:  row.CreatedBy,
+		}
+
+		if row.UpdatedAt.Valid {
+			updatedAt := time.UnixMilli(row.UpdatedAt.Int64)
+			filePage.Content[i].UpdatedAt = &updatedAt
+		}
+
+		if row.UpdatedBy.Valid {
+			updatedBy := row.UpdatedBy.String
+			filePage.Content[i].UpdatedBy = &updatedBy
+		}
+	}
+
+	return filePage, nil
+}
+
+func (r *filesRepository) FindAllByOwnerId(ownerId string, page int, size int, filename string) (filesPage *entity.FilePage, err error) {
+	rows, err := r.queries.FindAllFilesByOwnerID(r.ctx, gen.FindAllFilesByOwnerIDParams{
+		OwnerID:  ownerId,
+		FileName: "%" + filename + "%",
 		Limit:    int64(size),
 		Offset:   int64(page) * int64(size),
 	})
@@ -142,6 +338,7 @@ func (r *filesRepository) FindAll(userId string, page int, size int, filename st
 			Size:      row.Size,
 			Secret:    row.IsSecret,
 			Owner:     row.OwnerID,
+			ParentId:  row.ParentID.String,
 			CreatedAt: time.UnixMilli(row.CreatedAt),
 			CreatedBy: row.CreatedBy,
 		}
@@ -155,11 +352,178 @@ func (r *filesRepository) FindAll(userId string, page int, size int, filename st
 			updatedBy := row.UpdatedBy.String
 			filePage.Content[i].UpdatedBy = &updatedBy
 		}
+
+		if row.DeletedAt.Valid {
+			deletedAt := time.UnixMilli(row.DeletedAt.Int64)
+			filePage.Content[i].DeletedAt = &deletedAt
+		}
 	}
 
 	return filePage, nil
 }
 
+func (r *filesRepository) FindAllTrashed(ownerId string, page int, size int) (filesPage *entity.FilePage, err error) {
+	rows, err := r.queries.FindAllTrashedFiles(r.ctx, gen.FindAllTrashedFilesParams{
+		OwnerID: ownerId,
+		Limit:   int64(size),
+		Offset:  int64(page) * int64(size),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount := 0
+
+	if len(rows) != 0 {
+		totalCount = int(rows[0].Totalcount)
+	}
+
+	filePage := &entity.FilePage{Count: totalCount, Content: make([]*entity.File, len(rows))}
+
+	for i, row := range rows {
+		deletedAt := time.UnixMilli(row.DeletedAt.Int64)
+
+		filePage.Content[i] = &entity.File{
+			FileId:    row.FileID,
+			Filename:  row.FileName,
+			Size:      row.Size,
+			Secret:    row.IsSecret,
+			Owner:     row.OwnerID,
+			ParentId:  row.ParentID.String,
+			CreatedAt: time.UnixMilli(row.CreatedAt),
+			CreatedBy: row.CreatedBy,
+			DeletedAt: &deletedAt,
+		}
+
+		if row.UpdatedAt.Valid {
+			updatedAt := time.UnixMilli(row.UpdatedAt.Int64)
+			filePage.Content[i].UpdatedAt = &updatedAt
+		}
+
+		if row.UpdatedBy.Valid {
+			updatedBy := row.UpdatedBy.String
+			filePage.Content[i].UpdatedBy = &updatedBy
+		}
+
+		if row.DeletedBy.Valid {
+			deletedBy := row.DeletedBy.String
+			filePage.Content[i].DeletedBy = &deletedBy
+		}
+	}
+
+	return filePage, nil
+}
+
+func (r *filesRepository) FindAllExpiredTrash(deletedBefore time.Time, limit int) ([]string, error) {
+	return r.queries.FindExpiredTrashedFileIDs(r.ctx, gen.FindExpiredTrashedFileIDsParams{
+		DeletedBefore: sql.NullInt64{Int64: deletedBefore.UnixMilli(), Valid: true},
+		Limit:         int64(limit),
+	})
+}
+
+func (r *filesRepository) FindAllChecksummed(afterFileId string, limit int) ([]*entity.File, error) {
+	rows, err := r.queries.FindChecksummedFiles(r.ctx, gen.FindChecksummedFilesParams{
+		AfterFileID: afterFileId,
+		Limit:       int64(limit),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*entity.File, len(rows))
+
+	for i, row := range rows {
+		files[i] = &entity.File{FileId: row.FileID, Checksum: row.Checksum.String}
+
+		if row.CorruptedAt.Valid {
+			corruptedAt := time.UnixMilli(row.CorruptedAt.Int64)
+			files[i].CorruptedAt = &corruptedAt
+		}
+	}
+
+	return files, nil
+}
+
+func (r *filesRepository) UpdateCorruptedAt(fileId string, checksum string, corruptedAt *time.Time) error {
+	param := sql.NullInt64{}
+
+	if corruptedAt != nil {
+		param = sql.NullInt64{Int64: corruptedAt.UnixMilli(), Valid: true}
+	}
+
+	return r.queries.UpdateFileCorruptedAtByID(r.ctx, gen.UpdateFileCorruptedAtByIDParams{
+		CorruptedAt: param,
+		FileID:      fileId,
+		Checksum:    checksum,
+	})
+}
+
+func (r *filesRepository) FindAllSizes() ([]*entity.File, error) {
+	rows, err := r.queries.FindAllFileSizes(r.ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*entity.File, len(rows))
+
+	for i, row := range rows {
+		files[i] = &entity.File{FileId: row.FileID, Size: row.Size, Checksum: row.Checksum.String, CreatedAt: time.UnixMilli(row.CreatedAt)}
+
+		if row.UpdatedAt.Valid {
+			updatedAt := time.UnixMilli(row.UpdatedAt.Int64)
+			files[i].UpdatedAt = &updatedAt
+		}
+	}
+
+	return files, nil
+}
+
+func (r *filesRepository) UpdateSize(fileId string, size int64) error {
+	return r.queries.UpdateFileSizeByID(r.ctx, gen.UpdateFileSizeByIDParams{Size: size, FileID: fileId})
+}
+
+func (r *filesRepository) FindAllUsage(page int, size int) (usagePage *entity.UsagePage, err error) {
+	rows, err := r.queries.FindAllUsages(r.ctx, gen.FindAllUsagesParams{
+		Limit:  int64(size),
+		Offset: int64(page) * int64(size),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount := 0
+
+	if len(rows) != 0 {
+		totalCount = int(rows[0].Totalcount)
+	}
+
+	usagePage = &entity.UsagePage{Count: totalCount, Content: make([]*entity.Usage, len(rows))}
+
+	for i, row := range rows {
+		usagePage.Content[i] = &entity.Usage{
+			UserId: row.OwnerID,
+			Files:  row.Files,
+			Size:   row.TotalSize,
+		}
+	}
+
+	return usagePage, nil
+}
+
+func (r *filesRepository) FindTotals() (*entity.Totals, error) {
+	row, err := r.queries.FindServerTotals(r.ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Totals{Users: row.Users, Files: row.Files, Size: row.TotalSize}, nil
+}
+
 func (r *filesRepository) FindUsageByUserId(userId string) (int64, error) {
 	row, err := r.queries.FindUsageByUserID(r.ctx, userId)
 
@@ -177,3 +541,160 @@ func (r *filesRepository) FindUsageByUserId(userId string) (int64, error) {
 func (r *filesRepository) DeleteFilePermissionByFileId(fileId string) error {
 	return r.queries.DeleteFilePermissionByFileID(r.ctx, fileId)
 }
+
+func (r *filesRepository) SavePermission(fileId string, userId string, permission string) error {
+	return r.queries.CreateFilePermission(r.ctx, gen.CreateFilePermissionParams{
+		PermissionID: uuid.NewString(),
+		FileID:       fileId,
+		Permission:   permission,
+		UserID:       sql.NullString{String: userId, Valid: true},
+	})
+}
+
+func (r *filesRepository) DeletePermission(fileId string, userId string) error {
+	return r.queries.DeleteFilePermissionByFileIDAndUserID(r.ctx, gen.DeleteFilePermissionByFileIDAndUserIDParams{
+		FileID: fileId,
+		UserID: sql.NullString{String: userId, Valid: true},
+	})
+}
+
+func (r *filesRepository) SaveGroupPermission(fileId string, group string, permission string) error {
+	return r.queries.CreateFileGroupPermission(r.ctx, gen.CreateFileGroupPermissionParams{
+		PermissionID: uuid.NewString(),
+		FileID:       fileId,
+		Permission:   permission,
+		GroupName:    sql.NullString{String: group, Valid: true},
+	})
+}
+
+func (r *filesRepository) DeleteGroupPermission(fileId string, group string) error {
+	return r.queries.DeleteFilePermissionByFileIDAndGroupName(r.ctx, gen.DeleteFilePermissionByFileIDAndGroupNameParams{
+		FileID:    fileId,
+		GroupName: sql.NullString{String: group, Valid: true},
+	})
+}
+
+// groupsParam encodes the caller groups as a JSON array, which is how
+// the queries match them against files_permissions.group_name
+func groupsParam(groups []string) string {
+	if len(groups) == 0 {
+		return "[]"
+	}
+
+	encoded, err := json.Marshal(groups)
+
+	if err != nil {
+		return "[]"
+	}
+
+	return string(encoded)
+}
+
+// metadataParam encodes the metadata filter as a JSON object, where every entry must match
+func metadataParam(metadata map[string]string) string {
+	if len(metadata) == 0 {
+		return "{}"
+	}
+
+	encoded, err := json.Marshal(metadata)
+
+	if err != nil {
+		return "{}"
+	}
+
+	return string(encoded)
+}
+
+func mapFileRows(rows []gen.FindFileByIDRow) *entity.File {
+	ref := rows[0]
+
+	updatedAt := time.UnixMilli(ref.UpdatedAt.Int64)
+
+	file := &entity.File{
+		FileId:       ref.FileID,
+		Filename:     ref.FileName,
+		Size:         ref.Size,
+		Secret:       ref.IsSecret,
+		Owner:        ref.OwnerID,
+		ParentId:     ref.ParentID.String,
+		CreatedAt:    time.UnixMilli(ref.CreatedAt),
+		UpdatedAt:    &updatedAt,
+		CreatedBy:    ref.CreatedBy,
+		UpdatedBy:    &ref.UpdatedBy.String,
+		Checksum:     ref.Checksum.String,
+		Viewers:      []string{},
+		Editors:      []string{},
+		ViewerGroups: []string{},
+		EditorGroups: []string{},
+	}
+
+	if ref.CorruptedAt.Valid {
+		corruptedAt := time.UnixMilli(ref.CorruptedAt.Int64)
+		file.CorruptedAt = &corruptedAt
+	}
+
+	for _, row := range rows {
+		if !row.Permission.Valid {
+			continue
+		}
+
+		permission := row.Permission.String
+
+		if row.GroupName.Valid {
+			if permission == entity.PermissionViewer {
+				file.ViewerGroups = append(file.ViewerGroups, row.GroupName.String)
+			}
+
+			if permission == entity.PermissionEditor {
+				file.EditorGroups = append(file.EditorGroups, row.GroupName.String)
+			}
+
+			continue
+		}
+
+		if permission == entity.PermissionViewer {
+			file.Viewers = append(file.Viewers, row.UserID.String)
+		}
+
+		if permission == entity.PermissionEditor {
+			file.Editors = append(file.Editors, row.UserID.String)
+		}
+	}
+
+	return file
+}
+
+func mapFile(row gen.File) *entity.File {
+	file := &entity.File{
+		FileId:       row.FileID,
+		Filename:     row.FileName,
+		Size:         row.Size,
+		Secret:       row.IsSecret,
+		Owner:        row.OwnerID,
+		ParentId:     row.ParentID.String,
+		CreatedAt:    time.UnixMilli(row.CreatedAt),
+		CreatedBy:    row.CreatedBy,
+		Checksum:     row.Checksum.String,
+		Viewers:      []string{},
+		Editors:      []string{},
+		ViewerGroups: []string{},
+		EditorGroups: []string{},
+	}
+
+	if row.CorruptedAt.Valid {
+		corruptedAt := time.UnixMilli(row.CorruptedAt.Int64)
+		file.CorruptedAt = &corruptedAt
+	}
+
+	if row.UpdatedAt.Valid {
+		updatedAt := time.UnixMilli(row.UpdatedAt.Int64)
+		file.UpdatedAt = &updatedAt
+	}
+
+	if row.UpdatedBy.Valid {
+		updatedBy := row.UpdatedBy.String
+		file.UpdatedBy = &updatedBy
+	}
+
+	return file
+}
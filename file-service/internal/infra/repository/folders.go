@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type foldersRepository struct {
+	ctx     context.Context
+	queries *gen.Queries
+}
+
+var _ repository.FoldersRepository = (*foldersRepository)(nil)
+
+func NewFoldersRepository(ctx context.Context, db *sql.DB) *foldersRepository {
+	return &foldersRepository{queries: gen.New(db), ctx: ctx}
+}
+
+func (r *foldersRepository) Save(folder *entity.Folder) error {
+	return r.queries.CreateFolder(r.ctx, gen.CreateFolderParams{
+		FolderID:  folder.FolderId,
+		Name:      folder.Name,
+		ParentID:  sql.NullString{String: folder.ParentId, Valid: folder.ParentId != ""},
+		OwnerID:   folder.Owner,
+		CreatedAt: folder.CreatedAt.UnixMilli(),
+		CreatedBy: folder.CreatedBy,
+	})
+}
+
+func (r *foldersRepository) FindById(ownerId string, folderId string) (*entity.Folder, error) {
+	row, err := r.queries.FindFolderByID(r.ctx, gen.FindFolderByIDParams{FolderID: folderId, OwnerID: ownerId})
+
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrFolderDoesNotExists
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mapFolder(row), nil
+}
+
+func (r *foldersRepository) FindAllByParentId(ownerId string, parentId string) ([]*entity.Folder, error) {
+	rows, err := r.queries.FindFoldersByParentID(r.ctx, gen.FindFoldersByParentIDParams{OwnerID: ownerId, ParentID: parentId})
+
+	if err != nil {
+		return nil, err
+	}
+
+	folders := make([]*entity.Folder, len(rows))
+
+	for i, row := range rows {
+		folders[i] = mapFolder(row)
+	}
+
+	return folders, nil
+}
+
+func (r *foldersRepository) FindPath(folderId string) ([]*entity.Breadcrumb, error) {
+	rows, err := r.queries.FindFolderPath(r.ctx, folderId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	path := make([]*entity.Breadcrumb, len(rows))
+
+	for i, row := range rows {
+		path[i] = &entity.Breadcrumb{FolderId: row.FolderID, Name: row.Name}
+	}
+
+	return path, nil
+}
+
+func (r *foldersRepository) CountChildren(folderId string) (int64, error) {
+	return r.queries.CountFolderChildren(r.ctx, sql.NullString{String: folderId, Valid: true})
+}
+
+func (r *foldersRepository) Update(ownerId string, folder *entity.Folder) error {
+	ts := time.Now()
+	folder.UpdatedAt = &ts
+	folder.UpdatedBy = &ownerId
+
+	affected, err := r.queries.UpdateFolderByID(r.ctx, gen.UpdateFolderByIDParams{
+		FolderID:  folder.FolderId,
+		OwnerID:   ownerId,
+		Name:      folder.Name,
+		UpdatedAt: sql.NullInt64{Int64: ts.UnixMilli(), Valid: true},
+		UpdatedBy: sql.NullString{String: ownerId, Valid: true},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrFolderDoesNotExists
+	}
+
+	return nil
+}
+
+func (r *foldersRepository) Delete(ownerId string, folderId string) error {
+	affected, err := r.queries.DeleteFolderByID(r.ctx, gen.DeleteFolderByIDParams{FolderID: folderId, OwnerID: ownerId})
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrFolderDoesNotExists
+	}
+
+	return nil
+}
+
+func mapFolder(row gen.Folder) *entity.Folder {
+	folder := &entity.Folder{
+		FolderId:  row.FolderID,
+		Name:      row.Name,
+		ParentId:  row.ParentID.String,
+		Owner:     row.OwnerID,
+		CreatedAt: time.UnixMilli(row.CreatedAt),
+		CreatedBy: row.CreatedBy,
+	}
+
+	if row.UpdatedAt.Valid {
+		updatedAt := time.UnixMilli(row.UpdatedAt.Int64)
+		folder.UpdatedAt = &updatedAt
+	}
+
+	if row.UpdatedBy.Valid {
+		updatedBy := row.UpdatedBy.String
+		folder.UpdatedBy = &updatedBy
+	}
+
+	return folder
+}
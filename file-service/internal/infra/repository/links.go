@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type linksRepository struct {
+	ctx     context.Context
+	queries *gen.Queries
+}
+
+var _ repository.LinksRepository = (*linksRepository)(nil)
+
+func NewLinksRepository(ctx context.Context, db *sql.DB) *linksRepository {
+	return &linksRepository{queries: gen.New(db), ctx: ctx}
+}
+
+func (r *linksRepository) Save(link *entity.Link) error {
+	params := gen.CreateFileLinkParams{
+		Token:        link.Token,
+		FileID:       link.FileId,
+		PasswordHash: sql.NullString{String: link.PasswordHash, Valid: link.PasswordHash != ""},
+		CreatedAt:    link.CreatedAt.UnixMilli(),
+		CreatedBy:    link.CreatedBy,
+	}
+
+	if link.ExpiresAt != nil {
+		params.ExpiresAt = sql.NullInt64{Int64: link.ExpiresAt.UnixMilli(), Valid: true}
+	}
+
+	if link.MaxDownloads != nil {
+		params.MaxDownloads = sql.NullInt64{Int64: *link.MaxDownloads, Valid: true}
+	}
+
+	return r.queries.CreateFileLink(r.ctx, params)
+}
+
+func (r *linksRepository) FindAllByFileId(fileId string) ([]*entity.Link, error) {
+	rows, err := r.queries.FindFileLinksByFileID(r.ctx, fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*entity.Link, len(rows))
+
+	for i, row := range rows {
+		links[i] = mapLink(row.Token, row.FileID, row.PasswordHash, row.ExpiresAt, row.MaxDownloads, row.Downloads, row.CreatedAt, row.CreatedBy)
+	}
+
+	return links, nil
+}
+
+func (r *linksRepository) FindByToken(token string) (*entity.Link, *entity.File, error) {
+	row, err := r.queries.FindFileLinkByToken(r.ctx, token)
+
+	if err == sql.ErrNoRows {
+		return nil, nil, repository.ErrLinkDoesNotExists
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	link := mapLink(row.Token, row.FileID, row.PasswordHash, row.ExpiresAt, row.MaxDownloads, row.Downloads, row.CreatedAt, row.CreatedBy)
+
+	file := &entity.File{
+		FileId:   row.FileID,
+		Filename: row.FileName,
+		Size:     row.Size,
+	}
+
+	return link, file, nil
+}
+
+func (r *linksRepository) Delete(fileId string, token string) error {
+	return r.queries.DeleteFileLink(r.ctx, gen.DeleteFileLinkParams{FileID: fileId, Token: token})
+}
+
+func (r *linksRepository) IncrementDownloads(token string) (bool, error) {
+	affected, err := r.queries.IncrementFileLinkDownloads(r.ctx, token)
+
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func mapLink(token string, fileId string, passwordHash sql.NullString, expiresAt sql.NullInt64, maxDownloads sql.NullInt64, downloads int64, createdAt int64, createdBy string) *entity.Link {
+	link := &entity.Link{
+		Token:        token,
+		FileId:       fileId,
+		PasswordHash: passwordHash.String,
+		Downloads:    downloads,
+		CreatedAt:    time.UnixMilli(createdAt),
+		CreatedBy:    createdBy,
+	}
+
+	if expiresAt.Valid {
+		ts := time.UnixMilli(expiresAt.Int64)
+		link.ExpiresAt = &ts
+	}
+
+	if maxDownloads.Valid {
+		limit := maxDownloads.Int64
+		link.MaxDownloads = &limit
+	}
+
+	return link
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type locksRepository struct {
+	ctx     context.Context
+	queries *gen.Queries
+}
+
+var _ repository.LocksRepository = (*locksRepository)(nil)
+
+func NewLocksRepository(ctx context.Context, db *sql.DB) *locksRepository {
+	return &locksRepository{queries: gen.New(db), ctx: ctx}
+}
+
+// Save takes the lock when the file is free or its lock expired, and extends it when the holder locks it again
+func (r *locksRepository) Save(lock *entity.Lock) error {
+	affected, err := r.queries.CreateFileLock(r.ctx, gen.CreateFileLockParams{
+		FileID:    lock.FileId,
+		LockedBy:  lock.LockedBy,
+		LockedAt:  lock.LockedAt.UnixMilli(),
+		ExpiresAt: lock.ExpiresAt.UnixMilli(),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrFileLocked
+	}
+
+	return nil
+}
+
+func (r *locksRepository) FindByFileId(fileId string) (*entity.Lock, error) {
+	row, err := r.queries.FindFileLock(r.ctx, gen.FindFileLockParams{FileID: fileId, ExpiresAt: time.Now().UnixMilli()})
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.Lock{
+		FileId:    row.FileID,
+		LockedBy:  row.LockedBy,
+		LockedAt:  time.UnixMilli(row.LockedAt),
+		ExpiresAt: time.UnixMilli(row.ExpiresAt),
+	}, nil
+}
+
+func (r *locksRepository) Delete(fileId string) error {
+	return r.queries.DeleteFileLock(r.ctx, fileId)
+}
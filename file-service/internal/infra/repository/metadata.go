@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type metadataRepository struct {
+	ctx     context.Context
+	db      *sql.DB
+	queries *gen.Queries
+}
+
+var _ repository.MetadataRepository = (*metadataRepository)(nil)
+
+func NewMetadataRepository(ctx context.Context, db *sql.DB) *metadataRepository {
+	return &metadataRepository{queries: gen.New(db), db: db, ctx: ctx}
+}
+
+// Replace swaps every entry of the file for the given ones, so keys left out are removed
+func (r *metadataRepository) Replace(fileId string, metadata map[string]string) error {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	nq := r.queries.WithTx(tx)
+
+	if err := nq.DeleteFileMetadataByFileID(r.ctx, fileId); err != nil {
+		return err
+	}
+
+	for key, value := range metadata {
+		if err := nq.CreateFileMetadata(r.ctx, gen.CreateFileMetadataParams{FileID: fileId, Key: key, Value: value}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *metadataRepository) FindByFileId(fileId string) (map[string]string, error) {
+	rows, err := r.queries.FindFileMetadataByFileID(r.ctx, fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := make(map[string]string, len(rows))
+
+	for _, row := range rows {
+		metadata[row.Key] = row.Value
+	}
+
+	return metadata, nil
+}
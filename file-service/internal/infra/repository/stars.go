@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type starsRepository struct {
+	ctx     context.Context
+	queries *gen.Queries
+}
+
+var _ repository.StarsRepository = (*starsRepository)(nil)
+
+func NewStarsRepository(ctx context.Context, db *sql.DB) *starsRepository {
+	return &starsRepository{queries: gen.New(db), ctx: ctx}
+}
+
+func (r *starsRepository) Save(fileId string, userId string) error {
+	return r.queries.CreateFileStar(r.ctx, gen.CreateFileStarParams{FileID: fileId, UserID: userId, CreatedAt: time.Now().UnixMilli()})
+}
+
+func (r *starsRepository) Delete(fileId string, userId string) error {
+	return r.queries.DeleteFileStar(r.ctx, gen.DeleteFileStarParams{FileID: fileId, UserID: userId})
+}
+
+func (r *starsRepository) Exists(fileId string, userId string) (bool, error) {
+	count, err := r.queries.CountFileStars(r.ctx, gen.CountFileStarsParams{FileID: fileId, UserID: userId})
+
+	if err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type tagsRepository struct {
+	ctx     context.Context
+	queries *gen.Queries
+}
+
+var _ repository.TagsRepository = (*tagsRepository)(nil)
+
+func NewTagsRepository(ctx context.Context, db *sql.DB) *tagsRepository {
+	return &tagsRepository{queries: gen.New(db), ctx: ctx}
+}
+
+// Save attaches the tag to the file, creating the tag the first time its name is used.
+// Attaching a tag the file already has is a no-op
+func (r *tagsRepository) Save(fileId string, name string, createdBy string) error {
+	if err := r.queries.CreateTag(r.ctx, gen.CreateTagParams{TagID: uuid.NewString(), Name: name}); err != nil {
+		return err
+	}
+
+	return r.queries.CreateFileTag(r.ctx, gen.CreateFileTagParams{
+		FileID:    fileId,
+		CreatedAt: time.Now().UnixMilli(),
+		CreatedBy: createdBy,
+		Name:      name,
+	})
+}
+
+func (r *tagsRepository) Delete(fileId string, name string) error {
+	affected, err := r.queries.DeleteFileTag(r.ctx, gen.DeleteFileTagParams{FileID: fileId, Name: name})
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrTagDoesNotExists
+	}
+
+	return nil
+}
+
+func (r *tagsRepository) FindAllByFileId(fileId string) ([]string, error) {
+	tags, err := r.queries.FindFileTagsByFileID(r.ctx, fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if tags == nil {
+		return []string{}, nil
+	}
+
+	return tags, nil
+}
+
+func (r *tagsRepository) FindAll(userId string, groups []string) ([]*entity.Tag, error) {
+	rows, err := r.queries.FindAllTags(r.ctx, gen.FindAllTagsParams{OwnerID: userId, Groups: groupsParam(groups)})
+
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make([]*entity.Tag, len(rows))
+
+	for i, row := range rows {
+		tags[i] = &entity.Tag{Name: row.Name, Files: row.Files}
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type accessTokensRepository struct {
+	ctx     context.Context
+	queries *gen.Queries
+}
+
+var _ repository.AccessTokensRepository = (*accessTokensRepository)(nil)
+
+func NewAccessTokensRepository(ctx context.Context, db *sql.DB) *accessTokensRepository {
+	return &accessTokensRepository{queries: gen.New(db), ctx: ctx}
+}
+
+func (r *accessTokensRepository) Save(token *entity.AccessToken) error {
+	params := gen.CreateAccessTokenParams{
+		TokenID:   token.TokenId,
+		UserID:    token.UserId,
+		Name:      token.Name,
+		TokenHash: token.TokenHash,
+		Scope:     token.Scope,
+		Groups:    groupsParam(token.Groups),
+		CreatedAt: token.CreatedAt.UnixMilli(),
+	}
+
+	if token.ExpiresAt != nil {
+		params.ExpiresAt = sql.NullInt64{Int64: token.ExpiresAt.UnixMilli(), Valid: true}
+	}
+
+	return r.queries.CreateAccessToken(r.ctx, params)
+}
+
+func (r *accessTokensRepository) FindAllByUserId(userId string) ([]*entity.AccessToken, error) {
+	rows, err := r.queries.FindAccessTokensByUserID(r.ctx, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*entity.AccessToken, len(rows))
+
+	for i, row := range rows {
+		tokens[i] = mapAccessToken(row)
+	}
+
+	return tokens, nil
+}
+
+func (r *accessTokensRepository) FindByHash(tokenHash string) (*entity.AccessToken, error) {
+	row, err := r.queries.FindAccessTokenByHash(r.ctx, tokenHash)
+
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrAccessTokenDoesNotExists
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mapAccessToken(row), nil
+}
+
+func (r *accessTokensRepository) Delete(userId string, tokenId string) error {
+	affected, err := r.queries.DeleteAccessToken(r.ctx, gen.DeleteAccessTokenParams{TokenID: tokenId, UserID: userId})
+
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrAccessTokenDoesNotExists
+	}
+
+	return nil
+}
+
+func (r *accessTokensRepository) UpdateLastUsed(tokenId string) error {
+	return r.queries.UpdateAccessTokenLastUsed(r.ctx, gen.UpdateAccessTokenLastUsedParams{
+		TokenID:    tokenId,
+		LastUsedAt: sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true},
+	})
+}
+
+func mapAccessToken(row gen.AccessToken) *entity.AccessToken {
+	token := &entity.AccessToken{
+		TokenId:   row.TokenID,
+		UserId:    row.UserID,
+		Name:      row.Name,
+		Scope:     row.Scope,
+		Groups:    []string{},
+		CreatedAt: time.UnixMilli(row.CreatedAt),
+		TokenHash: row.TokenHash,
+	}
+
+	// groups are always written by groupsParam, a broken value just means no groups
+	_ = json.Unmarshal([]byte(row.Groups), &token.Groups)
+
+	if row.ExpiresAt.Valid {
+		ts := time.UnixMilli(row.ExpiresAt.Int64)
+		token.ExpiresAt = &ts
+	}
+
+	if row.LastUsedAt.Valid {
+		ts := time.UnixMilli(row.LastUsedAt.Int64)
+		token.LastUsedAt = &ts
+	}
+
+	return token
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type uploadReservationsRepository struct {
+	ctx     context.Context
+	db      *sql.DB
+	queries *gen.Queries
+}
+
+var _ repository.UploadReservationsRepository = (*uploadReservationsRepository)(nil)
+
+func NewUploadReservationsRepository(ctx context.Context, db *sql.DB) *uploadReservationsRepository {
+	return &uploadReservationsRepository{ctx: ctx, db: db, queries: gen.New(db)}
+}
+
+// Save checks the quota and inserts the reservation in a single statement, so two uploads
+// reserving at the same time cannot both take the last of the space
+func (r *uploadReservationsRepository) Save(reservation *entity.UploadReservation, quota int64) error {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	nq := r.queries.WithTx(tx)
+
+	now := time.Now().UnixMilli()
+
+	if err := nq.DeleteExpiredUploadReservations(r.ctx, now); err != nil {
+		return err
+	}
+
+	reserved, err := nq.CreateUploadReservation(r.ctx, gen.CreateUploadReservationParams{
+		ReservationID: reservation.ReservationId,
+		UserID:        reservation.UserId,
+		Size:          reservation.Size,
+		ExpiresAt:     reservation.ExpiresAt.UnixMilli(),
+		Now:           now,
+		Quota:         quota,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if reserved == 0 {
+		return repository.ErrQuotaExceeded
+	}
+
+	return tx.Commit()
+}
+
+func (r *uploadReservationsRepository) FindReservedByUserId(userId string) (int64, error) {
+	return r.queries.FindReservedSizeByUserID(r.ctx, gen.FindReservedSizeByUserIDParams{
+		UserID:    userId,
+		ExpiresAt: time.Now().UnixMilli(),
+	})
+}
+
+func (r *uploadReservationsRepository) FindAllActive() ([]*entity.UploadReservation, error) {
+	rows, err := r.queries.FindActiveUploadReservations(r.ctx, time.Now().UnixMilli())
+
+	if err != nil {
+		return nil, err
+	}
+
+	reservations := make([]*entity.UploadReservation, len(rows))
+
+	for i, row := range rows {
+		reservations[i] = &entity.UploadReservation{ReservationId: row.ReservationID, UserId: row.UserID, Size: row.Size,
+			ExpiresAt: time.UnixMilli(row.ExpiresAt)}
+	}
+
+	return reservations, nil
+}
+
+func (r *uploadReservationsRepository) Delete(reservationId string) error {
+	return r.queries.DeleteUploadReservation(r.ctx, reservationId)
+}
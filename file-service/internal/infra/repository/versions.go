@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/db/gen"
+)
+
+type versionsRepository struct {
+	ctx     context.Context
+	db      *sql.DB
+	queries *gen.Queries
+}
+
+var _ repository.VersionsRepository = (*versionsRepository)(nil)
+
+func NewVersionsRepository(ctx context.Context, db *sql.DB) *versionsRepository {
+	return &versionsRepository{queries: gen.New(db), db: db, ctx: ctx}
+}
+
+// Save numbers the version after the latest one of the file
+func (r *versionsRepository) Save(version *entity.FileVersion) error {
+	number, err := r.queries.CreateFileVersion(r.ctx, gen.CreateFileVersionParams{
+		VersionID: version.VersionId,
+		FileID:    version.FileId,
+		Size:      version.Size,
+		CreatedAt: version.CreatedAt.UnixMilli(),
+		CreatedBy: version.CreatedBy,
+		Checksum:  sql.NullString{String: version.Checksum, Valid: version.Checksum != ""},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	version.Version = number
+	return nil
+}
+
+func (r *versionsRepository) FindAllByFileId(fileId string) ([]*entity.FileVersion, error) {
+	rows, err := r.queries.FindFileVersionsByFileID(r.ctx, fileId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*entity.FileVersion, len(rows))
+
+	for i, row := range rows {
+		versions[i] = mapFileVersion(row)
+	}
+
+	return versions, nil
+}
+
+func (r *versionsRepository) FindByVersion(fileId string, version int64) (*entity.FileVersion, error) {
+	row, err := r.queries.FindFileVersion(r.ctx, gen.FindFileVersionParams{FileID: fileId, Version: version})
+
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrVersionDoesNotExists
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return mapFileVersion(row), nil
+}
+
+func (r *versionsRepository) Delete(versionId string) error {
+	return r.queries.DeleteFileVersionByID(r.ctx, versionId)
+}
+
+func (r *versionsRepository) Purge(versionId string) error {
+	tx, err := r.db.Begin()
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	nq := r.queries.WithTx(tx)
+
+	if err := nq.CreateBlobDeletion(r.ctx, gen.CreateBlobDeletionParams{BlobID: versionId, CreatedAt: time.Now().UnixMilli()}); err != nil {
+		return err
+	}
+
+	if err := nq.DeleteFileVersionByID(r.ctx, versionId); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *versionsRepository) FindAllSizes() ([]*entity.FileVersion, error) {
+	rows, err := r.queries.FindAllFileVersionSizes(r.ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	versions := make([]*entity.FileVersion, len(rows))
+
+	for i, row := range rows {
+		versions[i] = &entity.FileVersion{VersionId: row.VersionID, Size: row.Size, CreatedAt: time.UnixMilli(row.CreatedAt)}
+	}
+
+	return versions, nil
+}
+
+func (r *versionsRepository) UpdateSize(versionId string, size int64) error {
+	return r.queries.UpdateFileVersionSizeByID(r.ctx, gen.UpdateFileVersionSizeByIDParams{Size: size, VersionID: versionId})
+}
+
+func mapFileVersion(row gen.FilesVersion) *entity.FileVersion {
+	return &entity.FileVersion{
+		VersionId: row.VersionID,
+		FileId:    row.FileID,
+		Version:   row.Version,
+		Size:      row.Size,
+		Checksum:  row.Checksum.String,
+		CreatedAt: time.UnixMilli(row.CreatedAt),
+		CreatedBy: row.CreatedBy,
+	}
+}
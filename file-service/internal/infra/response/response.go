@@ -22,11 +22,31 @@ func InternalServerError(w http.ResponseWriter, traceId string) {
 	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
 }
 
+func Forbidden(w http.ResponseWriter, traceId string) {
+	w.Header().Set(traceIdHeaderKey, traceId)
+	http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+}
+
 func NotFound(w http.ResponseWriter, traceId string) {
 	w.Header().Set(traceIdHeaderKey, traceId)
 	http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
 }
 
+func Gone(w http.ResponseWriter, traceId string) {
+	w.Header().Set(traceIdHeaderKey, traceId)
+	http.Error(w, http.StatusText(http.StatusGone), http.StatusGone)
+}
+
+func Locked(w http.ResponseWriter, traceId string) {
+	w.Header().Set(traceIdHeaderKey, traceId)
+	http.Error(w, http.StatusText(http.StatusLocked), http.StatusLocked)
+}
+
+func LengthRequired(w http.ResponseWriter, traceId string) {
+	w.Header().Set(traceIdHeaderKey, traceId)
+	http.Error(w, http.StatusText(http.StatusLengthRequired), http.StatusLengthRequired)
+}
+
 func UnprocessableEntity(w http.ResponseWriter, traceId string) {
 	w.Header().Set(traceIdHeaderKey, traceId)
 	http.Error(w, http.StatusText(http.StatusUnprocessableEntity), http.StatusUnprocessableEntity)
@@ -8,19 +8,49 @@ import (
 	"time"
 
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
 	"github.com/murilo-bracero/raspstore/file-service/internal/application/usecase"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
 )
 
-func StartApiServer(config *config.Config, fileFacade facade.FileFacade, useCases *usecase.UseCases) {
-	filesHandler := handler.NewFilesHandler(fileFacade, useCases.UpdateFileUseCase)
+func StartApiServer(config *config.Config, fileFacade facade.FileFacade, linkFacade facade.LinkFacade, adminFacade facade.AdminFacade,
+	accessTokenFacade facade.AccessTokenFacade, folderFacade facade.FolderFacade, commentFacade facade.CommentFacade,
+	auditRepository repository.AuditRepository, blobStore storage.BlobStore, useCases *usecase.UseCases) {
+	filesHandler := handler.NewFilesHandler(fileFacade, useCases.UpdateFileUseCase, useCases.CopyFileUseCase)
 
-	uploadHanler := handler.NewUploadHandler(config, useCases.UploadUseCase, useCases.CreateFileUseCase)
+	uploadHanler := handler.NewUploadHandler(useCases.UploadUseCase, fileFacade, folderFacade)
 
 	downloadHandler := handler.NewDownloadHandler(useCases.DownloadFileUseCase, fileFacade)
 
-	router := NewFilesRouter(config, filesHandler, uploadHanler, downloadHandler).MountRoutes()
+	permissionsHandler := handler.NewPermissionsHandler(fileFacade)
+
+	linksHandler := handler.NewLinksHandler(linkFacade)
+
+	publicHandler := handler.NewPublicHandler(useCases.DownloadFileUseCase, linkFacade)
+
+	ownershipHandler := handler.NewOwnershipHandler(useCases.TransferOwnershipUseCase, useCases.TransferAllOwnershipUseCase)
+
+	adminHandler := handler.NewAdminHandler(adminFacade)
+
+	tokensHandler := handler.NewTokensHandler(accessTokenFacade)
+
+	foldersHandler := handler.NewFoldersHandler(folderFacade)
+
+	trashHandler := handler.NewTrashHandler(fileFacade)
+
+	versionsHandler := handler.NewVersionsHandler(fileFacade, useCases.RestoreVersionUseCase, useCases.DeleteVersionUseCase)
+
+	tagsHandler := handler.NewTagsHandler(fileFacade)
+
+	commentsHandler := handler.NewCommentsHandler(commentFacade)
+
+	locksHandler := handler.NewLocksHandler(config, fileFacade)
+
+	router := NewFilesRouter(config, filesHandler, uploadHanler, downloadHandler, permissionsHandler, linksHandler, publicHandler,
+		ownershipHandler, adminHandler, tokensHandler, foldersHandler, trashHandler, versionsHandler,
+		tagsHandler, commentsHandler, locksHandler, auditRepository, accessTokenFacade).MountRoutes()
 	http.Handle("/", router)
 	slog.Info("File Manager REST API runing", "port", config.Server.Port)
 
@@ -3,6 +3,9 @@ package server
 import (
 	"github.com/go-chi/chi/v5"
 	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/facade"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/handler"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/middleware"
@@ -12,20 +15,65 @@ const serviceBaseRoute = "/file-service"
 const fileBaseRoute = serviceBaseRoute + "/v1/files"
 const uploadRoute = serviceBaseRoute + "/v1/uploads"
 const downloadRoute = serviceBaseRoute + "/v1/downloads/{fileId}"
+const downloadVersionRoute = downloadRoute + "/versions/{version}"
+const publicRoute = serviceBaseRoute + "/v1/public/{token}"
+const adminBaseRoute = serviceBaseRoute + "/v1/admin"
+const tokensBaseRoute = serviceBaseRoute + "/v1/tokens"
+const foldersBaseRoute = serviceBaseRoute + "/v1/folders"
+const trashBaseRoute = serviceBaseRoute + "/v1/trash"
+const tagsBaseRoute = serviceBaseRoute + "/v1/tags"
 
 type FilesRouter interface {
 	MountRoutes() *chi.Mux
 }
 
 type filesRouter struct {
-	config          *config.Config
-	filesHandler    handler.FilesHandler
-	uploadHandler   handler.UploadHandler
-	downloadHandler handler.DownloadHandler
+	config             *config.Config
+	filesHandler       handler.FilesHandler
+	uploadHandler      handler.UploadHandler
+	downloadHandler    handler.DownloadHandler
+	permissionsHandler handler.PermissionsHandler
+	linksHandler       handler.LinksHandler
+	publicHandler      handler.PublicHandler
+	ownershipHandler   handler.OwnershipHandler
+	adminHandler       handler.AdminHandler
+	tokensHandler      handler.TokensHandler
+	foldersHandler     handler.FoldersHandler
+	trashHandler       handler.TrashHandler
+	versionsHandler    handler.VersionsHandler
+	tagsHandler        handler.TagsHandler
+	commentsHandler    handler.CommentsHandler
+	locksHandler       handler.LocksHandler
+	auditRepository    repository.AuditRepository
+	accessTokenFacade  facade.AccessTokenFacade
 }
 
-func NewFilesRouter(config *config.Config, filesHandler handler.FilesHandler, uploadHandler handler.UploadHandler, downloadHandler handler.DownloadHandler) FilesRouter {
-	return &filesRouter{config: config, filesHandler: filesHandler, uploadHandler: uploadHandler, downloadHandler: downloadHandler}
+func NewFilesRouter(config *config.Config, filesHandler handler.FilesHandler, uploadHandler handler.UploadHandler, downloadHandler handler.DownloadHandler,
+	permissionsHandler handler.PermissionsHandler, linksHandler handler.LinksHandler, publicHandler handler.PublicHandler,
+	ownershipHandler handler.OwnershipHandler, adminHandler handler.AdminHandler, tokensHandler handler.TokensHandler,
+	foldersHandler handler.FoldersHandler, trashHandler handler.TrashHandler, versionsHandler handler.VersionsHandler,
+	tagsHandler handler.TagsHandler, commentsHandler handler.CommentsHandler, locksHandler handler.LocksHandler,
+	auditRepository repository.AuditRepository, accessTokenFacade facade.AccessTokenFacade) FilesRouter {
+	return &filesRouter{
+		config:             config,
+		filesHandler:       filesHandler,
+		uploadHandler:      uploadHandler,
+		downloadHandler:    downloadHandler,
+		permissionsHandler: permissionsHandler,
+		linksHandler:       linksHandler,
+		publicHandler:      publicHandler,
+		ownershipHandler:   ownershipHandler,
+		adminHandler:       adminHandler,
+		tokensHandler:      tokensHandler,
+		foldersHandler:     foldersHandler,
+		trashHandler:       trashHandler,
+		versionsHandler:    versionsHandler,
+		tagsHandler:        tagsHandler,
+		commentsHandler:    commentsHandler,
+		locksHandler:       locksHandler,
+		auditRepository:    auditRepository,
+		accessTokenFacade:  accessTokenFacade,
+	}
 }
 
 func (fr *filesRouter) MountRoutes() *chi.Mux {
@@ -34,17 +82,100 @@ func (fr *filesRouter) MountRoutes() *chi.Mux {
 	router.Use(middleware.Cors)
 	router.Use(chiMiddleware.RequestID)
 	router.Use(chiMiddleware.Logger)
-	router.Use(middleware.JWTMiddleware(fr.config))
 
+	router.Get(publicRoute, fr.publicHandler.Download)
+
+	router.Group(func(router chi.Router) {
+		router.Use(middleware.AccessTokenMiddleware(fr.accessTokenFacade))
+		router.Use(middleware.JWTMiddleware(fr.config))
+
+		router.With(middleware.ScopeMiddleware(entity.ScopeUploadOnly)).Post(uploadRoute, fr.uploadHandler.Upload)
+
+		router.Group(func(router chi.Router) {
+			router.Use(middleware.ScopeMiddleware())
+
+			fr.mountScopedRoutes(router)
+		})
+	})
+
+	return router
+}
+
+func (fr *filesRouter) mountScopedRoutes(router chi.Router) {
 	router.Route(fileBaseRoute, func(r chi.Router) {
 		r.Get("/", fr.filesHandler.ListFiles)
+		r.Get("/shared", fr.filesHandler.ListSharedFiles)
 		r.Get("/{id}", fr.filesHandler.FindById)
 		r.Put("/{id}", fr.filesHandler.Update)
+		r.Put("/{id}/content", fr.uploadHandler.ReplaceContent)
 		r.Delete("/{id}", fr.filesHandler.Delete)
+		r.Post("/{id}/move", fr.filesHandler.Move)
+		r.Post("/{id}/copy", fr.filesHandler.Copy)
+		r.Put("/{id}/metadata", fr.filesHandler.UpdateMetadata)
+		r.Put("/{id}/star", fr.filesHandler.Star)
+		r.Delete("/{id}/star", fr.filesHandler.Unstar)
+		r.Put("/{id}/lock", fr.locksHandler.Lock)
+		r.Delete("/{id}/lock", fr.locksHandler.Unlock)
+
+		r.Get("/{id}/permissions", fr.permissionsHandler.ListPermissions)
+		r.Post("/{id}/permissions", fr.permissionsHandler.Grant)
+		r.Delete("/{id}/permissions/{userId}", fr.permissionsHandler.Revoke)
+		r.Delete("/{id}/permissions/groups/{group}", fr.permissionsHandler.RevokeGroup)
+
+		r.Get("/{id}/links", fr.linksHandler.ListLinks)
+		r.Post("/{id}/links", fr.linksHandler.Create)
+		r.Delete("/{id}/links/{token}", fr.linksHandler.Delete)
+
+		r.Put("/{id}/owner", fr.ownershipHandler.Transfer)
+
+		r.Get("/{id}/versions", fr.versionsHandler.ListVersions)
+		r.Post("/{id}/versions/{version}/restore", fr.versionsHandler.Restore)
+		r.Delete("/{id}/versions/{version}", fr.versionsHandler.Delete)
+
+		r.Post("/{id}/tags", fr.tagsHandler.Add)
+		r.Delete("/{id}/tags/{tag}", fr.tagsHandler.Remove)
+
+		r.Get("/{id}/comments", fr.commentsHandler.ListComments)
+		r.Post("/{id}/comments", fr.commentsHandler.Create)
+		r.Put("/{id}/comments/{commentId}", fr.commentsHandler.Update)
+		r.Delete("/{id}/comments/{commentId}", fr.commentsHandler.Delete)
 	})
 
-	router.Post(uploadRoute, fr.uploadHandler.Upload)
-	router.Get(downloadRoute, fr.downloadHandler.Download)
+	router.Get(tagsBaseRoute, fr.tagsHandler.ListTags)
 
-	return router
+	router.Route(foldersBaseRoute, func(r chi.Router) {
+		r.Get("/", fr.foldersHandler.ListRoot)
+		r.Post("/", fr.foldersHandler.Create)
+		r.Get("/{id}", fr.foldersHandler.FindById)
+		r.Put("/{id}", fr.foldersHandler.Rename)
+		r.Delete("/{id}", fr.foldersHandler.Delete)
+		r.Get("/{id}/children", fr.foldersHandler.ListChildren)
+	})
+
+	router.Route(trashBaseRoute, func(r chi.Router) {
+		r.Get("/", fr.trashHandler.ListTrash)
+		r.Post("/{id}/restore", fr.trashHandler.Restore)
+	})
+
+	router.Route(tokensBaseRoute, func(r chi.Router) {
+		r.Get("/", fr.tokensHandler.ListTokens)
+		r.With(middleware.IdentityProviderMiddleware()).Post("/", fr.tokensHandler.Create)
+		r.With(middleware.IdentityProviderMiddleware()).Delete("/{id}", fr.tokensHandler.Delete)
+	})
+
+	router.Route(adminBaseRoute, func(r chi.Router) {
+		r.Use(middleware.AuditMiddleware(fr.auditRepository))
+		r.Use(middleware.AdminMiddleware(fr.config))
+
+		r.Get("/usage", fr.adminHandler.ListUsage)
+		r.Get("/totals", fr.adminHandler.Totals)
+		r.Get("/audit", fr.adminHandler.ListAudit)
+		r.Get("/storage", fr.adminHandler.StorageStatus)
+		r.Get("/users/{userId}/files", fr.adminHandler.ListUserFiles)
+		r.Delete("/users/{userId}/files/{fileId}", fr.adminHandler.DeleteUserFile)
+		r.Post("/users/{userId}/transfer", fr.ownershipHandler.TransferAll)
+	})
+
+	router.Get(downloadRoute, fr.downloadHandler.Download)
+	router.Get(downloadVersionRoute, fr.downloadHandler.DownloadVersion)
 }
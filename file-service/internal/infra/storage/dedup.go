@@ -0,0 +1,210 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+)
+
+const (
+	contentPrefix = "sha256-"
+	stagingPrefix = "staging-"
+)
+
+type dedupBlobStore struct {
+	store           storage.BlobStore
+	blobsRepository repository.BlobsRepository
+	// mu keeps a content from being removed by its last release while a new upload of it takes it over
+	mu sync.Mutex
+}
+
+// NewDedupBlobStore keeps every content once in store, named after its SHA-256, and points file and version IDs
+// at it through blobsRepository. Blobs written before deduplication was enabled are still read by their own ID
+func NewDedupBlobStore(store storage.BlobStore, blobsRepository repository.BlobsRepository) *dedupBlobStore {
+	return &dedupBlobStore{store: store, blobsRepository: blobsRepository}
+}
+
+var _ storage.BlobStore = (*dedupBlobStore)(nil)
+
+// Put moves the content into place before referencing it, so a reference never points at missing content.
+// A crash in between only leaves an unreferenced content behind, which List reports
+func (d *dedupBlobStore) Put(id string, src io.Reader) (written int64, err error) {
+	stagingId := stagingPrefix + uuid.NewString()
+	hash := sha256.New()
+
+	if written, err = d.store.Put(stagingId, io.TeeReader(src, hash)); err != nil {
+		return 0, err
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	contentId := contentPrefix + sum
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	placed, err := d.place(stagingId, contentId)
+
+	if err != nil {
+		d.store.Delete(stagingId)
+		return 0, err
+	}
+
+	_, orphan, err := d.blobsRepository.Acquire(id, sum, written)
+
+	if err != nil {
+		// nothing else can reference a content placed while holding mu
+		if placed {
+			d.store.Delete(contentId)
+		} else {
+			d.store.Delete(stagingId)
+		}
+
+		return 0, err
+	}
+
+	if placed {
+		stagingId = ""
+	}
+
+	// the content is already referenced at this point, so a leftover only wastes space until the next cleanup
+	if err := d.discard(stagingId, orphan); err != nil {
+		slog.Warn("Could not remove blob left over by deduplication", "blobId", id, "error", err)
+	}
+
+	return written, nil
+}
+
+// place moves the staged upload to contentId, unless that content is stored already
+func (d *dedupBlobStore) place(stagingId string, contentId string) (placed bool, err error) {
+	_, err = d.store.Stat(contentId)
+
+	if err == nil {
+		return false, nil
+	}
+
+	if !errors.Is(err, storage.ErrBlobDoesNotExists) {
+		return false, err
+	}
+
+	return true, d.store.Move(stagingId, contentId)
+}
+
+func (d *dedupBlobStore) Get(id string, offset int64, length int64) (content io.ReadCloser, err error) {
+	ref, err := d.blobsRepository.FindByBlobId(id)
+
+	if err == repository.ErrBlobRefDoesNotExists {
+		return d.store.Get(id, offset, length)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return d.store.Get(contentPrefix+ref.Hash, offset, length)
+}
+
+func (d *dedupBlobStore) Delete(id string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	orphan, err := d.blobsRepository.Release(id)
+
+	if err == repository.ErrBlobRefDoesNotExists {
+		return d.store.Delete(id)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return d.discard("", orphan)
+}
+
+func (d *dedupBlobStore) Stat(id string) (info *storage.BlobInfo, err error) {
+	ref, err := d.blobsRepository.FindByBlobId(id)
+
+	if err == repository.ErrBlobRefDoesNotExists {
+		return d.store.Stat(id)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &storage.BlobInfo{Id: id, Size: ref.Size, ModifiedAt: ref.CreatedAt}, nil
+}
+
+// List returns the IDs pointing at a content along with the blobs written before deduplication.
+// Shared contents are left out while something references them, and so are the uploads being staged.
+// An unreferenced content is listed under its own ID, so it can be found and deleted as an orphan
+func (d *dedupBlobStore) List() (blobs []*storage.BlobInfo, err error) {
+	refs, err := d.blobsRepository.FindAll()
+
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := make(map[string]bool, len(refs))
+
+	for _, ref := range refs {
+		referenced[contentPrefix+ref.Hash] = true
+		blobs = append(blobs, &storage.BlobInfo{Id: ref.BlobId, Size: ref.Size, ModifiedAt: ref.CreatedAt})
+	}
+
+	stored, err := d.store.List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blob := range stored {
+		if referenced[blob.Id] || strings.HasPrefix(blob.Id, stagingPrefix) {
+			continue
+		}
+
+		blobs = append(blobs, blob)
+	}
+
+	return blobs, nil
+}
+
+// Move only repoints the reference, the content itself stays where it is
+func (d *dedupBlobStore) Move(fromId string, toId string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	orphan, err := d.blobsRepository.Rename(fromId, toId)
+
+	if err == repository.ErrBlobRefDoesNotExists {
+		return d.store.Move(fromId, toId)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return d.discard("", orphan)
+}
+
+// discard removes the staged upload and the content that lost its last reference, if any
+func (d *dedupBlobStore) discard(stagingId string, orphan string) error {
+	if stagingId != "" {
+		if err := d.store.Delete(stagingId); err != nil {
+			return err
+		}
+	}
+
+	if orphan != "" {
+		return d.store.Delete(contentPrefix + orphan)
+	}
+
+	return nil
+}
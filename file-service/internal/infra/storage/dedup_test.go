@@ -0,0 +1,205 @@
+package storage_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	appStorage "github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDedupBlobStore(t *testing.T) {
+	root := t.TempDir()
+
+	local, err := storage.NewLocalBlobStore(root)
+	assert.NoError(t, err)
+
+	assert.NoError(t, os.WriteFile(root+"/legacyId", []byte("legacy"), 0600))
+
+	store := storage.NewDedupBlobStore(local, newFakeBlobsRepository())
+
+	_, err = store.Put("firstId", strings.NewReader("same content"))
+	assert.NoError(t, err)
+
+	_, err = store.Put("secondId", strings.NewReader("same content"))
+	assert.NoError(t, err)
+
+	_, err = store.Put("otherId", strings.NewReader("other content"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 3, countFiles(t, root), "identical contents must share one blob")
+	assert.Equal(t, "same content", readBlob(t, store, "secondId", 0, -1))
+	assert.Equal(t, "content", readBlob(t, store, "firstId", 5, -1))
+	assert.Equal(t, "legacy", readBlob(t, store, "legacyId", 0, -1))
+
+	info, err := store.Stat("firstId")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), info.Size)
+
+	blobs, err := store.List()
+	assert.NoError(t, err)
+	assert.Len(t, blobs, 4)
+
+	assert.NoError(t, store.Delete("firstId"))
+	assert.Equal(t, "same content", readBlob(t, store, "secondId", 0, -1))
+
+	assert.NoError(t, store.Move("secondId", "movedId"))
+	assert.Equal(t, "same content", readBlob(t, store, "movedId", 0, -1))
+
+	_, err = store.Stat("secondId")
+	assert.ErrorIs(t, err, appStorage.ErrBlobDoesNotExists)
+
+	assert.NoError(t, store.Delete("movedId"))
+	assert.Equal(t, 2, countFiles(t, root), "the content must go away with its last reference")
+
+	assert.NoError(t, store.Delete("legacyId"))
+	assert.NoFileExists(t, root+"/legacyId")
+}
+
+func TestDedupBlobStoreFailedReference(t *testing.T) {
+	root := t.TempDir()
+
+	local, err := storage.NewLocalBlobStore(root)
+	assert.NoError(t, err)
+
+	blobsRepository := &failingBlobsRepository{fakeBlobsRepository: newFakeBlobsRepository(), failing: true}
+	store := storage.NewDedupBlobStore(local, blobsRepository)
+
+	_, err = store.Put("fileId", strings.NewReader("content"))
+	assert.Error(t, err)
+	assert.Equal(t, 0, countFiles(t, root), "content must not be left behind when it cannot be referenced")
+
+	// a content left unreferenced by a crash is listed so it can be deleted as an orphan
+	blobsRepository.failing = false
+
+	_, err = store.Put("fileId", strings.NewReader("content"))
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(root+"/sha256-leftover", []byte("leftover"), 0600))
+
+	blobs, err := store.List()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"fileId", "sha256-leftover"}, blobIds(blobs))
+
+	assert.NoError(t, store.Delete("sha256-leftover"))
+	assert.Equal(t, "content", readBlob(t, store, "fileId", 0, -1))
+}
+
+func blobIds(blobs []*appStorage.BlobInfo) []string {
+	ids := make([]string, len(blobs))
+
+	for i, blob := range blobs {
+		ids[i] = blob.Id
+	}
+
+	return ids
+}
+
+type failingBlobsRepository struct {
+	*fakeBlobsRepository
+	failing bool
+}
+
+func (f *failingBlobsRepository) Acquire(blobId string, hash string, size int64) (refCount int64, orphan string, err error) {
+	if f.failing {
+		return 0, "", errors.New("database is locked")
+	}
+
+	return f.fakeBlobsRepository.Acquire(blobId, hash, size)
+}
+
+func countFiles(t *testing.T, root string) int {
+	entries, err := os.ReadDir(root)
+	assert.NoError(t, err)
+	return len(entries)
+}
+
+type fakeBlob struct {
+	size     int64
+	refCount int64
+}
+
+// fakeBlobsRepository keeps the references in memory with the same semantics as the sqlite repository
+type fakeBlobsRepository struct {
+	blobs map[string]*fakeBlob
+	refs  map[string]string
+}
+
+func newFakeBlobsRepository() *fakeBlobsRepository {
+	return &fakeBlobsRepository{blobs: map[string]*fakeBlob{}, refs: map[string]string{}}
+}
+
+func (f *fakeBlobsRepository) Acquire(blobId string, hash string, size int64) (refCount int64, orphan string, err error) {
+	orphan, _ = f.Release(blobId)
+
+	if _, found := f.blobs[hash]; !found {
+		f.blobs[hash] = &fakeBlob{size: size}
+	}
+
+	f.blobs[hash].refCount++
+	f.refs[blobId] = hash
+
+	if orphan == hash {
+		orphan = ""
+	}
+
+	return f.blobs[hash].refCount, orphan, nil
+}
+
+func (f *fakeBlobsRepository) Release(blobId string) (orphan string, err error) {
+	hash, found := f.refs[blobId]
+
+	if !found {
+		return "", repository.ErrBlobRefDoesNotExists
+	}
+
+	delete(f.refs, blobId)
+
+	if f.blobs[hash].refCount--; f.blobs[hash].refCount > 0 {
+		return "", nil
+	}
+
+	delete(f.blobs, hash)
+
+	return hash, nil
+}
+
+func (f *fakeBlobsRepository) Rename(fromId string, toId string) (orphan string, err error) {
+	hash, found := f.refs[fromId]
+
+	if !found {
+		return "", repository.ErrBlobRefDoesNotExists
+	}
+
+	orphan, _ = f.Release(toId)
+	delete(f.refs, fromId)
+	f.refs[toId] = hash
+
+	return orphan, nil
+}
+
+func (f *fakeBlobsRepository) FindByBlobId(blobId string) (*entity.BlobRef, error) {
+	hash, found := f.refs[blobId]
+
+	if !found {
+		return nil, repository.ErrBlobRefDoesNotExists
+	}
+
+	return &entity.BlobRef{BlobId: blobId, Hash: hash, Size: f.blobs[hash].size, CreatedAt: time.Now()}, nil
+}
+
+func (f *fakeBlobsRepository) FindAll() ([]*entity.BlobRef, error) {
+	refs := []*entity.BlobRef{}
+
+	for blobId := range f.refs {
+		ref, _ := f.FindByBlobId(blobId)
+		refs = append(refs, ref)
+	}
+
+	return refs, nil
+}
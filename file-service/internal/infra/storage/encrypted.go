@@ -0,0 +1,337 @@
+package storage
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+)
+
+const (
+	encryptionMagic = "rstore-aes-gcm-1"
+	saltSize        = 32
+	headerSize      = len(encryptionMagic) + saltSize
+	chunkSize       = 64 << 10
+	// every sealed chunk carries the 16 bytes GCM tag
+	sealedChunkSize = chunkSize + 16
+)
+
+var (
+	ErrInvalidEncryptionKey = errors.New("encryption key must be 32 bytes encoded in base64")
+)
+
+type encryptedBlobStore struct {
+	store     storage.BlobStore
+	masterKey []byte
+}
+
+// NewEncryptedBlobStore seals every blob written to store with AES-256-GCM. A blob is a header with a random salt,
+// used to derive the blob key from masterKey, followed by chunks of 64KiB sealed on their own, so a range
+// is read by decrypting only the chunks it covers. Blobs written before encryption was enabled are read as they are
+func NewEncryptedBlobStore(store storage.BlobStore, masterKey []byte) (*encryptedBlobStore, error) {
+	if len(masterKey) != 32 {
+		return nil, ErrInvalidEncryptionKey
+	}
+
+	return &encryptedBlobStore{store: store, masterKey: masterKey}, nil
+}
+
+var _ storage.BlobStore = (*encryptedBlobStore)(nil)
+
+func (e *encryptedBlobStore) Put(id string, src io.Reader) (written int64, err error) {
+	salt := make([]byte, saltSize)
+
+	if _, err := rand.Read(salt); err != nil {
+		return 0, err
+	}
+
+	aead, err := e.aead(salt)
+
+	if err != nil {
+		return 0, err
+	}
+
+	pr, pw := io.Pipe()
+	sealed := make(chan error, 1)
+
+	go func() {
+		err := seal(pw, src, salt, aead, &written)
+		pw.CloseWithError(err)
+		sealed <- err
+	}()
+
+	_, err = e.store.Put(id, pr)
+
+	// unblocks the sealing goroutine when the store gave up before reading everything
+	pr.Close()
+
+	if sealErr := <-sealed; err == nil {
+		err = sealErr
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	return written, nil
+}
+
+func (e *encryptedBlobStore) Get(id string, offset int64, length int64) (content io.ReadCloser, err error) {
+	salt, err := e.readSalt(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if salt == nil {
+		return e.store.Get(id, offset, length)
+	}
+
+	aead, err := e.aead(salt)
+
+	if err != nil {
+		return nil, err
+	}
+
+	index := offset / chunkSize
+
+	body, err := e.store.Get(id, int64(headerSize)+index*sealedChunkSize, -1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &openingReader{
+		body:      body,
+		aead:      aead,
+		index:     uint64(index),
+		skip:      offset % chunkSize,
+		remaining: length,
+		sealed:    make([]byte, sealedChunkSize),
+	}, nil
+}
+
+func (e *encryptedBlobStore) Delete(id string) error {
+	return e.store.Delete(id)
+}
+
+func (e *encryptedBlobStore) Stat(id string) (info *storage.BlobInfo, err error) {
+	if info, err = e.store.Stat(id); err != nil {
+		return nil, err
+	}
+
+	return e.plainInfo(info)
+}
+
+func (e *encryptedBlobStore) List() (blobs []*storage.BlobInfo, err error) {
+	stored, err := e.store.List()
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, info := range stored {
+		plain, err := e.plainInfo(info)
+
+		if errors.Is(err, storage.ErrBlobDoesNotExists) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		blobs = append(blobs, plain)
+	}
+
+	return blobs, nil
+}
+
+func (e *encryptedBlobStore) Move(fromId string, toId string) error {
+	return e.store.Move(fromId, toId)
+}
+
+// plainInfo reports the size of the content before it was sealed
+func (e *encryptedBlobStore) plainInfo(info *storage.BlobInfo) (*storage.BlobInfo, error) {
+	salt, err := e.readSalt(info.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if salt == nil {
+		return info, nil
+	}
+
+	sealed := info.Size - int64(headerSize)
+	chunks := sealed/sealedChunkSize + 1
+
+	return &storage.BlobInfo{Id: info.Id, Size: sealed - chunks*(sealedChunkSize-chunkSize), ModifiedAt: info.ModifiedAt}, nil
+}
+
+// readSalt returns a nil salt when the blob was not written by this store
+func (e *encryptedBlobStore) readSalt(id string) ([]byte, error) {
+	content, err := e.store.Get(id, 0, int64(headerSize))
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer content.Close()
+
+	header := make([]byte, headerSize)
+
+	if _, err := io.ReadFull(content, header); err == io.EOF || err == io.ErrUnexpectedEOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if string(header[:len(encryptionMagic)]) != encryptionMagic {
+		return nil, nil
+	}
+
+	return header[len(encryptionMagic):], nil
+}
+
+func (e *encryptedBlobStore) aead(salt []byte) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, e.masterKey)
+	mac.Write(salt)
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func seal(dst io.Writer, src io.Reader, salt []byte, aead cipher.AEAD, written *int64) error {
+	if _, err := dst.Write(append([]byte(encryptionMagic), salt...)); err != nil {
+		return err
+	}
+
+	plain := make([]byte, chunkSize)
+	sealed := make([]byte, 0, sealedChunkSize)
+
+	for index := uint64(0); ; index++ {
+		n, err := io.ReadFull(src, plain)
+
+		// the last chunk is the only one shorter than chunkSize, even if that means an empty one
+		final := err == io.EOF || err == io.ErrUnexpectedEOF
+
+		if err != nil && !final {
+			return err
+		}
+
+		if _, err := dst.Write(aead.Seal(sealed[:0], chunkNonce(aead, index), plain[:n], chunkData(final))); err != nil {
+			return err
+		}
+
+		*written += int64(n)
+
+		if final {
+			return nil
+		}
+	}
+}
+
+// chunkNonce is unique per chunk of a blob, and each blob has a key of its own
+func chunkNonce(aead cipher.AEAD, index uint64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], index)
+	return nonce
+}
+
+// chunkData authenticates whether the chunk is the last one, so a truncated blob fails to open
+func chunkData(final bool) []byte {
+	if final {
+		return []byte{1}
+	}
+
+	return []byte{0}
+}
+
+type openingReader struct {
+	body      io.ReadCloser
+	aead      cipher.AEAD
+	index     uint64
+	skip      int64
+	remaining int64
+	sealed    []byte
+	plain     []byte
+	done      bool
+}
+
+func (o *openingReader) Read(p []byte) (n int, err error) {
+	if o.remaining == 0 {
+		return 0, io.EOF
+	}
+
+	for len(o.plain) == 0 {
+		if o.done {
+			return 0, io.EOF
+		}
+
+		if err := o.open(); err != nil {
+			return 0, err
+		}
+	}
+
+	if o.remaining >= 0 && int64(len(p)) > o.remaining {
+		p = p[:o.remaining]
+	}
+
+	n = copy(p, o.plain)
+	o.plain = o.plain[n:]
+
+	if o.remaining > 0 {
+		o.remaining -= int64(n)
+	}
+
+	return n, nil
+}
+
+func (o *openingReader) open() error {
+	n, err := io.ReadFull(o.body, o.sealed)
+
+	if err == io.EOF {
+		return storage.ErrBlobCorrupted
+	}
+
+	final := err == io.ErrUnexpectedEOF
+
+	if err != nil && !final {
+		return err
+	}
+
+	plain, err := o.aead.Open(o.sealed[:0], chunkNonce(o.aead, o.index), o.sealed[:n], chunkData(final))
+
+	if err != nil {
+		return storage.ErrBlobCorrupted
+	}
+
+	o.index++
+	o.done = final
+
+	if o.skip >= int64(len(plain)) {
+		o.skip -= int64(len(plain))
+		return nil
+	}
+
+	o.plain = plain[o.skip:]
+	o.skip = 0
+
+	return nil
+}
+
+func (o *openingReader) Close() error {
+	return o.body.Close()
+}
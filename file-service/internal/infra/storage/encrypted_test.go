@@ -0,0 +1,126 @@
+package storage_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	appStorage "github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+const testChunkSize = 64 << 10
+
+func TestEncryptedBlobStore(t *testing.T) {
+	root := t.TempDir()
+
+	local, err := storage.NewLocalBlobStore(root)
+	assert.NoError(t, err)
+
+	store, err := storage.NewEncryptedBlobStore(local, bytes.Repeat([]byte{7}, 32))
+	assert.NoError(t, err)
+
+	for _, size := range []int{0, 1, testChunkSize - 1, testChunkSize, 3*testChunkSize + 5} {
+		content := make([]byte, size)
+		_, err := rand.Read(content)
+		assert.NoError(t, err)
+
+		written, err := store.Put("blobId", bytes.NewReader(content))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(size), written)
+
+		info, err := store.Stat("blobId")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(size), info.Size)
+
+		assert.Equal(t, string(content), readBlob(t, store, "blobId", 0, -1))
+
+		for _, offset := range []int{0, 1, testChunkSize - 1, testChunkSize, testChunkSize + 3, size} {
+			if offset > size {
+				continue
+			}
+
+			assert.Equal(t, string(content[offset:]), readBlob(t, store, "blobId", int64(offset), -1), "size %d offset %d", size, offset)
+
+			end := min(offset+10, size)
+			assert.Equal(t, string(content[offset:end]), readBlob(t, store, "blobId", int64(offset), 10), "size %d offset %d", size, offset)
+		}
+	}
+
+	t.Run("stores ciphertext only", func(t *testing.T) {
+		_, err := store.Put("secretId", bytes.NewReader(bytes.Repeat([]byte("secret"), 100)))
+		assert.NoError(t, err)
+
+		stored, err := os.ReadFile(root + "/secretId")
+		assert.NoError(t, err)
+		assert.NotContains(t, string(stored), "secret")
+	})
+
+	t.Run("serves ranges through http", func(t *testing.T) {
+		content := bytes.Repeat([]byte("0123456789"), 20000)
+
+		_, err := store.Put("rangeId", bytes.NewReader(content))
+		assert.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Range", "bytes=65530-65545")
+		rr := httptest.NewRecorder()
+
+		reader := appStorage.NewReader(store, "rangeId", int64(len(content)))
+		defer reader.Close()
+
+		http.ServeContent(rr, req, "range.txt", time.Now(), reader)
+
+		assert.Equal(t, http.StatusPartialContent, rr.Code)
+		assert.Equal(t, string(content[65530:65546]), rr.Body.String())
+	})
+
+	t.Run("detects tampered blobs", func(t *testing.T) {
+		_, err := store.Put("tamperedId", bytes.NewReader(bytes.Repeat([]byte("a"), 2*testChunkSize)))
+		assert.NoError(t, err)
+
+		stored, err := os.ReadFile(root + "/tamperedId")
+		assert.NoError(t, err)
+
+		flipped := bytes.Clone(stored)
+		flipped[len(flipped)-1] ^= 1
+		assert.NoError(t, os.WriteFile(root+"/tamperedId", flipped, 0600))
+
+		assertCorrupted(t, store, "tamperedId")
+
+		// dropping the last chunk must not go unnoticed either
+		assert.NoError(t, os.WriteFile(root+"/tamperedId", stored[:len(stored)-16], 0600))
+		assertCorrupted(t, store, "tamperedId")
+	})
+
+	t.Run("reads blobs written before encryption", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(root+"/legacyId", []byte("plain content"), 0600))
+
+		assert.Equal(t, "content", readBlob(t, store, "legacyId", 6, -1))
+
+		info, err := store.Stat("legacyId")
+		assert.NoError(t, err)
+		assert.Equal(t, int64(13), info.Size)
+	})
+
+	t.Run("rejects invalid keys", func(t *testing.T) {
+		_, err := storage.NewEncryptedBlobStore(local, []byte("short"))
+		assert.ErrorIs(t, err, storage.ErrInvalidEncryptionKey)
+	})
+}
+
+func assertCorrupted(t *testing.T, store appStorage.BlobStore, id string) {
+	content, err := store.Get(id, 0, -1)
+	assert.NoError(t, err)
+
+	defer content.Close()
+
+	_, err = io.ReadAll(content)
+	assert.ErrorIs(t, err, appStorage.ErrBlobCorrupted)
+}
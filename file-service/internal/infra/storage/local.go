@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+)
+
+type localBlobStore struct {
+	root string
+}
+
+// NewLocalBlobStore keeps every blob as a file named after its ID directly under root
+func NewLocalBlobStore(root string) (*localBlobStore, error) {
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	return &localBlobStore{root: root}, nil
+}
+
+var _ storage.BlobStore = (*localBlobStore)(nil)
+
+func (l *localBlobStore) Put(id string, src io.Reader) (written int64, err error) {
+	file, err := os.Create(l.path(id))
+
+	if err != nil {
+		return 0, err
+	}
+
+	if written, err = io.Copy(file, src); err != nil {
+		file.Close()
+		os.Remove(l.path(id))
+		return 0, err
+	}
+
+	return written, file.Close()
+}
+
+func (l *localBlobStore) Get(id string, offset int64, length int64) (content io.ReadCloser, err error) {
+	file, err := os.Open(l.path(id))
+
+	if err != nil {
+		return nil, mapNotExist(err)
+	}
+
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if length < 0 {
+		return file, nil
+	}
+
+	return &limitedReadCloser{Reader: io.LimitReader(file, length), Closer: file}, nil
+}
+
+func (l *localBlobStore) Delete(id string) error {
+	if err := os.Remove(l.path(id)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (l *localBlobStore) Stat(id string) (info *storage.BlobInfo, err error) {
+	stat, err := os.Stat(l.path(id))
+
+	if err != nil {
+		return nil, mapNotExist(err)
+	}
+
+	return &storage.BlobInfo{Id: id, Size: stat.Size(), ModifiedAt: stat.ModTime()}, nil
+}
+
+func (l *localBlobStore) List() (blobs []*storage.BlobInfo, err error) {
+	entries, err := os.ReadDir(l.root)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		stat, err := entry.Info()
+
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		blobs = append(blobs, &storage.BlobInfo{Id: entry.Name(), Size: stat.Size(), ModifiedAt: stat.ModTime()})
+	}
+
+	return blobs, nil
+}
+
+func (l *localBlobStore) Move(fromId string, toId string) error {
+	return mapNotExist(os.Rename(l.path(fromId), l.path(toId)))
+}
+
+func (l *localBlobStore) path(id string) string {
+	return filepath.Join(l.root, filepath.Base(id))
+}
+
+type limitedReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func mapNotExist(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return storage.ErrBlobDoesNotExists
+	}
+
+	return err
+}
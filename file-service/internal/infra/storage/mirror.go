@@ -0,0 +1,754 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
+)
+
+const (
+	probeBlobId = ".mirror-probe"
+	// marks a blob whose last write succeeded on this disk only, so this disk has the state to resync from
+	pendingPrefix = ".mirror-pending-"
+)
+
+var (
+	ErrMirrorRequiresLocal = errors.New("storage mirror is only supported by the local backend")
+)
+
+type mirrorDisk struct {
+	path  string
+	store storage.BlobStore
+
+	mu       sync.Mutex
+	lastErr  error
+	failedAt time.Time
+	blobs    int
+}
+
+func (d *mirrorDisk) fail(err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.lastErr == nil {
+		slog.Warn("Mirror disk became unhealthy", "path", d.path, "error", err)
+	}
+
+	d.lastErr = err
+	d.failedAt = time.Now()
+}
+
+func (d *mirrorDisk) recover(blobs int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.lastErr != nil {
+		slog.Info("Mirror disk is healthy again", "path", d.path)
+	}
+
+	d.lastErr = nil
+	d.blobs = blobs
+}
+
+func (d *mirrorDisk) healthy() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.lastErr == nil
+}
+
+func (d *mirrorDisk) status() *entity.DiskStatus {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	status := &entity.DiskStatus{Path: d.path, Healthy: d.lastErr == nil, Blobs: d.blobs}
+
+	if d.lastErr != nil {
+		failedAt := d.failedAt
+		status.Error = d.lastErr.Error()
+		status.FailedAt = &failedAt
+	}
+
+	return status
+}
+
+type mirroredBlobStore struct {
+	disks []*mirrorDisk
+
+	resyncMu     sync.Mutex
+	statusMu     sync.Mutex
+	lastResyncAt *time.Time
+	repaired     int
+}
+
+// NewMirroredBlobStore keeps a copy of every blob on both primary and secondary. Writes go to
+// both disks and succeed while at least one of them accepts it, reads are served by the first
+// healthy disk holding the blob, and Resync brings a disk back in line with the other one, e.g.
+// after it missed writes or was replaced. A write that only one disk accepted leaves a pending
+// marker on it, so deletes and overwrites the other disk missed are not undone by the resync
+func NewMirroredBlobStore(primary string, secondary string) (*mirroredBlobStore, error) {
+	m := &mirroredBlobStore{}
+
+	for _, path := range []string{primary, secondary} {
+		store, err := NewLocalBlobStore(path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		m.disks = append(m.disks, &mirrorDisk{path: path, store: store})
+	}
+
+	return m, nil
+}
+
+var _ storage.BlobStore = (*mirroredBlobStore)(nil)
+var _ storage.Mirror = (*mirroredBlobStore)(nil)
+
+type putResult struct {
+	disk *mirrorDisk
+	err  error
+}
+
+func (m *mirroredBlobStore) Put(id string, src io.Reader) (written int64, err error) {
+	results := make(chan putResult, len(m.disks))
+	writers := make([]*tolerantWriter, len(m.disks))
+
+	for i, disk := range m.disks {
+		pr, pw := io.Pipe()
+		writers[i] = &tolerantWriter{pw: pw}
+
+		go func(disk *mirrorDisk) {
+			_, err := disk.store.Put(id, pr)
+			// unblocks the writer when the disk gave up before reading everything
+			pr.CloseWithError(err)
+			results <- putResult{disk: disk, err: err}
+		}(disk)
+	}
+
+	written, copyErr := io.Copy(fanOutWriter(writers), src)
+
+	for _, w := range writers {
+		w.pw.CloseWithError(copyErr)
+	}
+
+	var firstErr error
+	var stored []*mirrorDisk
+
+	for range m.disks {
+		result := <-results
+
+		if result.err == nil {
+			stored = append(stored, result.disk)
+			continue
+		}
+
+		if firstErr == nil {
+			firstErr = result.err
+		}
+
+		if copyErr == nil {
+			result.disk.fail(result.err)
+		}
+	}
+
+	if copyErr != nil {
+		m.deleteAll(id)
+		return 0, copyErr
+	}
+
+	if len(stored) == 0 {
+		return 0, firstErr
+	}
+
+	if len(stored) < len(m.disks) {
+		markPending(stored, id)
+	}
+
+	return written, nil
+}
+
+func (m *mirroredBlobStore) Get(id string, offset int64, length int64) (content io.ReadCloser, err error) {
+	err = m.read(id, func(store storage.BlobStore) (err error) {
+		content, err = store.Get(id, offset, length)
+		return err
+	})
+
+	return content, err
+}
+
+func (m *mirroredBlobStore) Stat(id string) (info *storage.BlobInfo, err error) {
+	err = m.read(id, func(store storage.BlobStore) (err error) {
+		info, err = store.Stat(id)
+		return err
+	})
+
+	return info, err
+}
+
+// read tries the healthy disks first. Disks that are found missing a blob another disk
+// holds are flagged, so the next resync repairs them
+func (m *mirroredBlobStore) read(id string, op func(store storage.BlobStore) error) error {
+	var missing []*mirrorDisk
+	var firstErr error
+
+	for _, disk := range m.byHealth() {
+		err := op(disk.store)
+
+		if err == nil {
+			for _, disk := range missing {
+				disk.fail(fmt.Errorf("blob %s is missing", id))
+			}
+
+			return nil
+		}
+
+		if firstErr == nil || firstErr == storage.ErrBlobDoesNotExists {
+			firstErr = err
+		}
+
+		if err == storage.ErrBlobDoesNotExists {
+			missing = append(missing, disk)
+			continue
+		}
+
+		disk.fail(err)
+	}
+
+	return firstErr
+}
+
+func (m *mirroredBlobStore) Delete(id string) error {
+	return m.write(func(disk *mirrorDisk) error {
+		return disk.store.Delete(id)
+	}, id)
+}
+
+func (m *mirroredBlobStore) Move(fromId string, toId string) error {
+	var missing []*mirrorDisk
+
+	err := m.write(func(disk *mirrorDisk) error {
+		err := disk.store.Move(fromId, toId)
+
+		if err == storage.ErrBlobDoesNotExists {
+			missing = append(missing, disk)
+		}
+
+		return err
+	}, fromId, toId)
+
+	if err != nil {
+		return err
+	}
+
+	for _, disk := range missing {
+		disk.fail(fmt.Errorf("blob %s is missing", fromId))
+	}
+
+	return nil
+}
+
+// write applies op to every disk and succeeds when at least one of them does. When some disk
+// did not, the blobs op changed are marked pending on the disks that did
+func (m *mirroredBlobStore) write(op func(disk *mirrorDisk) error, ids ...string) error {
+	var firstErr error
+	var succeeded []*mirrorDisk
+
+	for _, disk := range m.disks {
+		err := op(disk)
+
+		if err == nil {
+			succeeded = append(succeeded, disk)
+			continue
+		}
+
+		if err != storage.ErrBlobDoesNotExists {
+			disk.fail(err)
+		}
+
+		if firstErr == nil || firstErr == storage.ErrBlobDoesNotExists {
+			firstErr = err
+		}
+	}
+
+	if len(succeeded) == 0 {
+		return firstErr
+	}
+
+	if len(succeeded) < len(m.disks) {
+		markPending(succeeded, ids...)
+	}
+
+	return nil
+}
+
+func (m *mirroredBlobStore) List() (blobs []*storage.BlobInfo, err error) {
+	seen := map[string]bool{}
+	listed := 0
+	var lastErr error
+
+	for _, disk := range m.disks {
+		diskBlobs, err := disk.store.List()
+
+		if err != nil {
+			disk.fail(err)
+			lastErr = err
+			continue
+		}
+
+		listed++
+
+		for _, blob := range diskBlobs {
+			if isMirrorBlob(blob.Id) || seen[blob.Id] {
+				continue
+			}
+
+			seen[blob.Id] = true
+			blobs = append(blobs, blob)
+		}
+	}
+
+	if listed == 0 {
+		return nil, lastErr
+	}
+
+	return blobs, nil
+}
+
+func (m *mirroredBlobStore) Status() *entity.StorageStatus {
+	status := &entity.StorageStatus{Mirrored: true}
+
+	for _, disk := range m.disks {
+		status.Disks = append(status.Disks, disk.status())
+	}
+
+	m.statusMu.Lock()
+	defer m.statusMu.Unlock()
+
+	status.LastResyncAt = m.lastResyncAt
+	status.Repaired = m.repaired
+
+	return status
+}
+
+// Resync probes every disk and brings the reachable ones in line. A blob marked pending on a disk
+// is copied from it, or deleted from the others when it is gone from it. Any other blob a disk is
+// missing is copied from the disk holding it, and a blob both disks hold but with a different size
+// or SHA-256 is copied from the disk that changed it last. Hashes are compared for the blobs changed
+// since the previous resync only, or for every blob on the first one.
+// A disk is reported healthy again only when everything it needed got repaired
+func (m *mirroredBlobStore) Resync() (repaired int, err error) {
+	m.resyncMu.Lock()
+	defer m.resyncMu.Unlock()
+
+	m.statusMu.Lock()
+	since := m.lastResyncAt
+	m.statusMu.Unlock()
+
+	startedAt := time.Now()
+
+	contents := make([]map[string]*storage.BlobInfo, len(m.disks))
+	pending := make([]map[string]time.Time, len(m.disks))
+	reachable := make([]bool, len(m.disks))
+	allReachable := true
+
+	for i, disk := range m.disks {
+		if contents[i], pending[i], err = scan(disk.store); err != nil {
+			disk.fail(err)
+			allReachable = false
+			continue
+		}
+
+		reachable[i] = true
+	}
+
+	ids := map[string]bool{}
+
+	for i := range m.disks {
+		for id := range contents[i] {
+			ids[id] = true
+		}
+
+		for id := range pending[i] {
+			ids[id] = true
+		}
+	}
+
+	sorted := make([]string, 0, len(ids))
+
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+
+	sort.Strings(sorted)
+
+	targetErrs := make([]error, len(m.disks))
+
+	for _, id := range sorted {
+		source, marked := m.resyncSource(id, contents, pending, reachable, since)
+
+		if source < 0 {
+			continue
+		}
+
+		synced := true
+
+		for i, target := range m.disks {
+			if i == source || !reachable[i] {
+				continue
+			}
+
+			changed, err := m.resyncBlob(m.disks[source], target, id, contents[source][id], contents[i][id], marked)
+
+			if err != nil {
+				slog.Error("Could not repair blob on mirror disk", "path", target.path, "blobId", id, "error", err)
+				targetErrs[i] = err
+				synced = false
+				continue
+			}
+
+			if !changed {
+				continue
+			}
+
+			if contents[source][id] != nil {
+				contents[i][id] = contents[source][id]
+			} else {
+				delete(contents[i], id)
+			}
+
+			repaired++
+		}
+
+		// a disk that could not be reached still has to be brought in line from the marker
+		if marked && synced && allReachable {
+			clearPending(m.disks, pending, id)
+		}
+	}
+
+	var firstErr error
+
+	for i, target := range m.disks {
+		if !reachable[i] {
+			continue
+		}
+
+		if targetErrs[i] != nil {
+			target.fail(targetErrs[i])
+
+			if firstErr == nil {
+				firstErr = targetErrs[i]
+			}
+
+			continue
+		}
+
+		target.recover(len(contents[i]))
+	}
+
+	m.statusMu.Lock()
+	m.lastResyncAt = &startedAt
+	m.repaired = repaired
+	m.statusMu.Unlock()
+
+	for i, disk := range m.disks {
+		if !reachable[i] && firstErr == nil {
+			firstErr = fmt.Errorf("mirror disk %s is unreachable: %s", disk.path, disk.status().Error)
+		}
+	}
+
+	return repaired, firstErr
+}
+
+// resyncSource picks the disk whose copy of id the other disks must match, or -1 when they match already.
+// The newest pending marker wins, otherwise a disk holding the blob while another does not, or, when the
+// copies differ, the disk that wrote its copy last
+func (m *mirroredBlobStore) resyncSource(id string, contents []map[string]*storage.BlobInfo, pending []map[string]time.Time,
+	reachable []bool, since *time.Time) (source int, marked bool) {
+	source = -1
+
+	for i := range m.disks {
+		if !reachable[i] {
+			continue
+		}
+
+		if markedAt, ok := pending[i][id]; ok && (!marked || markedAt.After(pending[source][id])) {
+			source, marked = i, true
+		}
+	}
+
+	if marked {
+		return source, true
+	}
+
+	var holders []int
+
+	for i := range m.disks {
+		if reachable[i] && contents[i][id] != nil {
+			holders = append(holders, i)
+		}
+	}
+
+	if len(holders) == 0 {
+		return -1, false
+	}
+
+	reachableCount := 0
+
+	for _, ok := range reachable {
+		if ok {
+			reachableCount++
+		}
+	}
+
+	if len(holders) < reachableCount {
+		return holders[0], false
+	}
+
+	newest := holders[0]
+	differ := false
+
+	for _, i := range holders[1:] {
+		if m.differ(id, m.disks[newest], m.disks[i], contents[newest][id], contents[i][id], since) {
+			differ = true
+		}
+
+		if contents[i][id].ModifiedAt.After(contents[newest][id].ModifiedAt) {
+			newest = i
+		}
+	}
+
+	if !differ {
+		return -1, false
+	}
+
+	return newest, false
+}
+
+// differ compares the size of both copies, and their SHA-256 when either was changed since the previous resync
+func (m *mirroredBlobStore) differ(id string, a *mirrorDisk, b *mirrorDisk, aInfo *storage.BlobInfo, bInfo *storage.BlobInfo, since *time.Time) bool {
+	if aInfo.Size != bInfo.Size {
+		return true
+	}
+
+	if since != nil && aInfo.ModifiedAt.Before(*since) && bInfo.ModifiedAt.Before(*since) {
+		return false
+	}
+
+	aSum, err := blobHash(a.store, id)
+
+	if err != nil {
+		slog.Warn("Could not hash blob on mirror disk", "path", a.path, "blobId", id, "error", err)
+		return false
+	}
+
+	bSum, err := blobHash(b.store, id)
+
+	if err != nil {
+		slog.Warn("Could not hash blob on mirror disk", "path", b.path, "blobId", id, "error", err)
+		return false
+	}
+
+	return aSum != bSum
+}
+
+// resyncBlob makes the copy of id on target match the one on source, which may be no copy at all.
+// Without a pending marker, identical copies are left alone. It reports whether target changed
+func (m *mirroredBlobStore) resyncBlob(source *mirrorDisk, target *mirrorDisk, id string, sourceInfo *storage.BlobInfo,
+	targetInfo *storage.BlobInfo, marked bool) (changed bool, err error) {
+	if sourceInfo == nil {
+		if targetInfo == nil {
+			return false, nil
+		}
+
+		// written again since it was listed
+		if _, err := source.store.Stat(id); err == nil {
+			return false, nil
+		}
+
+		return true, target.store.Delete(id)
+	}
+
+	if !marked && targetInfo != nil && sourceInfo.Size == targetInfo.Size && !sourceInfo.ModifiedAt.After(targetInfo.ModifiedAt) {
+		return false, nil
+	}
+
+	return m.repair(source, target, id)
+}
+
+// repair copies the blob from source to target. A blob deleted between listing and copying is not an error
+func (m *mirroredBlobStore) repair(source *mirrorDisk, target *mirrorDisk, id string) (copied bool, err error) {
+	content, err := source.store.Get(id, 0, -1)
+
+	if err == storage.ErrBlobDoesNotExists {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	defer content.Close()
+
+	if _, err = target.store.Put(id, content); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// byHealth returns the disks with the healthy ones first, keeping the primary ahead of the secondary
+func (m *mirroredBlobStore) byHealth() []*mirrorDisk {
+	disks := make([]*mirrorDisk, 0, len(m.disks))
+
+	for _, disk := range m.disks {
+		if disk.healthy() {
+			disks = append(disks, disk)
+		}
+	}
+
+	for _, disk := range m.disks {
+		if !disk.healthy() {
+			disks = append(disks, disk)
+		}
+	}
+
+	return disks
+}
+
+func (m *mirroredBlobStore) deleteAll(id string) {
+	for _, disk := range m.disks {
+		if err := disk.store.Delete(id); err != nil {
+			slog.Warn("Could not remove partially written blob from mirror disk", "path", disk.path, "blobId", id, "error", err)
+		}
+	}
+}
+
+// scan lists the blobs of a reachable disk along with the blobs marked pending on it
+func scan(store storage.BlobStore) (contents map[string]*storage.BlobInfo, pending map[string]time.Time, err error) {
+	if err := probe(store); err != nil {
+		return nil, nil, err
+	}
+
+	blobs, err := store.List()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	contents = map[string]*storage.BlobInfo{}
+	pending = map[string]time.Time{}
+
+	for _, blob := range blobs {
+		switch {
+		case blob.Id == probeBlobId:
+		case strings.HasPrefix(blob.Id, pendingPrefix):
+			pending[strings.TrimPrefix(blob.Id, pendingPrefix)] = blob.ModifiedAt
+		default:
+			contents[blob.Id] = blob
+		}
+	}
+
+	return contents, pending, nil
+}
+
+func markPending(disks []*mirrorDisk, ids ...string) {
+	for _, disk := range disks {
+		for _, id := range ids {
+			if _, err := disk.store.Put(pendingPrefix+id, strings.NewReader("")); err != nil {
+				slog.Error("Could not mark blob pending on mirror disk", "path", disk.path, "blobId", id, "error", err)
+				disk.fail(err)
+			}
+		}
+	}
+}
+
+// clearPending removes the markers of id, unless one was set again since the disks were scanned
+func clearPending(disks []*mirrorDisk, pending []map[string]time.Time, id string) {
+	for i, disk := range disks {
+		markedAt, ok := pending[i][id]
+
+		if !ok {
+			continue
+		}
+
+		if info, err := disk.store.Stat(pendingPrefix + id); err != nil || info.ModifiedAt.After(markedAt) {
+			continue
+		}
+
+		if err := disk.store.Delete(pendingPrefix + id); err != nil {
+			slog.Warn("Could not clear pending marker on mirror disk", "path", disk.path, "blobId", id, "error", err)
+		}
+	}
+}
+
+func isMirrorBlob(id string) bool {
+	return id == probeBlobId || strings.HasPrefix(id, pendingPrefix)
+}
+
+func blobHash(store storage.BlobStore, id string) (string, error) {
+	content, err := store.Get(id, 0, -1)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer content.Close()
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func probe(store storage.BlobStore) error {
+	if _, err := store.Put(probeBlobId, strings.NewReader(probeBlobId)); err != nil {
+		return err
+	}
+
+	if _, err := store.Stat(probeBlobId); err != nil {
+		return err
+	}
+
+	return store.Delete(probeBlobId)
+}
+
+// tolerantWriter stops forwarding to a disk once it failed, so a single broken disk
+// does not interrupt the copy to the other one
+type tolerantWriter struct {
+	pw     *io.PipeWriter
+	failed bool
+}
+
+func (t *tolerantWriter) Write(p []byte) (int, error) {
+	if !t.failed {
+		if _, err := t.pw.Write(p); err != nil {
+			t.failed = true
+		}
+	}
+
+	return len(p), nil
+}
+
+type fanOutWriter []*tolerantWriter
+
+func (f fanOutWriter) Write(p []byte) (int, error) {
+	for _, w := range f {
+		w.Write(p)
+	}
+
+	return len(p), nil
+}
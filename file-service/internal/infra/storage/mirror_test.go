@@ -0,0 +1,210 @@
+package storage_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	appStorage "github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirroredBlobStore(t *testing.T) {
+	newMirror := func(t *testing.T) (primary string, secondary string, mirror appStorage.BlobStore) {
+		primary = t.TempDir() + "/storage"
+		secondary = t.TempDir() + "/storage"
+
+		mirror, err := storage.NewMirroredBlobStore(primary, secondary)
+		assert.NoError(t, err)
+
+		return primary, secondary, mirror
+	}
+
+	status := func(mirror appStorage.BlobStore) (healthy []bool) {
+		for _, disk := range mirror.(appStorage.Mirror).Status().Disks {
+			healthy = append(healthy, disk.Healthy)
+		}
+
+		return healthy
+	}
+
+	t.Run("should write every blob to both disks", func(t *testing.T) {
+		primary, secondary, mirror := newMirror(t)
+
+		written, err := mirror.Put("blobId", strings.NewReader("mirrored content"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(16), written)
+
+		for _, path := range []string{primary, secondary} {
+			content, err := os.ReadFile(path + "/blobId")
+			assert.NoError(t, err)
+			assert.Equal(t, "mirrored content", string(content))
+		}
+
+		assert.NoError(t, mirror.Move("blobId", "movedId"))
+		assert.NoFileExists(t, primary+"/blobId")
+		assert.FileExists(t, secondary+"/movedId")
+
+		assert.NoError(t, mirror.Delete("movedId"))
+		assert.NoFileExists(t, primary+"/movedId")
+		assert.NoFileExists(t, secondary+"/movedId")
+		assert.Equal(t, []bool{true, true}, status(mirror))
+	})
+
+	t.Run("should read from the secondary disk when the primary lost the blob", func(t *testing.T) {
+		primary, _, mirror := newMirror(t)
+
+		_, err := mirror.Put("blobId", strings.NewReader("mirrored content"))
+		assert.NoError(t, err)
+
+		assert.NoError(t, os.Remove(primary+"/blobId"))
+
+		assert.Equal(t, "mirrored content", readBlob(t, mirror, "blobId", 0, -1))
+		assert.Equal(t, []bool{false, true}, status(mirror))
+
+		repaired, err := mirror.(appStorage.Mirror).Resync()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, repaired)
+		assert.Equal(t, []bool{true, true}, status(mirror))
+
+		content, err := os.ReadFile(primary + "/blobId")
+		assert.NoError(t, err)
+		assert.Equal(t, "mirrored content", string(content))
+	})
+
+	t.Run("should keep writing while a disk is gone and repair it once replaced", func(t *testing.T) {
+		primary, secondary, mirror := newMirror(t)
+
+		_, err := mirror.Put("before", strings.NewReader("written before"))
+		assert.NoError(t, err)
+
+		assert.NoError(t, os.RemoveAll(secondary))
+
+		written, err := mirror.Put("during", strings.NewReader("written during"))
+		assert.NoError(t, err)
+		assert.Equal(t, int64(14), written)
+		assert.Equal(t, []bool{true, false}, status(mirror))
+
+		_, err = mirror.(appStorage.Mirror).Resync()
+		assert.Error(t, err)
+		assert.Equal(t, []bool{true, false}, status(mirror))
+
+		assert.NoError(t, os.MkdirAll(secondary, os.ModePerm))
+
+		repaired, err := mirror.(appStorage.Mirror).Resync()
+		assert.NoError(t, err)
+		assert.Equal(t, 2, repaired)
+
+		mirrorStatus := mirror.(appStorage.Mirror).Status()
+		assert.NotNil(t, mirrorStatus.LastResyncAt)
+		assert.Equal(t, 2, mirrorStatus.Repaired)
+		assert.True(t, mirrorStatus.Disks[1].Healthy)
+		assert.Equal(t, 2, mirrorStatus.Disks[1].Blobs)
+
+		assert.NoError(t, os.RemoveAll(primary))
+		assert.Equal(t, "written during", readBlob(t, mirror, "during", 0, -1))
+	})
+
+	// breakDisk makes every write to the disk fail and returns a function bringing it back with its previous contents
+	breakDisk := func(t *testing.T, path string) (restore func()) {
+		assert.NoError(t, os.Rename(path, path+".bak"))
+		assert.NoError(t, os.WriteFile(path, nil, 0600))
+
+		return func() {
+			assert.NoError(t, os.Remove(path))
+			assert.NoError(t, os.Rename(path+".bak", path))
+		}
+	}
+
+	t.Run("should not bring back blobs deleted while a disk was failing", func(t *testing.T) {
+		primary, secondary, mirror := newMirror(t)
+
+		_, err := mirror.Put("deletedId", strings.NewReader("deleted content"))
+		assert.NoError(t, err)
+
+		_, err = mirror.Put("movedId", strings.NewReader("moved content"))
+		assert.NoError(t, err)
+
+		restore := breakDisk(t, secondary)
+
+		assert.NoError(t, mirror.Delete("deletedId"))
+		assert.NoError(t, mirror.Move("movedId", "targetId"))
+		assert.Equal(t, []bool{true, false}, status(mirror))
+
+		restore()
+
+		repaired, err := mirror.(appStorage.Mirror).Resync()
+		assert.NoError(t, err)
+		assert.Equal(t, 3, repaired)
+		assert.Equal(t, []bool{true, true}, status(mirror))
+
+		for _, path := range []string{primary, secondary} {
+			assert.NoFileExists(t, path+"/deletedId")
+			assert.NoFileExists(t, path+"/movedId")
+			assert.FileExists(t, path+"/targetId")
+		}
+
+		blobs, err := mirror.List()
+		assert.NoError(t, err)
+		assert.Len(t, blobs, 1)
+
+		repaired, err = mirror.(appStorage.Mirror).Resync()
+		assert.NoError(t, err)
+		assert.Equal(t, 0, repaired)
+	})
+
+	t.Run("should replace content a disk missed the overwrite of", func(t *testing.T) {
+		_, secondary, mirror := newMirror(t)
+
+		_, err := mirror.Put("blobId", strings.NewReader("old content"))
+		assert.NoError(t, err)
+
+		restore := breakDisk(t, secondary)
+
+		_, err = mirror.Put("blobId", strings.NewReader("new content"))
+		assert.NoError(t, err)
+
+		restore()
+
+		repaired, err := mirror.(appStorage.Mirror).Resync()
+		assert.NoError(t, err)
+		assert.Equal(t, 1, repaired)
+
+		content, err := os.ReadFile(secondary + "/blobId")
+		assert.NoError(t, err)
+		assert.Equal(t, "new content", string(content))
+	})
+
+	t.Run("should fail writes only when both disks are gone", func(t *testing.T) {
+		primary, secondary, mirror := newMirror(t)
+
+		assert.NoError(t, os.RemoveAll(primary))
+		assert.NoError(t, os.RemoveAll(secondary))
+
+		_, err := mirror.Put("blobId", strings.NewReader("lost"))
+		assert.Error(t, err)
+
+		_, err = mirror.Get("blobId", 0, -1)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewBlobStoreWithMirror(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Storage.Path = t.TempDir()
+	cfg.Storage.Mirror.Path = t.TempDir()
+
+	store, mirror, err := storage.NewBlobStore(context.Background(), cfg, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, mirror)
+
+	_, err = store.Put("blobId", strings.NewReader("content"))
+	assert.NoError(t, err)
+
+	assert.FileExists(t, cfg.Storage.Path+"/storage/blobId")
+	assert.FileExists(t, cfg.Storage.Mirror.Path+"/storage/blobId")
+	assert.True(t, mirror.Status().Mirrored)
+}
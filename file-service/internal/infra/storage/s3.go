@@ -0,0 +1,396 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+)
+
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+var (
+	ErrInvalidS3Config = errors.New("s3 storage requires an endpoint with scheme and host and a bucket")
+)
+
+type s3BlobStore struct {
+	ctx       context.Context
+	client    *http.Client
+	endpoint  string
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	prefix    string
+}
+
+// NewS3BlobStore talks to any S3 compatible API, like AWS or MinIO, using path-style addressing
+// and requests signed with AWS Signature Version 4. Blobs are stored as objects named prefix + ID
+func NewS3BlobStore(ctx context.Context, config *config.Config) (*s3BlobStore, error) {
+	s3Config := config.Storage.S3
+
+	endpoint, err := url.Parse(s3Config.Endpoint)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if endpoint.Scheme == "" || endpoint.Host == "" || s3Config.Bucket == "" {
+		return nil, ErrInvalidS3Config
+	}
+
+	region := s3Config.Region
+
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return &s3BlobStore{
+		ctx:       ctx,
+		client:    http.DefaultClient,
+		endpoint:  strings.TrimSuffix(endpoint.String(), "/"),
+		bucket:    s3Config.Bucket,
+		region:    region,
+		accessKey: s3Config.AccessKey,
+		secretKey: s3Config.SecretKey,
+		prefix:    s3Config.Prefix,
+	}, nil
+}
+
+var _ storage.BlobStore = (*s3BlobStore)(nil)
+
+// Put spools src to a temporary file first, because S3 needs the object size before the upload starts
+func (s *s3BlobStore) Put(id string, src io.Reader) (written int64, err error) {
+	spool, err := os.CreateTemp("", "raspstore-s3-*")
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	if written, err = io.Copy(spool, src); err != nil {
+		return 0, err
+	}
+
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return 0, err
+	}
+
+	res, err := s.do(http.MethodPut, s.key(id), nil, nil, io.NopCloser(spool), written)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return 0, responseError(res)
+	}
+
+	return written, nil
+}
+
+func (s *s3BlobStore) Get(id string, offset int64, length int64) (content io.ReadCloser, err error) {
+	header := http.Header{}
+
+	if length < 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	} else if length > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+	} else {
+		return io.NopCloser(strings.NewReader("")), nil
+	}
+
+	res, err := s.do(http.MethodGet, s.key(id), nil, header, nil, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.StatusCode {
+	case http.StatusOK, http.StatusPartialContent:
+		return res.Body, nil
+	case http.StatusRequestedRangeNotSatisfiable:
+		// the range starts at the end of the blob, which is a valid empty read
+		res.Body.Close()
+		return io.NopCloser(strings.NewReader("")), nil
+	default:
+		return nil, responseError(res)
+	}
+}
+
+func (s *s3BlobStore) Delete(id string) error {
+	res, err := s.do(http.MethodDelete, s.key(id), nil, nil, nil, 0)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode/100 != 2 && res.StatusCode != http.StatusNotFound {
+		return responseError(res)
+	}
+
+	return nil
+}
+
+func (s *s3BlobStore) Stat(id string) (info *storage.BlobInfo, err error) {
+	res, err := s.do(http.MethodHead, s.key(id), nil, nil, nil, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseError(res)
+	}
+
+	modifiedAt, _ := http.ParseTime(res.Header.Get("Last-Modified"))
+
+	return &storage.BlobInfo{Id: id, Size: res.ContentLength, ModifiedAt: modifiedAt}, nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key          string
+		Size         int64
+		LastModified time.Time
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+func (s *s3BlobStore) List() (blobs []*storage.BlobInfo, err error) {
+	query := map[string]string{"list-type": "2", "prefix": s.prefix}
+
+	for {
+		result, err := s.listPage(query)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, object := range result.Contents {
+			id := strings.TrimPrefix(object.Key, s.prefix)
+
+			if id == "" || strings.Contains(id, "/") {
+				continue
+			}
+
+			blobs = append(blobs, &storage.BlobInfo{Id: id, Size: object.Size, ModifiedAt: object.LastModified})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return blobs, nil
+		}
+
+		query["continuation-token"] = result.NextContinuationToken
+	}
+}
+
+func (s *s3BlobStore) listPage(query map[string]string) (*listBucketResult, error) {
+	res, err := s.do(http.MethodGet, "", query, nil, nil, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, responseError(res)
+	}
+
+	var result listBucketResult
+
+	if err := xml.NewDecoder(res.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Move copies the object server side and removes the source, since S3 has no rename
+func (s *s3BlobStore) Move(fromId string, toId string) error {
+	header := http.Header{}
+	header.Set("x-amz-copy-source", uriEncode("/"+s.bucket+"/"+s.key(fromId), false))
+
+	res, err := s.do(http.MethodPut, s.key(toId), nil, header, nil, 0)
+
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return responseError(res)
+	}
+
+	return s.Delete(fromId)
+}
+
+func (s *s3BlobStore) key(id string) string {
+	return s.prefix + id
+}
+
+func (s *s3BlobStore) do(method string, key string, query map[string]string, header http.Header, body io.ReadCloser,
+	contentLength int64) (*http.Response, error) {
+	path := "/" + s.bucket
+
+	if key != "" {
+		path += "/" + key
+	}
+
+	canonicalPath := uriEncode(path, false)
+	canonicalQuery := canonicalQueryString(query)
+
+	target := s.endpoint + canonicalPath
+
+	if canonicalQuery != "" {
+		target += "?" + canonicalQuery
+	}
+
+	req, err := http.NewRequestWithContext(s.ctx, method, target, body)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	if body != nil {
+		req.ContentLength = contentLength
+	}
+
+	s.sign(req, canonicalPath, canonicalQuery, time.Now().UTC())
+
+	return s.client.Do(req)
+}
+
+func (s *s3BlobStore) sign(req *http.Request, canonicalPath string, canonicalQuery string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", unsignedPayload)
+
+	names := []string{"host"}
+
+	for name := range req.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-amz-") {
+			names = append(names, lower)
+		}
+	}
+
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+
+	for _, name := range names {
+		value := req.Host
+
+		if name != "host" {
+			value = strings.TrimSpace(req.Header.Get(name))
+		}
+
+		canonicalHeaders.WriteString(name + ":" + value + "\n")
+	}
+
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{req.Method, canonicalPath, canonicalQuery, canonicalHeaders.String(),
+		signedHeaders, unsignedPayload}, "\n")
+
+	scope := date + "/" + s.region + "/s3/aws4_request"
+
+	digest := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(digest[:])
+
+	signingKey := hmacSha256([]byte("AWS4"+s.secretKey), date)
+	signingKey = hmacSha256(signingKey, s.region)
+	signingKey = hmacSha256(signingKey, "s3")
+	signingKey = hmacSha256(signingKey, "aws4_request")
+
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func canonicalQueryString(query map[string]string) string {
+	pairs := make([]string, 0, len(query))
+
+	for name, value := range query {
+		pairs = append(pairs, uriEncode(name, true)+"="+uriEncode(value, true))
+	}
+
+	sort.Strings(pairs)
+
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode escapes everything but the unreserved characters of RFC 3986, as required by Signature Version 4
+func uriEncode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+
+	for _, b := range []byte(value) {
+		switch {
+		case 'A' <= b && b <= 'Z', 'a' <= b && b <= 'z', '0' <= b && b <= '9', b == '-', b == '_', b == '.', b == '~':
+			encoded.WriteByte(b)
+		case b == '/' && !encodeSlash:
+			encoded.WriteByte(b)
+		default:
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+
+	return encoded.String()
+}
+
+type s3Error struct {
+	Code    string
+	Message string
+}
+
+func responseError(res *http.Response) error {
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return storage.ErrBlobDoesNotExists
+	}
+
+	var body s3Error
+
+	// HEAD responses and some proxies send no XML body, the status is enough in that case
+	_ = xml.NewDecoder(io.LimitReader(res.Body, 64<<10)).Decode(&body)
+
+	return fmt.Errorf("s3 responded with status %d: %s %s", res.StatusCode, body.Code, body.Message)
+}
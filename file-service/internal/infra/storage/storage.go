@@ -0,0 +1,79 @@
+package storage
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/repository"
+	"github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+)
+
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+var (
+	ErrUnknownBackend = errors.New("storage backend must be local or s3")
+)
+
+// NewBlobStore builds the backend selected by storage.backend. The local backend is the default
+// and keeps the blobs under storage.path/storage, copied to storage.mirror.path/storage when a
+// mirror is configured, in which case the mirror is returned as well. With storage.encryption-key,
+// blobs are encrypted before reaching the backend, and with storage.dedup, identical contents are stored once
+func NewBlobStore(ctx context.Context, config *config.Config, blobsRepository repository.BlobsRepository) (store storage.BlobStore, mirror storage.Mirror, err error) {
+	store, mirror, err = newBackend(ctx, config)
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if config.Storage.EncryptionKey != "" {
+		masterKey, err := base64.StdEncoding.DecodeString(config.Storage.EncryptionKey)
+
+		if err != nil {
+			return nil, nil, ErrInvalidEncryptionKey
+		}
+
+		if store, err = NewEncryptedBlobStore(store, masterKey); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if config.Storage.Dedup {
+		return NewDedupBlobStore(store, blobsRepository), mirror, nil
+	}
+
+	return store, mirror, nil
+}
+
+func newBackend(ctx context.Context, config *config.Config) (storage.BlobStore, storage.Mirror, error) {
+	mirrorPath := config.Storage.Mirror.Path
+
+	switch config.Storage.Backend {
+	case "", BackendLocal:
+		if mirrorPath != "" {
+			mirror, err := NewMirroredBlobStore(config.Storage.Path+"/storage", mirrorPath+"/storage")
+
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return mirror, mirror, nil
+		}
+
+		store, err := NewLocalBlobStore(config.Storage.Path + "/storage")
+		return store, nil, err
+	case BackendS3:
+		if mirrorPath != "" {
+			return nil, nil, ErrMirrorRequiresLocal
+		}
+
+		store, err := NewS3BlobStore(ctx, config)
+		return store, nil, err
+	default:
+		return nil, nil, ErrUnknownBackend
+	}
+}
@@ -0,0 +1,251 @@
+package storage_test
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	appStorage "github.com/murilo-bracero/raspstore/file-service/internal/application/storage"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/config"
+	"github.com/murilo-bracero/raspstore/file-service/internal/infra/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlobStores(t *testing.T) {
+	local, err := storage.NewLocalBlobStore(t.TempDir() + "/storage")
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(newFakeS3(t, "bucket"))
+	t.Cleanup(server.Close)
+
+	cfg := &config.Config{}
+	cfg.Storage.Backend = storage.BackendS3
+	cfg.Storage.S3.Endpoint = server.URL
+	cfg.Storage.S3.Bucket = "bucket"
+	cfg.Storage.S3.AccessKey = "access"
+	cfg.Storage.S3.SecretKey = "secret"
+	cfg.Storage.S3.Prefix = "storage/"
+
+	s3, _, err := storage.NewBlobStore(context.Background(), cfg, nil)
+	assert.NoError(t, err)
+
+	mirror, err := storage.NewMirroredBlobStore(t.TempDir()+"/primary", t.TempDir()+"/secondary")
+	assert.NoError(t, err)
+
+	for name, store := range map[string]appStorage.BlobStore{"local": local, "s3": s3, "mirror": mirror} {
+		t.Run(name, func(t *testing.T) {
+			testBlobStore(t, store)
+		})
+	}
+}
+
+func testBlobStore(t *testing.T, store appStorage.BlobStore) {
+	written, err := store.Put("blobId", strings.NewReader("0123456789"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), written)
+
+	info, err := store.Stat("blobId")
+	assert.NoError(t, err)
+	assert.Equal(t, "blobId", info.Id)
+	assert.Equal(t, int64(10), info.Size)
+
+	assert.Equal(t, "0123456789", readBlob(t, store, "blobId", 0, -1))
+	assert.Equal(t, "3456", readBlob(t, store, "blobId", 3, 4))
+	assert.Equal(t, "789", readBlob(t, store, "blobId", 7, -1))
+
+	reader := appStorage.NewReader(store, "blobId", info.Size)
+
+	end, err := reader.Seek(0, io.SeekEnd)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), end)
+
+	_, err = reader.Seek(5, io.SeekStart)
+	assert.NoError(t, err)
+
+	content, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "56789", string(content))
+	assert.NoError(t, reader.Close())
+
+	assert.NoError(t, store.Move("blobId", "movedId"))
+
+	_, err = store.Stat("blobId")
+	assert.ErrorIs(t, err, appStorage.ErrBlobDoesNotExists)
+
+	_, err = store.Get("blobId", 0, -1)
+	assert.ErrorIs(t, err, appStorage.ErrBlobDoesNotExists)
+
+	assert.ErrorIs(t, store.Move("blobId", "otherId"), appStorage.ErrBlobDoesNotExists)
+
+	_, err = store.Put("otherId", strings.NewReader("other"))
+	assert.NoError(t, err)
+
+	blobs, err := store.List()
+	assert.NoError(t, err)
+
+	ids := []string{}
+
+	for _, blob := range blobs {
+		ids = append(ids, blob.Id)
+	}
+
+	sort.Strings(ids)
+	assert.Equal(t, []string{"movedId", "otherId"}, ids)
+
+	assert.NoError(t, store.Delete("movedId"))
+	assert.NoError(t, store.Delete("movedId"))
+
+	_, err = store.Stat("movedId")
+	assert.ErrorIs(t, err, appStorage.ErrBlobDoesNotExists)
+}
+
+func readBlob(t *testing.T, store appStorage.BlobStore, id string, offset int64, length int64) string {
+	content, err := store.Get(id, offset, length)
+	assert.NoError(t, err)
+
+	defer content.Close()
+
+	data, err := io.ReadAll(content)
+	assert.NoError(t, err)
+
+	return string(data)
+}
+
+func TestNewBlobStoreRejectsInvalidConfig(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Storage.Backend = "ftp"
+
+	_, _, err := storage.NewBlobStore(context.Background(), cfg, nil)
+	assert.ErrorIs(t, err, storage.ErrUnknownBackend)
+
+	cfg.Storage.Backend = storage.BackendS3
+
+	_, _, err = storage.NewBlobStore(context.Background(), cfg, nil)
+	assert.ErrorIs(t, err, storage.ErrInvalidS3Config)
+
+	cfg.Storage.Mirror.Path = t.TempDir()
+
+	_, _, err = storage.NewBlobStore(context.Background(), cfg, nil)
+	assert.ErrorIs(t, err, storage.ErrMirrorRequiresLocal)
+
+	cfg.Storage.Backend = storage.BackendLocal
+	cfg.Storage.Path = t.TempDir()
+	cfg.Storage.EncryptionKey = "c2hvcnQ="
+
+	_, _, err = storage.NewBlobStore(context.Background(), cfg, nil)
+	assert.ErrorIs(t, err, storage.ErrInvalidEncryptionKey)
+}
+
+type fakeObject struct {
+	content    []byte
+	modifiedAt time.Time
+}
+
+// fakeS3 is an in-memory stand-in for the subset of the S3 API used by the blob store
+type fakeS3 struct {
+	t       *testing.T
+	bucket  string
+	mu      sync.Mutex
+	objects map[string]*fakeObject
+}
+
+func newFakeS3(t *testing.T, bucket string) *fakeS3 {
+	return &fakeS3{t: t, bucket: bucket, objects: map[string]*fakeObject{}}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=access/") || r.Header.Get("x-amz-date") == "" ||
+		!strings.Contains(auth, "SignedHeaders=host;") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/"+f.bucket)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if path == "" || path == "/" {
+		f.list(w, r)
+		return
+	}
+
+	key := strings.TrimPrefix(path, "/")
+	object, found := f.objects[key]
+
+	switch r.Method {
+	case http.MethodPut:
+		if source := r.Header.Get("x-amz-copy-source"); source != "" {
+			copied, found := f.objects[strings.TrimPrefix(source, "/"+f.bucket+"/")]
+
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			f.objects[key] = &fakeObject{content: copied.content, modifiedAt: time.Now()}
+			return
+		}
+
+		assert.NotEqual(f.t, int64(-1), r.ContentLength)
+
+		content, err := io.ReadAll(r.Body)
+		assert.NoError(f.t, err)
+
+		f.objects[key] = &fakeObject{content: content, modifiedAt: time.Now()}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodHead:
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Length", strconv.Itoa(len(object.content)))
+		w.Header().Set("Last-Modified", object.modifiedAt.UTC().Format(http.TimeFormat))
+	case http.MethodGet:
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "<Error><Code>NoSuchKey</Code></Error>")
+			return
+		}
+
+		http.ServeContent(w, r, key, object.modifiedAt, strings.NewReader(string(object.content)))
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, "2", r.URL.Query().Get("list-type"))
+
+	type content struct {
+		Key          string
+		Size         int64
+		LastModified time.Time
+	}
+
+	result := struct {
+		XMLName     xml.Name `xml:"ListBucketResult"`
+		Contents    []content
+		IsTruncated bool
+	}{}
+
+	for key, object := range f.objects {
+		if strings.HasPrefix(key, r.URL.Query().Get("prefix")) {
+			result.Contents = append(result.Contents, content{Key: key, Size: int64(len(object.content)), LastModified: object.modifiedAt})
+		}
+	}
+
+	assert.NoError(f.t, xml.NewEncoder(w).Encode(result))
+}
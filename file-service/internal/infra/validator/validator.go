@@ -2,11 +2,44 @@ package validator
 
 import (
 	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/murilo-bracero/raspstore/file-service/internal/domain/entity"
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
 )
 
-var ErrFilenameEmpty = errors.New("field Filename must not be empty")
+const (
+	maxTagNameLength       = 50
+	maxMetadataEntries     = 50
+	maxMetadataKeyLength   = 64
+	maxMetadataValueLength = 1024
+	maxCommentLength       = 4000
+)
+
+var (
+	ErrFilenameEmpty     = errors.New("field Filename must not be empty")
+	ErrUserIdEmpty       = errors.New("field UserId or Group must not be empty")
+	ErrUserIdAndGroup    = errors.New("fields UserId and Group cannot be used together")
+	ErrRecipientEmpty    = errors.New("field UserId must not be empty")
+	ErrInvalidPermission = errors.New("field Permission must be one of VIEWER or EDITOR")
+	ErrExpiresAtInPast   = errors.New("field ExpiresAt must be a future date")
+	ErrMaxDownloads      = errors.New("field MaxDownloads must be greater than zero")
+	ErrTokenNameEmpty    = errors.New("field Name must not be empty")
+	ErrFolderNameEmpty   = errors.New("field Name must not be empty")
+	ErrFolderNameInvalid = errors.New("field Name must not contain slashes")
+	ErrInvalidScope      = errors.New("field Scope must be one of FULL, READ_ONLY or UPLOAD_ONLY")
+	ErrTagNameEmpty      = errors.New("field Name must not be empty")
+	ErrTagNameInvalid    = errors.New("field Name must not contain slashes")
+	ErrTagNameTooLong    = errors.New("field Name must have at most 50 characters")
+	ErrMetadataTooLarge  = errors.New("metadata must have at most 50 entries")
+	ErrMetadataKey       = errors.New("metadata keys must not be empty and must have at most 64 characters")
+	ErrMetadataValue     = errors.New("metadata values must have at most 1024 characters")
+	ErrCommentEmpty      = errors.New("field Content must not be empty")
+	ErrCommentTooLong    = errors.New("field Content must have at most 4000 characters")
+	ErrLockMinutes       = errors.New("field Minutes must not be negative")
+)
 
 func ValidateUpdateFileRequest(req *model.UpdateFileRequest) error {
 	if req.Filename == "" {
@@ -15,3 +48,139 @@ func ValidateUpdateFileRequest(req *model.UpdateFileRequest) error {
 
 	return nil
 }
+
+func ValidateCreatePermissionRequest(req *model.CreatePermissionRequest) error {
+	if req.UserId == "" && req.Group == "" {
+		return ErrUserIdEmpty
+	}
+
+	if req.UserId != "" && req.Group != "" {
+		return ErrUserIdAndGroup
+	}
+
+	if req.Permission != entity.PermissionViewer && req.Permission != entity.PermissionEditor {
+		return ErrInvalidPermission
+	}
+
+	return nil
+}
+
+func ValidateTransferOwnershipRequest(req *model.TransferOwnershipRequest) error {
+	if req.UserId == "" {
+		return ErrRecipientEmpty
+	}
+
+	return nil
+}
+
+func ValidateCreateFolderRequest(req *model.CreateFolderRequest) error {
+	return validateFolderName(req.Name)
+}
+
+func ValidateUpdateFolderRequest(req *model.UpdateFolderRequest) error {
+	return validateFolderName(req.Name)
+}
+
+func validateFolderName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrFolderNameEmpty
+	}
+
+	if strings.ContainsAny(name, "/\\") {
+		return ErrFolderNameInvalid
+	}
+
+	return nil
+}
+
+func ValidateAddTagRequest(req *model.AddTagRequest) error {
+	name := strings.TrimSpace(req.Name)
+
+	if name == "" {
+		return ErrTagNameEmpty
+	}
+
+	if strings.ContainsAny(name, "/\\") {
+		return ErrTagNameInvalid
+	}
+
+	if utf8.RuneCountInString(name) > maxTagNameLength {
+		return ErrTagNameTooLong
+	}
+
+	return nil
+}
+
+func ValidateUpdateMetadataRequest(req model.UpdateMetadataRequest) error {
+	if len(req) > maxMetadataEntries {
+		return ErrMetadataTooLarge
+	}
+
+	for key, value := range req {
+		if strings.TrimSpace(key) == "" || utf8.RuneCountInString(key) > maxMetadataKeyLength {
+			return ErrMetadataKey
+		}
+
+		if utf8.RuneCountInString(value) > maxMetadataValueLength {
+			return ErrMetadataValue
+		}
+	}
+
+	return nil
+}
+
+func ValidateCreateCommentRequest(req *model.CreateCommentRequest) error {
+	return validateCommentContent(req.Content)
+}
+
+func ValidateUpdateCommentRequest(req *model.UpdateCommentRequest) error {
+	return validateCommentContent(req.Content)
+}
+
+func validateCommentContent(content string) error {
+	if strings.TrimSpace(content) == "" {
+		return ErrCommentEmpty
+	}
+
+	if utf8.RuneCountInString(content) > maxCommentLength {
+		return ErrCommentTooLong
+	}
+
+	return nil
+}
+
+func ValidateLockFileRequest(req *model.LockFileRequest) error {
+	if req.Minutes < 0 {
+		return ErrLockMinutes
+	}
+
+	return nil
+}
+
+func ValidateCreateAccessTokenRequest(req *model.CreateAccessTokenRequest) error {
+	if req.Name == "" {
+		return ErrTokenNameEmpty
+	}
+
+	if req.Scope != "" && req.Scope != entity.ScopeFull && req.Scope != entity.ScopeReadOnly && req.Scope != entity.ScopeUploadOnly {
+		return ErrInvalidScope
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return ErrExpiresAtInPast
+	}
+
+	return nil
+}
+
+func ValidateCreateLinkRequest(req *model.CreateLinkRequest) error {
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return ErrExpiresAtInPast
+	}
+
+	if req.MaxDownloads != nil && *req.MaxDownloads <= 0 {
+		return ErrMaxDownloads
+	}
+
+	return nil
+}
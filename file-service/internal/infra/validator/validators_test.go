@@ -1,7 +1,10 @@
 package validator_test
 
 import (
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/murilo-bracero/raspstore/file-service/internal/domain/model"
 	"github.com/murilo-bracero/raspstore/file-service/internal/infra/validator"
@@ -31,3 +34,293 @@ func TestValidateUpdateFileRequest(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateCreatePermissionRequest(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		req := &model.CreatePermissionRequest{
+			UserId:     "user1",
+			Permission: "EDITOR",
+		}
+
+		err := validator.ValidateCreatePermissionRequest(req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error ErrUserIdEmpty", func(t *testing.T) {
+		req := &model.CreatePermissionRequest{
+			Permission: "VIEWER",
+		}
+
+		err := validator.ValidateCreatePermissionRequest(req)
+
+		assert.Equal(t, validator.ErrUserIdEmpty, err)
+	})
+
+	t.Run("should accept group instead of user", func(t *testing.T) {
+		req := &model.CreatePermissionRequest{
+			Group:      "/family",
+			Permission: "VIEWER",
+		}
+
+		err := validator.ValidateCreatePermissionRequest(req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error ErrUserIdAndGroup", func(t *testing.T) {
+		req := &model.CreatePermissionRequest{
+			UserId:     "user1",
+			Group:      "/family",
+			Permission: "VIEWER",
+		}
+
+		err := validator.ValidateCreatePermissionRequest(req)
+
+		assert.Equal(t, validator.ErrUserIdAndGroup, err)
+	})
+
+	t.Run("should return error ErrInvalidPermission", func(t *testing.T) {
+		req := &model.CreatePermissionRequest{
+			UserId:     "user1",
+			Permission: "OWNER",
+		}
+
+		err := validator.ValidateCreatePermissionRequest(req)
+
+		assert.Equal(t, validator.ErrInvalidPermission, err)
+	})
+}
+
+func TestValidateTransferOwnershipRequest(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		req := &model.TransferOwnershipRequest{
+			UserId:       "user1",
+			KeepAsEditor: true,
+		}
+
+		err := validator.ValidateTransferOwnershipRequest(req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error ErrRecipientEmpty", func(t *testing.T) {
+		req := &model.TransferOwnershipRequest{}
+
+		err := validator.ValidateTransferOwnershipRequest(req)
+
+		assert.Equal(t, validator.ErrRecipientEmpty, err)
+	})
+}
+
+func TestValidateCreateLinkRequest(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		maxDownloads := int64(3)
+
+		req := &model.CreateLinkRequest{
+			ExpiresAt:    &expiresAt,
+			MaxDownloads: &maxDownloads,
+		}
+
+		err := validator.ValidateCreateLinkRequest(req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error ErrExpiresAtInPast", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+
+		req := &model.CreateLinkRequest{
+			ExpiresAt: &expiresAt,
+		}
+
+		err := validator.ValidateCreateLinkRequest(req)
+
+		assert.Equal(t, validator.ErrExpiresAtInPast, err)
+	})
+
+	t.Run("should return error ErrMaxDownloads", func(t *testing.T) {
+		maxDownloads := int64(0)
+
+		req := &model.CreateLinkRequest{
+			MaxDownloads: &maxDownloads,
+		}
+
+		err := validator.ValidateCreateLinkRequest(req)
+
+		assert.Equal(t, validator.ErrMaxDownloads, err)
+	})
+}
+
+func TestValidateCreateAccessTokenRequest(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+
+		req := &model.CreateAccessTokenRequest{
+			Name:      "backup-script",
+			Scope:     "READ_ONLY",
+			ExpiresAt: &expiresAt,
+		}
+
+		err := validator.ValidateCreateAccessTokenRequest(req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error ErrTokenNameEmpty", func(t *testing.T) {
+		req := &model.CreateAccessTokenRequest{}
+
+		err := validator.ValidateCreateAccessTokenRequest(req)
+
+		assert.Equal(t, validator.ErrTokenNameEmpty, err)
+	})
+
+	t.Run("should return error ErrInvalidScope", func(t *testing.T) {
+		req := &model.CreateAccessTokenRequest{
+			Name:  "backup-script",
+			Scope: "ADMIN",
+		}
+
+		err := validator.ValidateCreateAccessTokenRequest(req)
+
+		assert.Equal(t, validator.ErrInvalidScope, err)
+	})
+
+	t.Run("should return error ErrExpiresAtInPast", func(t *testing.T) {
+		expiresAt := time.Now().Add(-time.Hour)
+
+		req := &model.CreateAccessTokenRequest{
+			Name:      "backup-script",
+			ExpiresAt: &expiresAt,
+		}
+
+		err := validator.ValidateCreateAccessTokenRequest(req)
+
+		assert.Equal(t, validator.ErrExpiresAtInPast, err)
+	})
+}
+
+func TestValidateCreateFolderRequest(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		req := &model.CreateFolderRequest{Name: "photos"}
+
+		err := validator.ValidateCreateFolderRequest(req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error ErrFolderNameEmpty", func(t *testing.T) {
+		req := &model.CreateFolderRequest{Name: "  "}
+
+		err := validator.ValidateCreateFolderRequest(req)
+
+		assert.Equal(t, validator.ErrFolderNameEmpty, err)
+	})
+
+	t.Run("should return error ErrFolderNameInvalid", func(t *testing.T) {
+		req := &model.CreateFolderRequest{Name: "photos/2024"}
+
+		err := validator.ValidateCreateFolderRequest(req)
+
+		assert.Equal(t, validator.ErrFolderNameInvalid, err)
+	})
+}
+
+func TestValidateAddTagRequest(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		req := &model.AddTagRequest{Name: "Holidays 2024"}
+
+		err := validator.ValidateAddTagRequest(req)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error ErrTagNameEmpty", func(t *testing.T) {
+		req := &model.AddTagRequest{Name: " "}
+
+		err := validator.ValidateAddTagRequest(req)
+
+		assert.Equal(t, validator.ErrTagNameEmpty, err)
+	})
+
+	t.Run("should return error ErrTagNameInvalid", func(t *testing.T) {
+		req := &model.AddTagRequest{Name: "photos/2024"}
+
+		err := validator.ValidateAddTagRequest(req)
+
+		assert.Equal(t, validator.ErrTagNameInvalid, err)
+	})
+
+	t.Run("should return error ErrTagNameTooLong", func(t *testing.T) {
+		req := &model.AddTagRequest{Name: strings.Repeat("a", 51)}
+
+		err := validator.ValidateAddTagRequest(req)
+
+		assert.Equal(t, validator.ErrTagNameTooLong, err)
+	})
+}
+
+func TestValidateUpdateMetadataRequest(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		err := validator.ValidateUpdateMetadataRequest(model.UpdateMetadataRequest{"camera": "X100", "project": ""})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error ErrMetadataKey when key is empty", func(t *testing.T) {
+		err := validator.ValidateUpdateMetadataRequest(model.UpdateMetadataRequest{" ": "X100"})
+
+		assert.Equal(t, validator.ErrMetadataKey, err)
+	})
+
+	t.Run("should return error ErrMetadataValue", func(t *testing.T) {
+		err := validator.ValidateUpdateMetadataRequest(model.UpdateMetadataRequest{"notes": strings.Repeat("a", 1025)})
+
+		assert.Equal(t, validator.ErrMetadataValue, err)
+	})
+
+	t.Run("should return error ErrMetadataTooLarge", func(t *testing.T) {
+		req := model.UpdateMetadataRequest{}
+
+		for i := 0; i < 51; i++ {
+			req[strconv.Itoa(i)] = "value"
+		}
+
+		err := validator.ValidateUpdateMetadataRequest(req)
+
+		assert.Equal(t, validator.ErrMetadataTooLarge, err)
+	})
+}
+
+func TestValidateCreateCommentRequest(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		err := validator.ValidateCreateCommentRequest(&model.CreateCommentRequest{Content: "Great picture!"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("should return error ErrCommentEmpty", func(t *testing.T) {
+		err := validator.ValidateCreateCommentRequest(&model.CreateCommentRequest{ParentId: "commentId", Content: " \n"})
+
+		assert.Equal(t, validator.ErrCommentEmpty, err)
+	})
+
+	t.Run("should return error ErrCommentTooLong", func(t *testing.T) {
+		err := validator.ValidateUpdateCommentRequest(&model.UpdateCommentRequest{Content: strings.Repeat("a", 4001)})
+
+		assert.Equal(t, validator.ErrCommentTooLong, err)
+	})
+}
+
+func TestValidateLockFileRequest(t *testing.T) {
+	t.Run("happy path", func(t *testing.T) {
+		assert.NoError(t, validator.ValidateLockFileRequest(&model.LockFileRequest{}))
+	})
+
+	t.Run("should return error ErrLockMinutes", func(t *testing.T) {
+		err := validator.ValidateLockFileRequest(&model.LockFileRequest{Minutes: -5})
+
+		assert.Equal(t, validator.ErrLockMinutes, err)
+	})
+}
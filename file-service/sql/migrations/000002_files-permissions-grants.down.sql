@@ -0,0 +1 @@
+DROP INDEX files_permissions_file_id_user_id_idx;
\ No newline at end of file
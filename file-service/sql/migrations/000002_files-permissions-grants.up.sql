@@ -0,0 +1 @@
+CREATE UNIQUE INDEX IF NOT EXISTS files_permissions_file_id_user_id_idx ON files_permissions (file_id, user_id);
@@ -0,0 +1,3 @@
+DROP INDEX files_links_file_id_idx;
+
+DROP TABLE files_links;
\ No newline at end of file
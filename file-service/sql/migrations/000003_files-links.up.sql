@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS files_links (
+    token text primary key,
+    file_id text not null,
+    password_hash text,
+    expires_at int,
+    max_downloads int,
+    downloads int not null default 0,
+    created_at int not null,
+    created_by text not null,
+    FOREIGN KEY(file_id) REFERENCES files(file_id)
+);
+
+CREATE INDEX IF NOT EXISTS files_links_file_id_idx ON files_links (file_id);
@@ -0,0 +1,20 @@
+DELETE FROM files_permissions WHERE user_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS files_permissions_users (
+    permission_id text primary key,
+    file_id text not null,
+    permission text check(permission in ('EDITOR', 'VIEWER')) not null,
+    user_id text not null,
+    FOREIGN KEY(file_id) REFERENCES files(file_id)
+);
+
+INSERT INTO files_permissions_users (permission_id, file_id, permission, user_id)
+SELECT permission_id, file_id, permission, user_id FROM files_permissions;
+
+DROP TABLE files_permissions;
+
+ALTER TABLE files_permissions_users RENAME TO files_permissions;
+
+CREATE UNIQUE INDEX IF NOT EXISTS files_permissions_permission_id_idx ON files_permissions (permission_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS files_permissions_file_id_user_id_idx ON files_permissions (file_id, user_id);
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS files_permissions_groups (
+    permission_id text primary key,
+    file_id text not null,
+    permission text check(permission in ('EDITOR', 'VIEWER')) not null,
+    user_id text,
+    group_name text,
+    check((user_id IS NULL) != (group_name IS NULL)),
+    FOREIGN KEY(file_id) REFERENCES files(file_id)
+);
+
+INSERT INTO files_permissions_groups (permission_id, file_id, permission, user_id)
+SELECT permission_id, file_id, permission, user_id FROM files_permissions;
+
+DROP TABLE files_permissions;
+
+ALTER TABLE files_permissions_groups RENAME TO files_permissions;
+
+CREATE UNIQUE INDEX IF NOT EXISTS files_permissions_permission_id_idx ON files_permissions (permission_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS files_permissions_file_id_user_id_idx ON files_permissions (file_id, user_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS files_permissions_file_id_group_name_idx ON files_permissions (file_id, group_name);
@@ -0,0 +1,3 @@
+DROP INDEX admin_audit_created_at_idx;
+
+DROP TABLE admin_audit;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS admin_audit (
+    audit_id text primary key,
+    user_id text not null,
+    action text not null,
+    target_user_id text,
+    target_file_id text,
+    status int not null,
+    trace_id text not null,
+    created_at int not null
+);
+
+CREATE INDEX IF NOT EXISTS admin_audit_created_at_idx ON admin_audit (created_at);
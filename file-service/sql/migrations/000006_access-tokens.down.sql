@@ -0,0 +1,5 @@
+DROP INDEX access_tokens_user_id_idx;
+
+DROP INDEX access_tokens_token_hash_idx;
+
+DROP TABLE access_tokens;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS access_tokens (
+    token_id text primary key,
+    user_id text not null,
+    name text not null,
+    token_hash text not null,
+    scope text check(scope in ('FULL', 'READ_ONLY', 'UPLOAD_ONLY')) not null,
+    groups text not null default '[]',
+    expires_at int,
+    created_at int not null,
+    last_used_at int
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS access_tokens_token_hash_idx ON access_tokens (token_hash);
+
+CREATE INDEX IF NOT EXISTS access_tokens_user_id_idx ON access_tokens (user_id);
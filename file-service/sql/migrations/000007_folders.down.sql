@@ -0,0 +1,7 @@
+DROP INDEX files_parent_id_idx;
+
+ALTER TABLE files DROP COLUMN parent_id;
+
+DROP INDEX folders_owner_id_parent_id_idx;
+
+DROP TABLE folders;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS folders (
+    folder_id text primary key,
+    name text not null,
+    parent_id text,
+    owner_id text not null,
+    created_at int not null,
+    updated_at int,
+    created_by text not null,
+    updated_by text,
+    FOREIGN KEY(parent_id) REFERENCES folders(folder_id)
+);
+
+CREATE INDEX IF NOT EXISTS folders_owner_id_parent_id_idx ON folders (owner_id, parent_id);
+
+ALTER TABLE files ADD COLUMN parent_id text;
+
+CREATE INDEX IF NOT EXISTS files_parent_id_idx ON files (parent_id);
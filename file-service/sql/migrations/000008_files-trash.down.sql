@@ -0,0 +1,5 @@
+DROP INDEX files_deleted_at_idx;
+
+ALTER TABLE files DROP COLUMN deleted_by;
+
+ALTER TABLE files DROP COLUMN deleted_at;
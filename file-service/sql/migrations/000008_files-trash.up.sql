@@ -0,0 +1,5 @@
+ALTER TABLE files ADD COLUMN deleted_at int;
+
+ALTER TABLE files ADD COLUMN deleted_by text;
+
+CREATE INDEX IF NOT EXISTS files_deleted_at_idx ON files (deleted_at);
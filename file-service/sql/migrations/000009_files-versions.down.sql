@@ -0,0 +1,3 @@
+DROP INDEX files_versions_file_id_version_idx;
+
+DROP TABLE files_versions;
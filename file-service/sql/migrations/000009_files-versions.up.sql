@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS files_versions (
+    version_id text primary key,
+    file_id text not null,
+    version int not null,
+    size int not null,
+    created_at int not null,
+    created_by text not null,
+    FOREIGN KEY(file_id) REFERENCES files(file_id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS files_versions_file_id_version_idx ON files_versions (file_id, version);
@@ -0,0 +1,7 @@
+DROP INDEX files_tags_tag_id_idx;
+
+DROP TABLE files_tags;
+
+DROP INDEX tags_name_idx;
+
+DROP TABLE tags;
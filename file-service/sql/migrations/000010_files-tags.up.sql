@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS tags (
+    tag_id text primary key,
+    name text not null
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tags_name_idx ON tags (name);
+
+CREATE TABLE IF NOT EXISTS files_tags (
+    file_id text not null,
+    tag_id text not null,
+    created_at int not null,
+    created_by text not null,
+    PRIMARY KEY(file_id, tag_id),
+    FOREIGN KEY(file_id) REFERENCES files(file_id),
+    FOREIGN KEY(tag_id) REFERENCES tags(tag_id)
+);
+
+CREATE INDEX IF NOT EXISTS files_tags_tag_id_idx ON files_tags (tag_id);
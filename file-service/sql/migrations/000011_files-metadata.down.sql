@@ -0,0 +1,3 @@
+DROP INDEX files_metadata_key_value_idx;
+
+DROP TABLE files_metadata;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS files_metadata (
+    file_id text not null,
+    key text not null,
+    value text not null,
+    PRIMARY KEY(file_id, key),
+    FOREIGN KEY(file_id) REFERENCES files(file_id)
+);
+
+CREATE INDEX IF NOT EXISTS files_metadata_key_value_idx ON files_metadata (key, value);
@@ -0,0 +1,3 @@
+DROP INDEX files_stars_user_id_idx;
+
+DROP TABLE files_stars;
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS files_stars (
+    file_id text not null,
+    user_id text not null,
+    created_at int not null,
+    PRIMARY KEY(file_id, user_id),
+    FOREIGN KEY(file_id) REFERENCES files(file_id)
+);
+
+CREATE INDEX IF NOT EXISTS files_stars_user_id_idx ON files_stars (user_id);
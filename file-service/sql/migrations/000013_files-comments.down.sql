@@ -0,0 +1,3 @@
+DROP INDEX files_comments_file_id_idx;
+
+DROP TABLE files_comments;
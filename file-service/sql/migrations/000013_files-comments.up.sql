@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS files_comments (
+    comment_id text primary key,
+    file_id text not null,
+    parent_id text,
+    content text not null,
+    created_at int not null,
+    created_by text not null,
+    updated_at int,
+    FOREIGN KEY(file_id) REFERENCES files(file_id),
+    FOREIGN KEY(parent_id) REFERENCES files_comments(comment_id)
+);
+
+CREATE INDEX IF NOT EXISTS files_comments_file_id_idx ON files_comments (file_id);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS files_locks (
+    file_id text primary key,
+    locked_by text not null,
+    locked_at int not null,
+    expires_at int not null,
+    FOREIGN KEY(file_id) REFERENCES files(file_id)
+);
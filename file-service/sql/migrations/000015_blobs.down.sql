@@ -0,0 +1,2 @@
+DROP TABLE blobs_refs;
+DROP TABLE blobs;
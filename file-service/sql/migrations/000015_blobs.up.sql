@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS blobs (
+    hash text primary key,
+    size int not null,
+    ref_count int not null,
+    created_at int not null
+);
+
+CREATE TABLE IF NOT EXISTS blobs_refs (
+    blob_id text primary key,
+    hash text not null,
+    created_at int not null,
+    FOREIGN KEY(hash) REFERENCES blobs(hash)
+);
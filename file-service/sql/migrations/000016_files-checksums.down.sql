@@ -0,0 +1,5 @@
+ALTER TABLE files_versions DROP COLUMN checksum;
+
+ALTER TABLE files DROP COLUMN corrupted_at;
+
+ALTER TABLE files DROP COLUMN checksum;
@@ -0,0 +1,5 @@
+ALTER TABLE files ADD COLUMN checksum text;
+
+ALTER TABLE files ADD COLUMN corrupted_at int;
+
+ALTER TABLE files_versions ADD COLUMN checksum text;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS blob_deletions (
+    blob_id text primary key,
+    attempts int not null default 0,
+    last_error text,
+    created_at int not null
+);
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS upload_reservations (
+    reservation_id text primary key,
+    user_id text not null,
+    size int not null,
+    expires_at int not null
+);
+
+CREATE INDEX IF NOT EXISTS upload_reservations_user_id_idx ON upload_reservations (user_id);
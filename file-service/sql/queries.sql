@@ -2,65 +2,585 @@
 SELECT f.*, fp.*
 FROM files f
 LEFT JOIN files_permissions fp ON f.file_id = fp.file_id
-WHERE f.file_id = ?1
+WHERE f.file_id = sqlc.arg(file_id)
 AND (
-    f.owner_id = ?2 OR (f.file_id IN (
+    f.owner_id = sqlc.arg(owner_id) OR (f.file_id IN (
         SELECT ffp.file_id
         FROM files_permissions ffp
-        WHERE ffp.file_id = f.file_id AND ffp.user_id = ?2
+        WHERE ffp.file_id = f.file_id AND (
+            ffp.user_id = sqlc.arg(owner_id) OR
+            ffp.group_name IN (SELECT value FROM json_each(sqlc.arg(groups)))
+        )
     ) AND f.is_secret = FALSE)
-);
+)
+AND f.deleted_at IS NULL;
 
 -- name: CreateFile :exec
-INSERT INTO files (file_id, file_name, size, is_secret, owner_id, created_at, created_by)
-VALUES (?, ?, ?, ?, ?, ?, ?);
+INSERT INTO files (file_id, file_name, size, is_secret, owner_id, created_at, created_by, parent_id, checksum)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
 
--- name: DeleteFileByID :exec
-DELETE FROM files
-WHERE file_id IN (
+-- name: TrashFileByID :exec
+UPDATE files SET
+deleted_at = sqlc.arg(deleted_at),
+deleted_by = sqlc.arg(deleted_by)
+WHERE deleted_at IS NULL
+AND file_id IN (
     SELECT f.file_id 
     FROM files f
     LEFT JOIN files_permissions fp ON f.file_id = fp.file_id AND fp.permission = 'EDITOR' 
-    WHERE f.file_id = ?1
+    WHERE f.file_id = sqlc.arg(file_id)
     AND (
-        f.owner_id = ?2 OR
-        fp.user_id = ?2
+        f.owner_id = sqlc.arg(owner_id) OR
+        fp.user_id = sqlc.arg(owner_id) OR
+        fp.group_name IN (SELECT value FROM json_each(sqlc.arg(groups)))
     )
 );
 
 -- name: UpdateFileByID :exec
 UPDATE files SET 
-file_name = ?3,
-is_secret = ?4,
-updated_at = ?5,
-updated_by = ?6
+file_name = sqlc.arg(file_name),
+is_secret = sqlc.arg(is_secret),
+updated_at = sqlc.arg(updated_at),
+updated_by = sqlc.arg(updated_by)
 WHERE file_id IN (
     SELECT f.file_id
     FROM files f
     LEFT JOIN files_permissions fp ON f.file_id = fp.file_id AND fp.permission = 'EDITOR' 
-    WHERE f.file_id = ?1
+    WHERE f.file_id = sqlc.arg(file_id)
+    AND (
+        f.owner_id = sqlc.arg(owner_id) OR
+        fp.user_id = sqlc.arg(owner_id) OR
+        fp.group_name IN (SELECT value FROM json_each(sqlc.arg(groups)))
+    )
+);
+
+-- name: UpdateFileParentByID :execrows
+UPDATE files SET
+parent_id = sqlc.arg(parent_id),
+updated_at = sqlc.arg(updated_at),
+updated_by = sqlc.arg(updated_by)
+WHERE file_id IN (
+    SELECT f.file_id
+    FROM files f
+    LEFT JOIN files_permissions fp ON f.file_id = fp.file_id AND fp.permission = 'EDITOR'
+    WHERE f.file_id = sqlc.arg(file_id)
     AND (
-        f.owner_id = ?2 OR
-        fp.user_id = ?2
+        f.owner_id = sqlc.arg(owner_id) OR
+        fp.user_id = sqlc.arg(owner_id) OR
+        fp.group_name IN (SELECT value FROM json_each(sqlc.arg(groups)))
     )
 );
 
+-- name: UpdateFileOwnerByID :exec
+UPDATE files SET owner_id = ?, parent_id = NULL, updated_at = ?, updated_by = ? WHERE file_id = ?;
+
+-- name: UpdateFilesOwnerByOwnerID :execrows
+UPDATE files SET
+owner_id = sqlc.arg(new_owner_id),
+updated_at = sqlc.arg(updated_at),
+updated_by = sqlc.arg(updated_by)
+WHERE owner_id = sqlc.arg(owner_id);
+
 -- name: DeleteFilePermissionByFileID :exec
 DELETE FROM files_permissions WHERE file_id = ?;
 
 -- name: FindAllFiles :many
-SELECT f.*, COUNT() OVER() AS totalCount
+SELECT f.*, CAST(EXISTS (
+    SELECT 1 FROM files_stars fs WHERE fs.file_id = f.file_id AND fs.user_id = sqlc.arg(owner_id)
+) AS BOOLEAN) AS starred, COUNT() OVER() AS totalCount
 FROM files f
-LEFT JOIN files_permissions fp ON f.file_id = fp.file_id
-WHERE (f.owner_id = ?1 OR fp.user_id = ?1)
-AND f.file_name LIKE ?2
-AND f.is_secret = ?3
+WHERE (f.owner_id = sqlc.arg(owner_id) OR EXISTS (
+    SELECT 1
+    FROM files_permissions fp
+    WHERE fp.file_id = f.file_id AND (
+        fp.user_id = sqlc.arg(owner_id) OR
+        fp.group_name IN (SELECT value FROM json_each(sqlc.arg(groups)))
+    )
+))
+AND f.file_name LIKE sqlc.arg(file_name)
+AND f.is_secret = sqlc.arg(is_secret)
+AND f.deleted_at IS NULL
+AND (
+    CAST(sqlc.arg(folder_id) AS TEXT) = '' OR
+    (sqlc.arg(folder_id) = 'root' AND f.parent_id IS NULL) OR
+    f.parent_id = sqlc.arg(folder_id)
+)
+AND (
+    CAST(sqlc.arg(tag) AS TEXT) = '' OR EXISTS (
+        SELECT 1
+        FROM files_tags ft
+        JOIN tags t ON t.tag_id = ft.tag_id
+        WHERE ft.file_id = f.file_id AND t.name = sqlc.arg(tag)
+    )
+)
+AND NOT EXISTS (
+    SELECT 1
+    FROM json_each(sqlc.arg(metadata)) m
+    WHERE NOT EXISTS (
+        SELECT 1
+        FROM files_metadata fm
+        WHERE fm.file_id = f.file_id AND fm.key = m.key AND fm.value = m.value
+    )
+)
+AND (
+    CAST(sqlc.arg(starred) AS BOOLEAN) = FALSE OR EXISTS (
+        SELECT 1 FROM files_stars fs WHERE fs.file_id = f.file_id AND fs.user_id = sqlc.arg(owner_id)
+    )
+)
 ORDER BY f.created_at DESC
-LIMIT ?4
-OFFSET ?5;
+LIMIT sqlc.arg(limit)
+OFFSET sqlc.arg(offset);
+
+-- name: FindAllTrashedFiles :many
+SELECT f.*, COUNT() OVER() AS totalCount
+FROM files f
+WHERE f.owner_id = ?
+AND f.deleted_at IS NOT NULL
+ORDER BY f.deleted_at DESC
+LIMIT ?
+OFFSET ?;
+
+-- name: RestoreFileByID :execrows
+UPDATE files SET
+deleted_at = NULL,
+deleted_by = NULL,
+parent_id = (SELECT fo.folder_id FROM folders fo WHERE fo.folder_id = files.parent_id),
+updated_at = sqlc.arg(updated_at),
+updated_by = sqlc.arg(updated_by)
+WHERE file_id = sqlc.arg(file_id)
+AND owner_id = sqlc.arg(owner_id)
+AND deleted_at IS NOT NULL;
+
+-- name: FindExpiredTrashedFileIDs :many
+SELECT file_id FROM files
+WHERE deleted_at IS NOT NULL
+AND deleted_at < sqlc.arg(deleted_before)
+ORDER BY deleted_at
+LIMIT sqlc.arg(limit);
+
+-- name: FindFileByName :one
+SELECT * FROM files
+WHERE owner_id = sqlc.arg(owner_id)
+AND file_name = sqlc.arg(file_name)
+AND COALESCE(parent_id, '') = CAST(sqlc.arg(parent_id) AS TEXT)
+AND deleted_at IS NULL
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: UpdateFileContentByID :exec
+UPDATE files SET size = ?, checksum = ?, corrupted_at = NULL, updated_at = ?, updated_by = ? WHERE file_id = ?;
+
+-- name: FindChecksummedFiles :many
+SELECT file_id, checksum, corrupted_at FROM files
+WHERE checksum IS NOT NULL
+AND file_id > sqlc.arg(after_file_id)
+ORDER BY file_id
+LIMIT sqlc.arg(limit);
+
+-- name: UpdateFileCorruptedAtByID :exec
+UPDATE files SET corrupted_at = sqlc.arg(corrupted_at)
+WHERE file_id = sqlc.arg(file_id)
+AND COALESCE(checksum, '') = CAST(sqlc.arg(checksum) AS TEXT);
+
+-- name: FindAllFileSizes :many
+SELECT file_id, size, checksum, created_at, updated_at FROM files;
+
+-- name: UpdateFileSizeByID :exec
+UPDATE files SET size = ? WHERE file_id = ?;
+
+-- name: DeleteFileByID :exec
+DELETE FROM files WHERE file_id = ?;
 
 -- name: FindUsageByUserID :one
-SELECT SUM(f.size) as totalSize
+SELECT SUM(f.size) + COALESCE((
+    SELECT SUM(v.size)
+    FROM files_versions v
+    INNER JOIN files fv ON v.file_id = fv.file_id
+    WHERE fv.owner_id = f.owner_id
+), 0) as totalSize
 FROM files f
 WHERE f.owner_id = ?
-GROUP BY f.owner_id;
\ No newline at end of file
+GROUP BY f.owner_id;
+
+-- name: CreateFilePermission :exec
+INSERT INTO files_permissions (permission_id, file_id, permission, user_id)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (file_id, user_id) DO UPDATE SET permission = excluded.permission;
+
+-- name: CreateFileGroupPermission :exec
+INSERT INTO files_permissions (permission_id, file_id, permission, group_name)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (file_id, group_name) DO UPDATE SET permission = excluded.permission;
+
+-- name: DeleteFilePermissionByFileIDAndUserID :exec
+DELETE FROM files_permissions WHERE file_id = ? AND user_id = ?;
+
+-- name: DeleteFilePermissionByFileIDAndGroupName :exec
+DELETE FROM files_permissions WHERE file_id = ? AND group_name = ?;
+
+-- name: DeleteFilePermissionsByOwnerIDAndUserID :exec
+DELETE FROM files_permissions
+WHERE user_id = ?
+AND file_id IN (SELECT file_id FROM files WHERE owner_id = ?);
+
+-- name: CreateOwnerEditorPermissions :exec
+INSERT INTO files_permissions (permission_id, file_id, permission, user_id)
+SELECT lower(hex(randomblob(16))), file_id, 'EDITOR', owner_id
+FROM files
+WHERE owner_id = ? AND is_secret = FALSE
+ON CONFLICT (file_id, user_id) DO NOTHING;
+
+-- name: FindAllSharedFiles :many
+SELECT f.*, CAST(MIN(fp.permission) AS TEXT) AS permission, CAST(EXISTS (
+    SELECT 1 FROM files_stars fs WHERE fs.file_id = f.file_id AND fs.user_id = sqlc.arg(user_id)
+) AS BOOLEAN) AS starred, COUNT() OVER() AS totalCount
+FROM files f
+INNER JOIN files_permissions fp ON f.file_id = fp.file_id
+WHERE (
+    fp.user_id = sqlc.arg(user_id) OR
+    fp.group_name IN (SELECT value FROM json_each(sqlc.arg(groups)))
+)
+AND f.owner_id != sqlc.arg(user_id)
+AND f.is_secret = FALSE
+AND f.deleted_at IS NULL
+GROUP BY f.file_id
+ORDER BY f.created_at DESC
+LIMIT sqlc.arg(limit)
+OFFSET sqlc.arg(offset);
+
+-- name: CreateFileLink :exec
+INSERT INTO files_links (token, file_id, password_hash, expires_at, max_downloads, created_at, created_by)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: FindFileLinksByFileID :many
+SELECT * FROM files_links
+WHERE file_id = ?
+ORDER BY created_at DESC;
+
+-- name: FindFileLinkByToken :one
+SELECT fl.*, f.file_name, f.size
+FROM files_links fl
+INNER JOIN files f ON fl.file_id = f.file_id
+WHERE fl.token = ?
+AND f.deleted_at IS NULL
+AND f.is_secret = FALSE;
+
+-- name: DeleteFileLinksByFileID :exec
+DELETE FROM files_links WHERE file_id = ?;
+
+-- name: DeleteFileLink :exec
+DELETE FROM files_links WHERE file_id = ? AND token = ?;
+
+-- name: IncrementFileLinkDownloads :execrows
+UPDATE files_links SET downloads = downloads + 1
+WHERE token = ?
+AND (max_downloads IS NULL OR downloads < max_downloads);
+
+-- name: FindAllUsages :many
+SELECT owner_id, COUNT(*) AS files, CAST(SUM(size) + COALESCE((
+    SELECT SUM(v.size)
+    FROM files_versions v
+    INNER JOIN files fv ON v.file_id = fv.file_id
+    WHERE fv.owner_id = files.owner_id
+), 0) AS INTEGER) AS total_size, COUNT() OVER() AS totalCount
+FROM files
+GROUP BY owner_id
+ORDER BY total_size DESC
+LIMIT ?
+OFFSET ?;
+
+-- name: FindAllFilesByOwnerID :many
+SELECT f.*, COUNT() OVER() AS totalCount
+FROM files f
+WHERE f.owner_id = ?
+AND f.file_name LIKE ?
+ORDER BY f.created_at DESC
+LIMIT ?
+OFFSET ?;
+
+-- name: FindServerTotals :one
+SELECT COUNT(DISTINCT owner_id) AS users, COUNT(*) AS files,
+CAST(COALESCE(SUM(size), 0) + (SELECT COALESCE(SUM(v.size), 0) FROM files_versions v) AS INTEGER) AS total_size
+FROM files;
+
+-- name: CreateAdminAudit :exec
+INSERT INTO admin_audit (audit_id, user_id, action, target_user_id, target_file_id, status, trace_id, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: FindAllAdminAudits :many
+SELECT *, COUNT() OVER() AS totalCount
+FROM admin_audit
+ORDER BY created_at DESC
+LIMIT ?
+OFFSET ?;
+
+-- name: CreateAccessToken :exec
+INSERT INTO access_tokens (token_id, user_id, name, token_hash, scope, groups, expires_at, created_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: FindAccessTokensByUserID :many
+SELECT * FROM access_tokens WHERE user_id = ? ORDER BY created_at DESC;
+
+-- name: FindAccessTokenByHash :one
+SELECT * FROM access_tokens WHERE token_hash = ?;
+
+-- name: DeleteAccessToken :execrows
+DELETE FROM access_tokens WHERE token_id = ? AND user_id = ?;
+
+-- name: UpdateAccessTokenLastUsed :exec
+UPDATE access_tokens SET last_used_at = ? WHERE token_id = ?;
+
+-- name: CreateFolder :exec
+INSERT INTO folders (folder_id, name, parent_id, owner_id, created_at, created_by)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: FindFolderByID :one
+SELECT * FROM folders WHERE folder_id = ? AND owner_id = ?;
+
+-- name: FindFoldersByParentID :many
+SELECT * FROM folders
+WHERE owner_id = sqlc.arg(owner_id)
+AND COALESCE(parent_id, '') = CAST(sqlc.arg(parent_id) AS TEXT)
+ORDER BY name;
+
+-- name: FindFolderPath :many
+WITH RECURSIVE path (folder_id, name, parent_id, depth) AS (
+    SELECT fo.folder_id, fo.name, fo.parent_id, 0
+    FROM folders fo
+    WHERE fo.folder_id = ?
+    UNION ALL
+    SELECT fo.folder_id, fo.name, fo.parent_id, p.depth + 1
+    FROM folders fo
+    INNER JOIN path p ON fo.folder_id = p.parent_id
+)
+SELECT path.folder_id, path.name
+FROM path
+ORDER BY path.depth DESC;
+
+-- name: UpdateFolderByID :execrows
+UPDATE folders SET
+name = sqlc.arg(name),
+updated_at = sqlc.arg(updated_at),
+updated_by = sqlc.arg(updated_by)
+WHERE folder_id = sqlc.arg(folder_id) AND owner_id = sqlc.arg(owner_id);
+
+-- name: UpdateFoldersOwnerByOwnerID :exec
+UPDATE folders SET
+owner_id = sqlc.arg(new_owner_id),
+updated_at = sqlc.arg(updated_at),
+updated_by = sqlc.arg(updated_by)
+WHERE owner_id = sqlc.arg(owner_id);
+
+-- name: DeleteFolderByID :execrows
+DELETE FROM folders WHERE folder_id = ? AND owner_id = ?;
+
+-- name: CountFolderChildren :one
+SELECT
+    (SELECT COUNT(*) FROM folders fo WHERE fo.parent_id = sqlc.arg(folder_id)) +
+    (SELECT COUNT(*) FROM files f WHERE f.parent_id = sqlc.arg(folder_id) AND f.deleted_at IS NULL) AS children;
+
+-- name: CreateFileVersion :one
+INSERT INTO files_versions (version_id, file_id, version, size, created_at, created_by, checksum)
+SELECT sqlc.arg(version_id), sqlc.arg(file_id), COALESCE(MAX(version), 0) + 1, sqlc.arg(size), sqlc.arg(created_at), sqlc.arg(created_by), sqlc.arg(checksum)
+FROM files_versions
+WHERE file_id = sqlc.arg(file_id)
+RETURNING version;
+
+-- name: CreateNumberedFileVersion :exec
+INSERT INTO files_versions (version_id, file_id, version, size, created_at, created_by, checksum)
+VALUES (?, ?, ?, ?, ?, ?, ?);
+
+-- name: FindFileVersionsByFileID :many
+SELECT * FROM files_versions WHERE file_id = ? ORDER BY version DESC;
+
+-- name: FindFileVersion :one
+SELECT * FROM files_versions WHERE file_id = ? AND version = ?;
+
+-- name: DeleteFileVersionByID :exec
+DELETE FROM files_versions WHERE version_id = ?;
+
+-- name: DeleteFileVersionsByFileID :exec
+DELETE FROM files_versions WHERE file_id = ?;
+
+-- name: FindAllFileVersionSizes :many
+SELECT version_id, size, created_at FROM files_versions;
+
+-- name: UpdateFileVersionSizeByID :exec
+UPDATE files_versions SET size = ? WHERE version_id = ?;
+
+-- name: CreateTag :exec
+INSERT INTO tags (tag_id, name) VALUES (?, ?)
+ON CONFLICT (name) DO NOTHING;
+
+-- name: CreateFileTag :exec
+INSERT INTO files_tags (file_id, tag_id, created_at, created_by)
+SELECT sqlc.arg(file_id), t.tag_id, sqlc.arg(created_at), sqlc.arg(created_by)
+FROM tags t
+WHERE t.name = sqlc.arg(name)
+ON CONFLICT (file_id, tag_id) DO NOTHING;
+
+-- name: FindFileTagsByFileID :many
+SELECT t.name
+FROM files_tags ft
+JOIN tags t ON t.tag_id = ft.tag_id
+WHERE ft.file_id = ?
+ORDER BY t.name;
+
+-- name: FindAllTags :many
+SELECT t.name, COUNT(*) AS files
+FROM tags t
+JOIN files_tags ft ON ft.tag_id = t.tag_id
+JOIN files f ON f.file_id = ft.file_id
+WHERE (f.owner_id = sqlc.arg(owner_id) OR EXISTS (
+    SELECT 1
+    FROM files_permissions fp
+    WHERE fp.file_id = f.file_id AND (
+        fp.user_id = sqlc.arg(owner_id) OR
+        fp.group_name IN (SELECT value FROM json_each(sqlc.arg(groups)))
+    )
+))
+AND f.deleted_at IS NULL
+GROUP BY t.name
+ORDER BY t.name;
+
+-- name: DeleteFileTag :execrows
+DELETE FROM files_tags
+WHERE file_id = sqlc.arg(file_id)
+AND tag_id = (SELECT tag_id FROM tags WHERE name = sqlc.arg(name));
+
+-- name: DeleteFileTagsByFileID :exec
+DELETE FROM files_tags WHERE file_id = ?;
+
+-- name: CreateFileMetadata :exec
+INSERT INTO files_metadata (file_id, key, value) VALUES (?, ?, ?);
+
+-- name: FindFileMetadataByFileID :many
+SELECT * FROM files_metadata WHERE file_id = ? ORDER BY key;
+
+-- name: DeleteFileMetadataByFileID :exec
+DELETE FROM files_metadata WHERE file_id = ?;
+
+-- name: CreateFileStar :exec
+INSERT INTO files_stars (file_id, user_id, created_at) VALUES (?, ?, ?)
+ON CONFLICT (file_id, user_id) DO NOTHING;
+
+-- name: CountFileStars :one
+SELECT COUNT(*) FROM files_stars WHERE file_id = ? AND user_id = ?;
+
+-- name: DeleteFileStar :exec
+DELETE FROM files_stars WHERE file_id = ? AND user_id = ?;
+
+-- name: DeleteFileStarsByFileID :exec
+DELETE FROM files_stars WHERE file_id = ?;
+
+-- name: CreateFileComment :exec
+INSERT INTO files_comments (comment_id, file_id, parent_id, content, created_at, created_by)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: FindFileCommentsByFileID :many
+SELECT * FROM files_comments WHERE file_id = ? ORDER BY created_at;
+
+-- name: FindFileComment :one
+SELECT * FROM files_comments WHERE file_id = ? AND comment_id = ?;
+
+-- name: UpdateFileComment :exec
+UPDATE files_comments SET content = ?, updated_at = ? WHERE comment_id = ?;
+
+-- name: DeleteFileCommentThread :exec
+WITH RECURSIVE thread(comment_id) AS (
+    SELECT sqlc.arg(comment_id)
+    UNION ALL
+    SELECT c.comment_id FROM files_comments c JOIN thread t ON c.parent_id = t.comment_id
+)
+DELETE FROM files_comments WHERE comment_id IN (SELECT comment_id FROM thread);
+
+-- name: DeleteFileCommentsByFileID :exec
+DELETE FROM files_comments WHERE file_id = ?;
+
+-- name: CreateFileLock :execrows
+INSERT INTO files_locks (file_id, locked_by, locked_at, expires_at)
+VALUES (sqlc.arg(file_id), sqlc.arg(locked_by), sqlc.arg(locked_at), sqlc.arg(expires_at))
+ON CONFLICT (file_id) DO UPDATE SET
+    locked_by = excluded.locked_by,
+    locked_at = excluded.locked_at,
+    expires_at = excluded.expires_at
+WHERE files_locks.locked_by = excluded.locked_by OR files_locks.expires_at <= excluded.locked_at;
+
+-- name: FindFileLock :one
+SELECT * FROM files_locks WHERE file_id = ? AND expires_at > ?;
+
+-- name: DeleteFileLock :exec
+DELETE FROM files_locks WHERE file_id = ?;
+
+-- name: AcquireBlob :one
+INSERT INTO blobs (hash, size, ref_count, created_at)
+VALUES (sqlc.arg(hash), sqlc.arg(size), 1, sqlc.arg(created_at))
+ON CONFLICT (hash) DO UPDATE SET ref_count = blobs.ref_count + 1
+RETURNING ref_count;
+
+-- name: ReleaseBlob :one
+UPDATE blobs SET ref_count = ref_count - 1 WHERE hash = ? RETURNING ref_count;
+
+-- name: DeleteUnreferencedBlob :exec
+DELETE FROM blobs WHERE hash = ? AND ref_count <= 0;
+
+-- name: CreateBlobRef :exec
+INSERT INTO blobs_refs (blob_id, hash, created_at) VALUES (?, ?, ?);
+
+-- name: FindBlobRef :one
+SELECT r.blob_id, r.hash, b.size, r.created_at FROM blobs_refs r JOIN blobs b ON b.hash = r.hash WHERE r.blob_id = ?;
+
+-- name: FindAllBlobRefs :many
+SELECT r.blob_id, r.hash, b.size, r.created_at FROM blobs_refs r JOIN blobs b ON b.hash = r.hash ORDER BY r.blob_id;
+
+-- name: UpdateBlobRefID :exec
+UPDATE blobs_refs SET blob_id = sqlc.arg(to_blob_id) WHERE blob_id = sqlc.arg(from_blob_id);
+
+-- name: DeleteBlobRef :exec
+DELETE FROM blobs_refs WHERE blob_id = ?;
+
+-- name: CreateBlobDeletion :exec
+INSERT INTO blob_deletions (blob_id, created_at) VALUES (?, ?)
+ON CONFLICT (blob_id) DO NOTHING;
+
+-- name: CreateFileBlobDeletions :exec
+INSERT INTO blob_deletions (blob_id, created_at)
+SELECT f.file_id, sqlc.arg(created_at) FROM files f WHERE f.file_id = sqlc.arg(file_id)
+UNION ALL
+SELECT v.version_id, sqlc.arg(created_at) FROM files_versions v WHERE v.file_id = sqlc.arg(file_id)
+ON CONFLICT (blob_id) DO NOTHING;
+
+-- name: FindAllBlobDeletions :many
+SELECT * FROM blob_deletions ORDER BY created_at;
+
+-- name: UpdateBlobDeletionFailure :exec
+UPDATE blob_deletions SET attempts = attempts + 1, last_error = ? WHERE blob_id = ?;
+
+-- name: DeleteBlobDeletion :exec
+DELETE FROM blob_deletions WHERE blob_id = ?;
+
+-- name: CreateUploadReservation :execrows
+INSERT INTO upload_reservations (reservation_id, user_id, size, expires_at)
+SELECT sqlc.arg(reservation_id), sqlc.arg(user_id), sqlc.arg(size), sqlc.arg(expires_at)
+WHERE (
+    SELECT COALESCE(SUM(f.size), 0) FROM files f WHERE f.owner_id = sqlc.arg(user_id)
+) + (
+    SELECT COALESCE(SUM(v.size), 0)
+    FROM files_versions v
+    INNER JOIN files fv ON v.file_id = fv.file_id
+    WHERE fv.owner_id = sqlc.arg(user_id)
+) + (
+    SELECT COALESCE(SUM(r.size), 0) FROM upload_reservations r WHERE r.user_id = sqlc.arg(user_id) AND r.expires_at > sqlc.arg(now)
+) + sqlc.arg(size) <= sqlc.arg(quota);
+
+-- name: FindReservedSizeByUserID :one
+SELECT CAST(COALESCE(SUM(size), 0) AS INTEGER) FROM upload_reservations WHERE user_id = ? AND expires_at > ?;
+
+-- name: FindActiveUploadReservations :many
+SELECT * FROM upload_reservations WHERE expires_at > ?;
+
+-- name: DeleteExpiredUploadReservations :exec
+DELETE FROM upload_reservations WHERE expires_at <= ?;
+
+-- name: DeleteUploadReservation :exec
+DELETE FROM upload_reservations WHERE reservation_id = ?;